@@ -0,0 +1,210 @@
+// Copyright 2026 The go-xdgbasedir Authors. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package autostart
+
+import (
+	"errors"
+	"fmt"
+	"io/fs"
+	"path/filepath"
+	"sort"
+	"strings"
+
+	"github.com/zchee/go-xdgbasedir"
+	"github.com/zchee/go-xdgbasedir/desktopentry"
+	"github.com/zchee/go-xdgbasedir/keyfile"
+	"github.com/zchee/go-xdgbasedir/locale"
+)
+
+// ErrNotFound is returned if no autostart entry of the name is found.
+var ErrNotFound = errors.New("autostart: entry not found")
+
+// filePerm is the permission of the written user entries.
+const filePerm = 0644
+
+// dirPerm is the permission of the created user autostart directory.
+const dirPerm = 0700
+
+// Dirs returns the autostart directories of r's ConfigHome and each ConfigDirs in precedence order.
+// If r is nil, xdgbasedir.Default() is used.
+func Dirs(r *xdgbasedir.Resolver) []string {
+	if r == nil {
+		r = xdgbasedir.Default()
+	}
+	var dirs []string
+	for _, dir := range append([]string{r.ConfigHome()}, filepath.SplitList(r.ConfigDirs())...) {
+		if dir != "" {
+			dirs = append(dirs, filepath.Join(dir, "autostart"))
+		}
+	}
+	return dirs
+}
+
+// Files returns the paths of the effective autostart files resolved by r keyed by file name, where a file in a
+// higher precedence directory shadows the lower ones. If r is nil, xdgbasedir.Default() is used.
+func Files(r *xdgbasedir.Resolver) map[string]string {
+	if r == nil {
+		r = xdgbasedir.Default()
+	}
+	files := make(map[string]string)
+	for _, dir := range Dirs(r) {
+		entries, err := r.FS().ReadDir(dir)
+		if err != nil {
+			continue
+		}
+		for _, de := range entries {
+			name := de.Name()
+			if !strings.HasSuffix(name, desktopentry.Ext) {
+				continue
+			}
+			if _, ok := files[name]; !ok {
+				files[name] = filepath.Join(dir, name)
+			}
+		}
+	}
+	return files
+}
+
+// Entries returns the effective autostart entries resolved by r for the desktop environments, such as the list
+// returned by desktopentry.CurrentDesktops, sorted by file name. Hidden entries, entries which are not shown in
+// desktops by OnlyShowIn and NotShowIn, entries whose TryExec program is not installed, and malformed files are
+// skipped. If r is nil, xdgbasedir.Default() is used.
+func Entries(r *xdgbasedir.Resolver, desktops []string) []*desktopentry.Entry {
+	if r == nil {
+		r = xdgbasedir.Default()
+	}
+	files := Files(r)
+	names := make([]string, 0, len(files))
+	for name := range files {
+		names = append(names, name)
+	}
+	sort.Strings(names)
+
+	locales := locale.Preferences(r)
+	var entries []*desktopentry.Entry
+	for _, name := range names {
+		e, err := load(r, name, files[name], locales)
+		if err != nil || e.Hidden || !e.ShowIn(desktops) || !e.Installed(r) {
+			continue
+		}
+		entries = append(entries, e)
+	}
+	return entries
+}
+
+// Enabled reports whether the autostart entry name, such as "app.desktop", exists and is not Hidden.
+// If r is nil, xdgbasedir.Default() is used.
+func Enabled(r *xdgbasedir.Resolver, name string) bool {
+	if r == nil {
+		r = xdgbasedir.Default()
+	}
+	path, ok := Files(r)[name]
+	if !ok {
+		return false
+	}
+	e, err := load(r, name, path, nil)
+	return err == nil && !e.Hidden
+}
+
+// Enable enables the autostart entry name, such as "app.desktop", in the user directory. name must be a file name
+// with the .desktop extension.
+//
+// If data is not nil, it is written as the user entry. Otherwise, the Hidden key of the user entry which disables
+// a system entry is removed, and ErrNotFound is returned if there is neither a user nor a system entry.
+// If r is nil, xdgbasedir.Default() is used.
+func Enable(r *xdgbasedir.Resolver, name string, data []byte) error {
+	if r == nil {
+		r = xdgbasedir.Default()
+	}
+	if err := checkName(name); err != nil {
+		return err
+	}
+	userPath := filepath.Join(r.ConfigHome(), "autostart", name)
+	if data != nil {
+		return write(r, userPath, data)
+	}
+
+	f, err := readKeyFile(r, userPath)
+	if errors.Is(err, fs.ErrNotExist) {
+		if _, ok := Files(r)[name]; ok {
+			return nil
+		}
+		return fmt.Errorf("autostart: %s: %w", name, ErrNotFound)
+	}
+	if err != nil {
+		return err
+	}
+	if !f.HasKey(desktopentry.Group, "Hidden") {
+		return nil
+	}
+	f.DeleteKey(desktopentry.Group, "Hidden")
+	return write(r, userPath, f.Bytes())
+}
+
+// Disable disables the autostart entry name, such as "app.desktop", by setting Hidden=true in the user entry.
+// If there is only a system entry, it is copied to the user directory with Hidden=true, and the system file is
+// left as is. ErrNotFound is returned if there is no entry. If r is nil, xdgbasedir.Default() is used.
+func Disable(r *xdgbasedir.Resolver, name string) error {
+	if r == nil {
+		r = xdgbasedir.Default()
+	}
+	path, ok := Files(r)[name]
+	if !ok {
+		return fmt.Errorf("autostart: %s: %w", name, ErrNotFound)
+	}
+	f, err := readKeyFile(r, path)
+	if err != nil {
+		return err
+	}
+	if hidden, _ := f.Bool(desktopentry.Group, "Hidden"); hidden {
+		return nil
+	}
+	f.SetBool(desktopentry.Group, "Hidden", true)
+	return write(r, filepath.Join(r.ConfigHome(), "autostart", name), f.Bytes())
+}
+
+// checkName returns an error if name is not the file name of an autostart entry.
+func checkName(name string) error {
+	if strings.ContainsAny(name, `/`+string(filepath.Separator)) || !strings.HasSuffix(name, desktopentry.Ext) {
+		return fmt.Errorf("autostart: invalid entry name %q", name)
+	}
+	return nil
+}
+
+// load parses the autostart file path.
+func load(r *xdgbasedir.Resolver, name, path string, locales []string) (*desktopentry.Entry, error) {
+	data, err := r.FS().ReadFile(path)
+	if err != nil {
+		return nil, err
+	}
+	e, err := desktopentry.Parse(data, locales)
+	if err != nil {
+		return nil, fmt.Errorf("%s: %w", path, err)
+	}
+	e.ID = name
+	e.File = path
+	return e, nil
+}
+
+// readKeyFile reads the key file path.
+func readKeyFile(r *xdgbasedir.Resolver, path string) (*keyfile.File, error) {
+	data, err := r.FS().ReadFile(path)
+	if err != nil {
+		return nil, err
+	}
+	f, err := keyfile.ParseBytes(data)
+	if err != nil {
+		return nil, fmt.Errorf("%s: %w", path, err)
+	}
+	return f, nil
+}
+
+// write writes data to the user entry path atomically, creating the user autostart directory.
+func write(r *xdgbasedir.Resolver, path string, data []byte) error {
+	if err := r.FS().MkdirAll(filepath.Dir(path), dirPerm); err != nil {
+		return err
+	}
+	return xdgbasedir.WriteFileAtomic(r.FS(), path, data, filePerm)
+}
@@ -0,0 +1,113 @@
+// Copyright 2026 The go-xdgbasedir Authors. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package autostart_test
+
+import (
+	"errors"
+	"os"
+	"reflect"
+	"testing"
+
+	"github.com/zchee/go-xdgbasedir"
+	"github.com/zchee/go-xdgbasedir/autostart"
+	"github.com/zchee/go-xdgbasedir/xdgtest"
+)
+
+const system = "[Desktop Entry]\n# system entry\nType=Application\nName=Applet\nExec=applet\n"
+
+func TestEntries(t *testing.T) {
+	t.Parallel()
+
+	tr := xdgtest.New(t, xdgtest.WithFiles(map[string]string{
+		"home/.config/autostart/applet.desktop":   "[Desktop Entry]\nType=Application\nName=User Applet\nExec=applet --user\n",
+		"home/.config/autostart/disabled.desktop": "[Desktop Entry]\nType=Application\nName=Disabled\nExec=disabled\nHidden=true\n",
+		"etc/xdg/autostart/applet.desktop":        system,
+		"etc/xdg/autostart/disabled.desktop":      "[Desktop Entry]\nType=Application\nName=System\nExec=disabled\n",
+		"etc/xdg/autostart/gnome-only.desktop":    "[Desktop Entry]\nType=Application\nName=GNOME\nExec=gnome\nOnlyShowIn=GNOME;\n",
+		"etc/xdg/autostart/not-kde.desktop":       "[Desktop Entry]\nType=Application\nName=Not KDE\nExec=not-kde\nNotShowIn=KDE;\n",
+		"etc/xdg/autostart/tryexec.desktop":       "[Desktop Entry]\nType=Application\nName=TryExec\nExec=agent\nTryExec=agent\n",
+		"etc/xdg/autostart/missing.desktop":       "[Desktop Entry]\nType=Application\nName=Missing\nExec=missing\nTryExec=missing\n",
+		"etc/xdg/autostart/noexec.desktop":        "[Desktop Entry]\nType=Application\nName=Not Executable\nExec=noexec\nTryExec=noexec\n",
+		"etc/xdg/autostart/broken.desktop":        "Name=Broken\n",
+		"bin/agent":                               "",
+		"bin/noexec":                              "",
+	}))
+	if err := os.Chmod(tr.Path("bin/agent"), 0755); err != nil {
+		t.Fatal(err)
+	}
+	r := tr.Resolver(xdgbasedir.WithEnv(append(tr.Env(), "PATH="+tr.Path("bin"))))
+
+	tests := []struct {
+		desktops []string
+		want     []string
+	}{
+		{desktops: []string{"GNOME"}, want: []string{"applet.desktop=User Applet", "gnome-only.desktop=GNOME", "not-kde.desktop=Not KDE", "tryexec.desktop=TryExec"}},
+		{desktops: []string{"KDE"}, want: []string{"applet.desktop=User Applet", "tryexec.desktop=TryExec"}},
+	}
+	for _, tt := range tests {
+		var got []string
+		for _, e := range autostart.Entries(r, tt.desktops) {
+			got = append(got, e.ID+"="+e.Name)
+		}
+		if !reflect.DeepEqual(got, tt.want) {
+			t.Errorf("Entries(%q) = %q, want %q", tt.desktops, got, tt.want)
+		}
+	}
+}
+
+func TestEnableDisable(t *testing.T) {
+	t.Parallel()
+
+	tr := xdgtest.New(t, xdgtest.WithFiles(map[string]string{
+		"etc/xdg/autostart/applet.desktop": system,
+	}))
+	r := tr.Resolver()
+
+	if !autostart.Enabled(r, "applet.desktop") {
+		t.Fatal("Enabled(applet) = false before Disable")
+	}
+	if err := autostart.Disable(r, "applet.desktop"); err != nil {
+		t.Fatal(err)
+	}
+	if autostart.Enabled(r, "applet.desktop") {
+		t.Error("Enabled(applet) = true after Disable")
+	}
+	tr.AssertFileContent("home/.config/autostart/applet.desktop", system+"Hidden=true\n")
+	tr.AssertFileContent("etc/xdg/autostart/applet.desktop", system)
+
+	if err := autostart.Enable(r, "applet.desktop", nil); err != nil {
+		t.Fatal(err)
+	}
+	if !autostart.Enabled(r, "applet.desktop") {
+		t.Error("Enabled(applet) = false after Enable")
+	}
+	tr.AssertFileContent("home/.config/autostart/applet.desktop", system)
+	tr.AssertFileContent("etc/xdg/autostart/applet.desktop", system)
+
+	own := "[Desktop Entry]\nType=Application\nName=Own\nExec=own\n"
+	if err := autostart.Enable(r, "own.desktop", []byte(own)); err != nil {
+		t.Fatal(err)
+	}
+	tr.AssertFileContent("home/.config/autostart/own.desktop", own)
+	if got := autostart.Entries(r, nil); len(got) != 2 {
+		t.Errorf("Entries() = %d entries, want 2", len(got))
+	}
+
+	if err := autostart.Enable(r, "missing.desktop", nil); !errors.Is(err, autostart.ErrNotFound) {
+		t.Errorf("Enable(missing) error = %v, want ErrNotFound", err)
+	}
+	if err := autostart.Disable(r, "missing.desktop"); !errors.Is(err, autostart.ErrNotFound) {
+		t.Errorf("Disable(missing) error = %v, want ErrNotFound", err)
+	}
+	tr.AssertNotExist("home/.config/autostart/missing.desktop")
+
+	for _, name := range []string{"../escape.desktop", "sub/own.desktop", "own"} {
+		if err := autostart.Enable(r, name, []byte(own)); err == nil {
+			t.Errorf("Enable(%q) succeeded", name)
+		}
+	}
+	tr.AssertNotExist("home/.config/escape.desktop")
+	tr.AssertNotExist("home/.config/autostart/own")
+}
@@ -0,0 +1,12 @@
+// Copyright 2026 The go-xdgbasedir Authors. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+// Package autostart implements the freedesktop.org Desktop Application Autostart Specification.
+//
+//	https://specifications.freedesktop.org/autostart-spec/latest/
+//
+// Autostart entries are desktop entries in the autostart subdirectory of ConfigHome and each ConfigDirs. An entry
+// shadows the entries of the same file name in the lower precedence directories, and Hidden=true disables it.
+// Enable and Disable only write the user directory under ConfigHome, and never modify the system files.
+package autostart // import "github.com/zchee/go-xdgbasedir/autostart"
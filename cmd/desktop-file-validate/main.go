@@ -0,0 +1,57 @@
+// Copyright 2026 The go-xdgbasedir Authors. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+// Command desktop-file-validate validates desktop entry files against the Desktop Entry Specification.
+//
+// Usage:
+//
+//	desktop-file-validate [-no-warn] file.desktop...
+//
+// The exit status is 1 if any error is found, and 0 otherwise.
+package main
+
+import (
+	"flag"
+	"fmt"
+	"os"
+
+	"github.com/zchee/go-xdgbasedir/desktopentry"
+)
+
+var flagNoWarn = flag.Bool("no-warn", false, "do not print warnings")
+
+func main() {
+	flag.Usage = func() {
+		fmt.Fprintln(os.Stderr, "usage: desktop-file-validate [-no-warn] file.desktop...")
+		flag.PrintDefaults()
+	}
+	flag.Parse()
+	if flag.NArg() == 0 {
+		flag.Usage()
+		os.Exit(2)
+	}
+
+	failed := false
+	for _, name := range flag.Args() {
+		problems, err := desktopentry.ValidateFile(nil, name)
+		if err != nil {
+			fmt.Fprintf(os.Stderr, "desktop-file-validate: %v\n", err)
+			failed = true
+			continue
+		}
+		for _, p := range problems {
+			if p.Severity == desktopentry.Warning && *flagNoWarn {
+				continue
+			}
+			fmt.Println(p)
+		}
+		if problems.HasErrors() {
+			failed = true
+		}
+	}
+
+	if failed {
+		os.Exit(1)
+	}
+}
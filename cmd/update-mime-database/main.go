@@ -0,0 +1,35 @@
+// Copyright 2026 The go-xdgbasedir Authors. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+// Command update-mime-database compiles the shared-mime-info package files of a mime directory into the globs,
+// magic, mime.cache and the other files read by the applications.
+//
+// Usage:
+//
+//	update-mime-database [MIMEDIR]
+//
+// MIMEDIR defaults to the mime directory of XDG_DATA_HOME, where the package files are installed in the packages
+// subdirectory.
+package main
+
+import (
+	"flag"
+	"fmt"
+	"os"
+
+	"github.com/zchee/go-xdgbasedir/sharedmime"
+)
+
+func main() {
+	flag.Parse()
+
+	if flag.NArg() > 1 {
+		fmt.Fprintln(os.Stderr, "usage: update-mime-database [MIMEDIR]")
+		os.Exit(2)
+	}
+	if err := sharedmime.Update(nil, flag.Arg(0)); err != nil {
+		fmt.Fprintf(os.Stderr, "update-mime-database: %v\n", err)
+		os.Exit(1)
+	}
+}
@@ -0,0 +1,76 @@
+// Copyright 2026 The go-xdgbasedir Authors. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+// Command xdg-doctor audits the current environment against the XDG Base Directory Specification.
+//
+// Usage:
+//
+//	xdg-doctor [-json] [-min info|warning|error]
+//
+// The exit status is 1 if any error is found, and 0 otherwise.
+package main
+
+import (
+	"encoding/json"
+	"flag"
+	"fmt"
+	"os"
+
+	"github.com/zchee/go-xdgbasedir/doctor"
+)
+
+var (
+	flagJSON = flag.Bool("json", false, "print findings as JSON")
+	flagMin  = flag.String("min", "info", "minimum severity to print (info, warning or error)")
+)
+
+func main() {
+	flag.Parse()
+
+	minSev, err := parseSeverity(*flagMin)
+	if err != nil {
+		fmt.Fprintf(os.Stderr, "xdg-doctor: %v\n", err)
+		os.Exit(2)
+	}
+
+	findings := doctor.Run()
+	var out doctor.Findings
+	for _, f := range findings {
+		if f.Severity >= minSev {
+			out = append(out, f)
+		}
+	}
+
+	if *flagJSON {
+		enc := json.NewEncoder(os.Stdout)
+		enc.SetIndent("", "  ")
+		if out == nil {
+			out = doctor.Findings{}
+		}
+		if err := enc.Encode(out); err != nil {
+			fmt.Fprintf(os.Stderr, "xdg-doctor: %v\n", err)
+			os.Exit(2)
+		}
+	} else {
+		for _, f := range out {
+			fmt.Println(f)
+		}
+		if len(out) == 0 {
+			fmt.Println("no problems found")
+		}
+	}
+
+	if findings.Max() >= doctor.Error {
+		os.Exit(1)
+	}
+}
+
+func parseSeverity(s string) (doctor.Severity, error) {
+	for _, sev := range []doctor.Severity{doctor.Info, doctor.Warning, doctor.Error} {
+		if sev.String() == s {
+			return sev, nil
+		}
+	}
+	return 0, fmt.Errorf("unknown severity %q", s)
+}
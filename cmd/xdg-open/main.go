@@ -0,0 +1,52 @@
+// Copyright 2026 The go-xdgbasedir Authors. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+// Command xdg-open opens a file or URL in the user's preferred application.
+//
+// Usage:
+//
+//	xdg-open {FILE | URL}
+//
+// The exit status is 2 if the file does not exist, 3 if no application can open the target and 4 if the
+// application fails to launch, same as xdg-open of xdg-utils.
+package main
+
+import (
+	"errors"
+	"flag"
+	"fmt"
+	"io/fs"
+	"os"
+
+	"github.com/zchee/go-xdgbasedir/xdgopen"
+)
+
+func main() {
+	flag.Parse()
+
+	if flag.NArg() != 1 {
+		fmt.Fprintln(os.Stderr, "usage: xdg-open {FILE | URL}")
+		os.Exit(1)
+	}
+	if err := xdgopen.Open(flag.Arg(0)); err != nil {
+		fmt.Fprintf(os.Stderr, "xdg-open: %v\n", err)
+		os.Exit(exitCode(err))
+	}
+}
+
+// exitCode returns the exit status of the error of xdgopen.Open. An *xdgopen.Error is checked first, because
+// its candidate errors, such as a missing Exec program, must not be mistaken for a missing target file.
+func exitCode(err error) int {
+	var nerr *xdgopen.Error
+	switch {
+	case errors.As(err, &nerr):
+		if len(nerr.Errs) == 0 {
+			return 3
+		}
+		return 4
+	case errors.Is(err, fs.ErrNotExist):
+		return 2
+	}
+	return 4
+}
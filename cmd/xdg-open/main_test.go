@@ -0,0 +1,34 @@
+// Copyright 2026 The go-xdgbasedir Authors. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package main
+
+import (
+	"errors"
+	"fmt"
+	"io/fs"
+	"testing"
+
+	"github.com/zchee/go-xdgbasedir/xdgopen"
+)
+
+func TestExitCode(t *testing.T) {
+	launch := &fs.PathError{Op: "fork/exec", Path: "/opt/missing/bin/app", Err: fs.ErrNotExist}
+	tests := []struct {
+		name string
+		err  error
+		want int
+	}{
+		{name: "missing file", err: fmt.Errorf("xdgopen: %w", &fs.PathError{Op: "stat", Path: "/missing", Err: fs.ErrNotExist}), want: 2},
+		{name: "no handler", err: &xdgopen.Error{Target: "a.txt", MIMEType: "text/plain"}, want: 3},
+		{name: "launch failure", err: &xdgopen.Error{Target: "a.txt", MIMEType: "text/plain", Errs: []error{errors.New("exit status 1")}}, want: 4},
+		{name: "missing Exec program", err: &xdgopen.Error{Target: "a.txt", MIMEType: "text/plain", Errs: []error{launch}}, want: 4},
+		{name: "other", err: errors.New("xdgopen: broken database"), want: 4},
+	}
+	for _, tt := range tests {
+		if got := exitCode(tt.err); got != tt.want {
+			t.Errorf("exitCode(%s) = %d, want %d", tt.name, got, tt.want)
+		}
+	}
+}
@@ -0,0 +1,71 @@
+// Copyright 2026 The go-xdgbasedir Authors. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+// Command xdg-user-dirs-update creates the xdg-user-dirs user directories and writes user-dirs.dirs.
+//
+// Usage:
+//
+//	xdg-user-dirs-update [-catalog file.json]
+//	xdg-user-dirs-update -set NAME PATH
+//
+// The catalog is a JSON object of the translations of the default directory names keyed by locale,
+// such as {"de": {"Desktop": "Schreibtisch"}}.
+package main
+
+import (
+	"encoding/json"
+	"flag"
+	"fmt"
+	"os"
+	"strings"
+
+	"github.com/zchee/go-xdgbasedir/userdirs"
+)
+
+var (
+	flagSet     = flag.String("set", "", "set the user directory `NAME` to PATH")
+	flagCatalog = flag.String("catalog", "", "translate the default directory names with the JSON `file`")
+)
+
+func main() {
+	flag.Parse()
+
+	if *flagSet != "" {
+		if flag.NArg() != 1 {
+			fmt.Fprintln(os.Stderr, "usage: xdg-user-dirs-update -set NAME PATH")
+			os.Exit(2)
+		}
+		if err := userdirs.Set(nil, userdirs.Name(strings.ToUpper(*flagSet)), flag.Arg(0)); err != nil {
+			fmt.Fprintf(os.Stderr, "xdg-user-dirs-update: %v\n", err)
+			os.Exit(1)
+		}
+		return
+	}
+
+	var opts []userdirs.Option
+	if *flagCatalog != "" {
+		catalog, err := loadCatalog(*flagCatalog)
+		if err != nil {
+			fmt.Fprintf(os.Stderr, "xdg-user-dirs-update: %v\n", err)
+			os.Exit(2)
+		}
+		opts = append(opts, userdirs.WithCatalog(catalog))
+	}
+	if _, err := userdirs.Update(nil, opts...); err != nil {
+		fmt.Fprintf(os.Stderr, "xdg-user-dirs-update: %v\n", err)
+		os.Exit(1)
+	}
+}
+
+func loadCatalog(name string) (userdirs.Catalog, error) {
+	data, err := os.ReadFile(name)
+	if err != nil {
+		return nil, err
+	}
+	var catalog userdirs.Catalog
+	if err := json.Unmarshal(data, &catalog); err != nil {
+		return nil, fmt.Errorf("%s: %w", name, err)
+	}
+	return catalog, nil
+}
@@ -0,0 +1,45 @@
+// Copyright 2026 The go-xdgbasedir Authors. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package config
+
+import (
+	"bytes"
+	"encoding/json"
+	"errors"
+	"io"
+)
+
+// Codec encodes and decodes a configuration file format.
+//
+// Unmarshal must decode objects into map[string]interface{} and arrays into []interface{} when v is
+// a *interface{}, as encoding/json does. Marshal must accept the same generic values.
+type Codec interface {
+	Marshal(v interface{}) ([]byte, error)
+	Unmarshal(data []byte, v interface{}) error
+}
+
+// JSON is the Codec of the JSON format. Numbers are decoded as json.Number to keep their precision, and data after
+// the top-level value is an error.
+var JSON Codec = jsonCodec{}
+
+type jsonCodec struct{}
+
+// Marshal implements Codec.
+func (jsonCodec) Marshal(v interface{}) ([]byte, error) {
+	return json.Marshal(v)
+}
+
+// Unmarshal implements Codec.
+func (jsonCodec) Unmarshal(data []byte, v interface{}) error {
+	dec := json.NewDecoder(bytes.NewReader(data))
+	dec.UseNumber()
+	if err := dec.Decode(v); err != nil {
+		return err
+	}
+	if _, err := dec.Token(); err != io.EOF {
+		return errors.New("config: invalid JSON: data after the top-level value")
+	}
+	return nil
+}
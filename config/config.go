@@ -0,0 +1,320 @@
+// Copyright 2026 The go-xdgbasedir Authors. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package config
+
+import (
+	"errors"
+	"fmt"
+	"io/fs"
+	"path/filepath"
+	"reflect"
+	"sort"
+	"strconv"
+	"strings"
+
+	"github.com/zchee/go-xdgbasedir"
+)
+
+// Strategy is a merge strategy of a value which is set in more than one layer.
+type Strategy int
+
+const (
+	// Merge deep-merges objects, such as maps and structs, key by key. For arrays it is same as Replace.
+	Merge Strategy = iota
+	// Replace replaces the value of the lower precedence layer as a whole.
+	Replace
+	// Append appends the array elements of the higher precedence layer to the lower one.
+	// For objects it is same as Merge.
+	Append
+	// Union is same as Append, but skips the elements which are already in the array.
+	Union
+)
+
+// String implements fmt.Stringer.
+func (s Strategy) String() string {
+	switch s {
+	case Merge:
+		return "merge"
+	case Replace:
+		return "replace"
+	case Append:
+		return "append"
+	case Union:
+		return "union"
+	default:
+		return fmt.Sprintf("Strategy(%d)", int(s))
+	}
+}
+
+// options is the configuration of Load.
+type options struct {
+	resolver *xdgbasedir.Resolver
+	codec    Codec
+	objects  Strategy
+	arrays   Strategy
+	fields   map[string]Strategy
+}
+
+// Option configures Load.
+type Option func(*options)
+
+// WithResolver makes Load resolve the configuration directories with r. By default, xdgbasedir.Default().
+func WithResolver(r *xdgbasedir.Resolver) Option {
+	return func(o *options) {
+		o.resolver = r
+	}
+}
+
+// WithCodec sets the Codec of the configuration file format. By default, JSON.
+func WithCodec(c Codec) Option {
+	return func(o *options) {
+		o.codec = c
+	}
+}
+
+// WithObjectStrategy sets the default Strategy of objects, such as maps and structs. By default, Merge.
+func WithObjectStrategy(s Strategy) Option {
+	return func(o *options) {
+		o.objects = s
+	}
+}
+
+// WithArrayStrategy sets the default Strategy of arrays, such as slices. By default, Replace.
+func WithArrayStrategy(s Strategy) Option {
+	return func(o *options) {
+		o.arrays = s
+	}
+}
+
+// WithFieldStrategy sets the Strategy of the field at path, in the form of Result.Sources keys such as "server.plugins".
+func WithFieldStrategy(path string, s Strategy) Option {
+	return func(o *options) {
+		if o.fields == nil {
+			o.fields = make(map[string]Strategy)
+		}
+		o.fields[path] = s
+	}
+}
+
+// Result is the result of Load.
+type Result[T any] struct {
+	// Value is the merged configuration.
+	Value T
+	// Files is the list of loaded files, from the lowest precedence to the highest.
+	Files []string
+	// Sources maps each field path, such as "server.port" or "plugins[1]", to the file which supplied it.
+	Sources map[string]string
+}
+
+// Source returns the file which supplied the field at path. If path is not recorded, such as an element of
+// a replaced array, the file of the nearest recorded parent is returned.
+func (r *Result[T]) Source(path string) (string, bool) {
+	for {
+		if file, ok := r.Sources[path]; ok {
+			return file, true
+		}
+		i := strings.LastIndexAny(path, ".[")
+		if i < 0 {
+			return "", false
+		}
+		path = path[:i]
+	}
+}
+
+// Fields returns the sorted list of recorded field paths.
+func (r *Result[T]) Fields() []string {
+	paths := make([]string, 0, len(r.Sources))
+	for path := range r.Sources {
+		paths = append(paths, path)
+	}
+	sort.Strings(paths)
+	return paths
+}
+
+// Load loads the rel file from each ConfigDirs entry in reverse precedence order and then from ConfigHome,
+// deep-merges them and decodes the result into T.
+//
+// Missing files are skipped. If no file exists, the zero value of T is returned without error.
+func Load[T any](rel string, opts ...Option) (*Result[T], error) {
+	o := &options{
+		resolver: xdgbasedir.Default(),
+		codec:    JSON,
+		objects:  Merge,
+		arrays:   Replace,
+	}
+	for _, opt := range opts {
+		opt(o)
+	}
+
+	res := &Result[T]{Sources: make(map[string]string)}
+	var merged interface{}
+	for _, path := range layers(o.resolver, rel) {
+		data, err := o.resolver.FS().ReadFile(path)
+		if errors.Is(err, fs.ErrNotExist) {
+			continue
+		}
+		if err != nil {
+			return nil, err
+		}
+
+		var v interface{}
+		if err := o.codec.Unmarshal(data, &v); err != nil {
+			return nil, fmt.Errorf("config: decode %s: %w", path, err)
+		}
+		res.Files = append(res.Files, path)
+		merged = o.merge("", merged, v, path, res.Sources)
+	}
+	if merged == nil {
+		return res, nil
+	}
+
+	data, err := o.codec.Marshal(merged)
+	if err != nil {
+		return nil, fmt.Errorf("config: encode merged %s: %w", rel, err)
+	}
+	if err := o.codec.Unmarshal(data, &res.Value); err != nil {
+		return nil, fmt.Errorf("config: decode merged %s: %w", rel, err)
+	}
+	return res, nil
+}
+
+// layers returns the candidate paths of the rel file from the lowest precedence to the highest.
+func layers(r *xdgbasedir.Resolver, rel string) []string {
+	dirs := filepath.SplitList(r.ConfigDirs())
+	paths := make([]string, 0, len(dirs)+1)
+	for i := len(dirs) - 1; i >= 0; i-- {
+		if dirs[i] != "" {
+			paths = append(paths, filepath.Join(dirs[i], rel))
+		}
+	}
+	return append(paths, filepath.Join(r.ConfigHome(), rel))
+}
+
+// strategy returns the Strategy of the field at path whose value is an object or an array.
+func (o *options) strategy(path string, array bool) Strategy {
+	if s, ok := o.fields[path]; ok {
+		return s
+	}
+	if array {
+		return o.arrays
+	}
+	return o.objects
+}
+
+// merge merges src of the file over dst at path, and records the sources.
+func (o *options) merge(path string, dst, src interface{}, file string, sources map[string]string) interface{} {
+	switch s := src.(type) {
+	case map[string]interface{}:
+		d, ok := dst.(map[string]interface{})
+		if !ok || o.strategy(path, false) == Replace {
+			record(path, src, file, sources)
+			return src
+		}
+		for k, v := range s {
+			d[k] = o.merge(join(path, k), d[k], v, file, sources)
+		}
+		sources[path] = file
+		return d
+
+	case []interface{}:
+		d, ok := dst.([]interface{})
+		strategy := o.strategy(path, true)
+		if !ok || (strategy != Append && strategy != Union) {
+			record(path, src, file, sources)
+			return src
+		}
+		for _, v := range s {
+			if strategy == Union && contains(d, v) {
+				continue
+			}
+			record(index(path, len(d)), v, file, sources)
+			d = append(d, v)
+		}
+		sources[path] = file
+		return d
+
+	default:
+		record(path, src, file, sources)
+		return src
+	}
+}
+
+// record records file as the source of v at path and all of its descendants, dropping stale descendants.
+func record(path string, v interface{}, file string, sources map[string]string) {
+	for p := range sources {
+		if strings.HasPrefix(p, path+".") || strings.HasPrefix(p, path+"[") || (path == "" && p != "") {
+			delete(sources, p)
+		}
+	}
+	var walk func(path string, v interface{})
+	walk = func(path string, v interface{}) {
+		sources[path] = file
+		switch v := v.(type) {
+		case map[string]interface{}:
+			for k, e := range v {
+				walk(join(path, k), e)
+			}
+		case []interface{}:
+			for i, e := range v {
+				walk(index(path, i), e)
+			}
+		}
+	}
+	walk(path, v)
+}
+
+// join returns the field path of the key in the object at path.
+func join(path, key string) string {
+	if path == "" {
+		return key
+	}
+	return path + "." + key
+}
+
+// index returns the field path of the i-th element of the array at path.
+func index(path string, i int) string {
+	return path + "[" + strconv.Itoa(i) + "]"
+}
+
+// contains reports whether list contains a value equal to v.
+func contains(list []interface{}, v interface{}) bool {
+	for _, e := range list {
+		if equal(e, v) {
+			return true
+		}
+	}
+	return false
+}
+
+// equal reports whether the generic values a and b are equal.
+func equal(a, b interface{}) bool {
+	switch a := a.(type) {
+	case map[string]interface{}:
+		b, ok := b.(map[string]interface{})
+		if !ok || len(a) != len(b) {
+			return false
+		}
+		for k, v := range a {
+			if !equal(v, b[k]) {
+				return false
+			}
+		}
+		return true
+	case []interface{}:
+		b, ok := b.([]interface{})
+		if !ok || len(a) != len(b) {
+			return false
+		}
+		for i := range a {
+			if !equal(a[i], b[i]) {
+				return false
+			}
+		}
+		return true
+	default:
+		// a codec may decode to uncomparable types, such as map[interface{}]interface{}
+		return reflect.DeepEqual(a, b)
+	}
+}
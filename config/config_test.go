@@ -0,0 +1,196 @@
+// Copyright 2026 The go-xdgbasedir Authors. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package config_test
+
+import (
+	"encoding/json"
+	"path/filepath"
+	"reflect"
+	"strings"
+	"testing"
+
+	"github.com/zchee/go-xdgbasedir/config"
+	"github.com/zchee/go-xdgbasedir/xdgtest"
+)
+
+type Config struct {
+	Name    string            `json:"name"`
+	Server  Server            `json:"server"`
+	Plugins []string          `json:"plugins"`
+	Labels  map[string]string `json:"labels"`
+}
+
+type Server struct {
+	Host string `json:"host"`
+	Port int64  `json:"port"`
+}
+
+func TestLoad(t *testing.T) {
+	t.Parallel()
+
+	tr := xdgtest.New(t,
+		xdgtest.WithConfigDirs("etc/xdg", "usr/etc/xdg"),
+		xdgtest.WithFiles(map[string]string{
+			"usr/etc/xdg/app/config.json":  `{"name": "vendor", "server": {"host": "0.0.0.0", "port": 9007199254740993}, "plugins": ["a"], "labels": {"x": "1"}}`,
+			"etc/xdg/app/config.json":      `{"server": {"host": "localhost"}, "plugins": ["b", "a"], "labels": {"y": "2"}}`,
+			"home/.config/app/config.json": `{"name": "user", "plugins": ["c"]}`,
+		}),
+	)
+	usr := tr.Path("usr/etc/xdg/app/config.json")
+	etc := tr.Path("etc/xdg/app/config.json")
+	user := tr.Path("home/.config/app/config.json")
+
+	tests := []struct {
+		name    string
+		opts    []config.Option
+		want    Config
+		sources map[string]string
+	}{
+		{
+			name: "default strategies",
+			want: Config{
+				Name:    "user",
+				Server:  Server{Host: "localhost", Port: 9007199254740993},
+				Plugins: []string{"c"},
+				Labels:  map[string]string{"x": "1", "y": "2"},
+			},
+			sources: map[string]string{
+				"name":        user,
+				"server.host": etc,
+				"server.port": usr,
+				"plugins":     user,
+				"plugins[0]":  user,
+				"labels.x":    usr,
+				"labels.y":    etc,
+			},
+		},
+		{
+			name: "union plugins",
+			opts: []config.Option{config.WithFieldStrategy("plugins", config.Union)},
+			want: Config{
+				Name:    "user",
+				Server:  Server{Host: "localhost", Port: 9007199254740993},
+				Plugins: []string{"a", "b", "c"},
+				Labels:  map[string]string{"x": "1", "y": "2"},
+			},
+			sources: map[string]string{
+				"plugins[0]": usr,
+				"plugins[1]": etc,
+				"plugins[2]": user,
+			},
+		},
+		{
+			name: "append arrays and replace objects",
+			opts: []config.Option{
+				config.WithArrayStrategy(config.Append),
+				config.WithFieldStrategy("labels", config.Replace),
+			},
+			want: Config{
+				Name:    "user",
+				Server:  Server{Host: "localhost", Port: 9007199254740993},
+				Plugins: []string{"a", "b", "a", "c"},
+				Labels:  map[string]string{"y": "2"},
+			},
+			sources: map[string]string{
+				"plugins[2]": etc,
+				"plugins[3]": user,
+				"labels":     etc,
+				"labels.y":   etc,
+			},
+		},
+	}
+	for _, tt := range tests {
+		tt := tt
+		t.Run(tt.name, func(t *testing.T) {
+			t.Parallel()
+
+			opts := append([]config.Option{config.WithResolver(tr.Resolver())}, tt.opts...)
+			res, err := config.Load[Config](filepath.Join("app", "config.json"), opts...)
+			if err != nil {
+				t.Fatal(err)
+			}
+			if !reflect.DeepEqual(res.Value, tt.want) {
+				t.Errorf("Value = %+v, want %+v", res.Value, tt.want)
+			}
+			if want := []string{usr, etc, user}; !reflect.DeepEqual(res.Files, want) {
+				t.Errorf("Files = %v, want %v", res.Files, want)
+			}
+			for path, want := range tt.sources {
+				if got, _ := res.Source(path); got != want {
+					t.Errorf("Source(%q) = %v, want %v", path, got, want)
+				}
+			}
+			if _, ok := res.Sources["labels.x"]; tt.name == "append arrays and replace objects" && ok {
+				t.Error("stale source of replaced labels.x")
+			}
+		})
+	}
+}
+
+func TestLoadMissing(t *testing.T) {
+	t.Parallel()
+
+	tr := xdgtest.New(t)
+	res, err := config.Load[Config]("app/config.json", config.WithResolver(tr.Resolver()))
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(res.Files) != 0 || !reflect.DeepEqual(res.Value, Config{}) {
+		t.Errorf("Load() = %+v, want zero value", res)
+	}
+}
+
+func TestLoadDecodeError(t *testing.T) {
+	t.Parallel()
+
+	for _, data := range []string{`{`, `{"name": "user"} {"name": "trailing"}`, `{"name": "user"} x`} {
+		tr := xdgtest.New(t, xdgtest.WithFiles(map[string]string{
+			"home/.config/app/config.json": data,
+		}))
+		if _, err := config.Load[Config]("app/config.json", config.WithResolver(tr.Resolver())); err == nil {
+			t.Errorf("Load(%q) succeeded, want decode error", data)
+		}
+	}
+}
+
+// sliceCodec is a Codec which decodes the lines of a file into an array of uncomparable []string elements.
+type sliceCodec struct{}
+
+func (sliceCodec) Marshal(v interface{}) ([]byte, error) {
+	return json.Marshal(v)
+}
+
+func (sliceCodec) Unmarshal(data []byte, v interface{}) error {
+	p, ok := v.(*interface{})
+	if !ok {
+		return json.Unmarshal(data, v)
+	}
+	var tags []interface{}
+	for _, line := range strings.Fields(string(data)) {
+		tags = append(tags, []string{line})
+	}
+	*p = map[string]interface{}{"tags": tags}
+	return nil
+}
+
+func TestLoadUncomparable(t *testing.T) {
+	t.Parallel()
+
+	tr := xdgtest.New(t, xdgtest.WithFiles(map[string]string{
+		"etc/xdg/app/tags":      "a b",
+		"home/.config/app/tags": "b c",
+	}))
+	res, err := config.Load[map[string][][]string]("app/tags",
+		config.WithResolver(tr.Resolver()),
+		config.WithCodec(sliceCodec{}),
+		config.WithArrayStrategy(config.Union),
+	)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if want := map[string][][]string{"tags": {{"a"}, {"b"}, {"c"}}}; !reflect.DeepEqual(res.Value, want) {
+		t.Errorf("Value = %v, want %v", res.Value, want)
+	}
+}
@@ -0,0 +1,15 @@
+// Copyright 2026 The go-xdgbasedir Authors. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+// Package config implements layered, typed configuration loading across the XDG configuration directories.
+//
+// Load reads the same relative file from each ConfigDirs entry in reverse precedence order and then from
+// ConfigHome, and deep-merges them, so that
+//
+//	/etc/xdg/app/config.json
+//	~/.config/app/config.json
+//
+// are combined with the user configuration taking precedence. Each layer is decoded with a pluggable Codec,
+// and the Result reports which file supplied each field.
+package config // import "github.com/zchee/go-xdgbasedir/config"
@@ -0,0 +1,74 @@
+// Copyright 2026 The go-xdgbasedir Authors. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package xdgbasedir
+
+import (
+	"path/filepath"
+)
+
+// dirPerm is the permission of the directories created by the file operations.
+//
+// The specification says: If, when attempting to write a file, the destination directory is non-existent
+// an attempt should be made to create it with permission 0700.
+const dirPerm = 0700
+
+// ConfigFile returns the path of the rel file under ConfigHome, creating its parent directories with permission 0700.
+func ConfigFile(rel string) (string, error) {
+	return std.ConfigFile(rel)
+}
+
+// DataFile returns the path of the rel file under DataHome, creating its parent directories with permission 0700.
+func DataFile(rel string) (string, error) {
+	return std.DataFile(rel)
+}
+
+// StateFile returns the path of the rel file under StateHome, creating its parent directories with permission 0700.
+func StateFile(rel string) (string, error) {
+	return std.StateFile(rel)
+}
+
+// CacheFile returns the path of the rel file under CacheHome, creating its parent directories with permission 0700.
+func CacheFile(rel string) (string, error) {
+	return std.CacheFile(rel)
+}
+
+// RuntimeFile returns the path of the rel file under RuntimeDir, creating its parent directories with permission 0700.
+func RuntimeFile(rel string) (string, error) {
+	return std.RuntimeFile(rel)
+}
+
+// ConfigFile returns the path of the rel file under r's ConfigHome, creating its parent directories with permission 0700.
+func (r *Resolver) ConfigFile(rel string) (string, error) {
+	return r.create(r.ConfigHome(), rel)
+}
+
+// DataFile returns the path of the rel file under r's DataHome, creating its parent directories with permission 0700.
+func (r *Resolver) DataFile(rel string) (string, error) {
+	return r.create(r.DataHome(), rel)
+}
+
+// StateFile returns the path of the rel file under r's StateHome, creating its parent directories with permission 0700.
+func (r *Resolver) StateFile(rel string) (string, error) {
+	return r.create(r.StateHome(), rel)
+}
+
+// CacheFile returns the path of the rel file under r's CacheHome, creating its parent directories with permission 0700.
+func (r *Resolver) CacheFile(rel string) (string, error) {
+	return r.create(r.CacheHome(), rel)
+}
+
+// RuntimeFile returns the path of the rel file under r's RuntimeDir, creating its parent directories with permission 0700.
+func (r *Resolver) RuntimeFile(rel string) (string, error) {
+	return r.create(r.RuntimeDir(), rel)
+}
+
+// create returns the path of the rel file under base, creating its parent directories in r's FileSystem.
+func (r *Resolver) create(base, rel string) (string, error) {
+	path := filepath.Join(base, rel)
+	if err := r.FS().MkdirAll(filepath.Dir(path), dirPerm); err != nil {
+		return "", err
+	}
+	return path, nil
+}
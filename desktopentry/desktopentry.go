@@ -0,0 +1,394 @@
+// Copyright 2026 The go-xdgbasedir Authors. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package desktopentry
+
+import (
+	"errors"
+	"fmt"
+	"io/fs"
+	"path/filepath"
+	"runtime"
+	"sort"
+	"strings"
+
+	"github.com/zchee/go-xdgbasedir"
+	"github.com/zchee/go-xdgbasedir/keyfile"
+	"github.com/zchee/go-xdgbasedir/locale"
+)
+
+// Group is the name of the main group of a desktop entry.
+const Group = "Desktop Entry"
+
+// ActionGroupPrefix is the prefix of the group names of the additional application actions.
+const ActionGroupPrefix = "Desktop Action "
+
+// Ext is the file name extension of the desktop entries.
+const Ext = ".desktop"
+
+// List of the types of desktop entries.
+const (
+	Application = "Application"
+	Link        = "Link"
+	Directory   = "Directory"
+)
+
+// ErrNotFound is returned if no desktop entry of the desktop-file ID is found.
+var ErrNotFound = errors.New("desktopentry: desktop entry not found")
+
+// Entry is a parsed desktop entry. The localestring values are localized for the locales given to Parse.
+type Entry struct {
+	ID   string // desktop-file ID, such as "org.gnome.gedit.desktop"
+	File string // path of the desktop file
+
+	Type            string
+	Version         string
+	Name            string
+	GenericName     string
+	Comment         string
+	Icon            string
+	Exec            string
+	TryExec         string
+	Path            string // working directory
+	URL             string
+	Terminal        bool
+	NoDisplay       bool
+	Hidden          bool
+	DBusActivatable bool
+	StartupNotify   bool
+	StartupWMClass  string
+	Categories      []string
+	MimeType        []string
+	Keywords        []string
+	OnlyShowIn      []string
+	NotShowIn       []string
+	Actions         []Action
+
+	// KeyFile is the underlying key file, for the keys which are not parsed, such as the X- extensions.
+	KeyFile *keyfile.File
+}
+
+// Action is an additional application action of a desktop entry.
+type Action struct {
+	ID   string
+	Name string
+	Icon string
+	Exec string
+}
+
+// Parse parses a desktop entry from data, localizing the localestring values for locales, such as the list
+// returned by locale.Preferences.
+func Parse(data []byte, locales []string) (*Entry, error) {
+	f, err := keyfile.ParseBytes(data)
+	if err != nil {
+		return nil, err
+	}
+	if groups := f.Groups(); len(groups) == 0 || groups[0] != Group {
+		return nil, fmt.Errorf("desktopentry: first group is not %q", Group)
+	}
+
+	g := getter{f: f, group: Group, locales: locales}
+	e := &Entry{
+		Type:            g.string("Type"),
+		Version:         g.string("Version"),
+		Name:            g.localeString("Name"),
+		GenericName:     g.localeString("GenericName"),
+		Comment:         g.localeString("Comment"),
+		Icon:            g.localeString("Icon"),
+		Exec:            g.string("Exec"),
+		TryExec:         g.string("TryExec"),
+		Path:            g.string("Path"),
+		URL:             g.string("URL"),
+		Terminal:        g.bool("Terminal"),
+		NoDisplay:       g.bool("NoDisplay"),
+		Hidden:          g.bool("Hidden"),
+		DBusActivatable: g.bool("DBusActivatable"),
+		StartupNotify:   g.bool("StartupNotify"),
+		StartupWMClass:  g.string("StartupWMClass"),
+		Categories:      g.stringList("Categories"),
+		MimeType:        g.stringList("MimeType"),
+		Keywords:        g.localeStringList("Keywords"),
+		OnlyShowIn:      g.stringList("OnlyShowIn"),
+		NotShowIn:       g.stringList("NotShowIn"),
+		KeyFile:         f,
+	}
+	for _, id := range g.stringList("Actions") {
+		group := ActionGroupPrefix + id
+		if !f.HasGroup(group) {
+			continue
+		}
+		ag := getter{f: f, group: group, locales: locales}
+		e.Actions = append(e.Actions, Action{
+			ID:   id,
+			Name: ag.localeString("Name"),
+			Icon: ag.localeString("Icon"),
+			Exec: ag.string("Exec"),
+		})
+	}
+
+	return e, nil
+}
+
+// getter gets the values of a group ignoring errors, same as the desktop environments do for malformed values.
+type getter struct {
+	f       *keyfile.File
+	group   string
+	locales []string
+}
+
+func (g getter) string(key string) string {
+	v, _ := g.f.String(g.group, key)
+	return v
+}
+
+func (g getter) localeString(key string) string {
+	v, _ := g.f.LocaleString(g.group, key, g.locales...)
+	return v
+}
+
+func (g getter) bool(key string) bool {
+	v, _ := g.f.Bool(g.group, key)
+	return v
+}
+
+func (g getter) stringList(key string) []string {
+	v, _ := g.f.StringList(g.group, key)
+	return v
+}
+
+func (g getter) localeStringList(key string) []string {
+	v, _ := g.f.LocaleStringList(g.group, key, g.locales...)
+	return v
+}
+
+// ShowIn reports whether e should be shown in any of the desktop environments, such as the list returned by
+// CurrentDesktops, according to OnlyShowIn and NotShowIn. An empty desktops matches no OnlyShowIn.
+func (e *Entry) ShowIn(desktops []string) bool {
+	for _, d := range desktops {
+		if contains(e.NotShowIn, d) {
+			return false
+		}
+		if contains(e.OnlyShowIn, d) {
+			return true
+		}
+	}
+	return len(e.OnlyShowIn) == 0
+}
+
+// Installed reports whether the TryExec program of e is an executable file found in the $PATH of r's environment,
+// which means the application is installed. It is true if e has no TryExec. If r is nil, xdgbasedir.Default() is used.
+func (e *Entry) Installed(r *xdgbasedir.Resolver) bool {
+	if e.TryExec == "" {
+		return true
+	}
+	if r == nil {
+		r = xdgbasedir.Default()
+	}
+	return lookPath(r, e.TryExec) != ""
+}
+
+// lookPath searches the executable program in the $PATH of r's environment, and returns its path or an empty string.
+func lookPath(r *xdgbasedir.Resolver, prog string) string {
+	if filepath.IsAbs(prog) {
+		if isExecutable(r, prog) {
+			return prog
+		}
+		return ""
+	}
+	for _, dir := range filepath.SplitList(r.Getenv("PATH")) {
+		if dir == "" {
+			continue
+		}
+		if path := filepath.Join(dir, prog); isExecutable(r, path) {
+			return path
+		}
+	}
+	return ""
+}
+
+// isExecutable reports whether path is a regular file with an executable mode bit in r's FileSystem. If r has a
+// sysroot, path is a path of the target root filesystem. On windows, which has no executable mode bits, any regular
+// file is executable.
+func isExecutable(r *xdgbasedir.Resolver, path string) bool {
+	if root, ok := r.Sysroot(); ok {
+		path = filepath.Join(root, path)
+	}
+	fi, err := r.Stat(path)
+	if err != nil || fi.IsDir() {
+		return false
+	}
+	return runtime.GOOS == "windows" || fi.Mode().Perm()&0111 != 0
+}
+
+// CurrentDesktops returns the XDG_CURRENT_DESKTOP list of r's environment, such as ["ubuntu", "GNOME"].
+// If r is nil, xdgbasedir.Default() is used.
+func CurrentDesktops(r *xdgbasedir.Resolver) []string {
+	if r == nil {
+		r = xdgbasedir.Default()
+	}
+	var desktops []string
+	for _, d := range strings.Split(r.Getenv("XDG_CURRENT_DESKTOP"), ":") {
+		if d != "" {
+			desktops = append(desktops, d)
+		}
+	}
+	return desktops
+}
+
+// FileID returns the desktop-file ID of the desktop file path under the applications directory dir.
+func FileID(dir, path string) (string, error) {
+	rel, err := filepath.Rel(dir, path)
+	if err != nil {
+		return "", err
+	}
+	if rel == ".." || strings.HasPrefix(rel, ".."+string(filepath.Separator)) {
+		return "", fmt.Errorf("desktopentry: %s is not under %s", path, dir)
+	}
+	return strings.ReplaceAll(filepath.ToSlash(rel), "/", "-"), nil
+}
+
+// Dirs returns the applications directories of r's DataHome and each DataDirs in precedence order.
+func Dirs(r *xdgbasedir.Resolver) []string {
+	if r == nil {
+		r = xdgbasedir.Default()
+	}
+	var dirs []string
+	for _, dir := range append([]string{r.DataHome()}, filepath.SplitList(r.DataDirs())...) {
+		if dir != "" {
+			dirs = append(dirs, filepath.Join(dir, "applications"))
+		}
+	}
+	return dirs
+}
+
+// Files returns the paths of the effective desktop files resolved by r keyed by desktop-file ID, where an entry in
+// a higher precedence directory shadows the lower ones. If r is nil, xdgbasedir.Default() is used.
+func Files(r *xdgbasedir.Resolver) map[string]string {
+	if r == nil {
+		r = xdgbasedir.Default()
+	}
+	files := make(map[string]string)
+	for _, dir := range Dirs(r) {
+		walk(r, dir, func(path string) {
+			id, err := FileID(dir, path)
+			if err != nil {
+				return
+			}
+			if _, ok := files[id]; !ok {
+				files[id] = path
+			}
+		})
+	}
+	return files
+}
+
+// walk calls fn for each desktop file under dir in r's FileSystem recursively, following symbolic links inside the
+// sysroot of r, if any. A symbolic link to a directory being walked, such as a link to ".", is skipped so that a
+// loop does not recurse forever.
+func walk(r *xdgbasedir.Resolver, dir string, fn func(path string)) {
+	walkDir(r, dir, make(map[string]bool), fn)
+}
+
+// walkDir implements walk, where ancestors is the set of the real paths of the directories being walked.
+func walkDir(r *xdgbasedir.Resolver, dir string, ancestors map[string]bool, fn func(path string)) {
+	resolved, err := r.EvalSymlinks(dir)
+	if err != nil || ancestors[resolved] {
+		return
+	}
+	ancestors[resolved] = true
+	defer delete(ancestors, resolved)
+
+	entries, err := r.ReadDir(dir)
+	if err != nil {
+		return
+	}
+	for _, de := range entries {
+		path := filepath.Join(dir, de.Name())
+		isDir := de.IsDir()
+		if de.Type()&fs.ModeSymlink != 0 {
+			fi, err := r.Stat(path)
+			if err != nil {
+				continue
+			}
+			isDir = fi.IsDir()
+		}
+		switch {
+		case isDir:
+			walkDir(r, path, ancestors, fn)
+		case strings.HasSuffix(de.Name(), Ext):
+			fn(path)
+		}
+	}
+}
+
+// Load loads the effective desktop entry of the desktop-file ID resolved by r, localized for the locale of r's
+// environment. It returns ErrNotFound if there is none or the entry is Hidden. If r is nil, xdgbasedir.Default()
+// is used.
+func Load(r *xdgbasedir.Resolver, id string) (*Entry, error) {
+	if r == nil {
+		r = xdgbasedir.Default()
+	}
+	path, ok := Files(r)[id]
+	if !ok {
+		return nil, fmt.Errorf("desktopentry: %s: %w", id, ErrNotFound)
+	}
+	e, err := loadFile(r, id, path, locale.Preferences(r))
+	if err != nil {
+		return nil, err
+	}
+	if e.Hidden {
+		return nil, fmt.Errorf("desktopentry: %s: %w", id, ErrNotFound)
+	}
+	return e, nil
+}
+
+// Applications returns the effective desktop entries resolved by r sorted by desktop-file ID, localized for the
+// locale of r's environment. Hidden entries, which mean deleted, and malformed files are skipped. NoDisplay entries
+// are included. If r is nil, xdgbasedir.Default() is used.
+func Applications(r *xdgbasedir.Resolver) []*Entry {
+	if r == nil {
+		r = xdgbasedir.Default()
+	}
+	files := Files(r)
+	ids := make([]string, 0, len(files))
+	for id := range files {
+		ids = append(ids, id)
+	}
+	sort.Strings(ids)
+
+	locales := locale.Preferences(r)
+	var entries []*Entry
+	for _, id := range ids {
+		e, err := loadFile(r, id, files[id], locales)
+		if err != nil || e.Hidden {
+			continue
+		}
+		entries = append(entries, e)
+	}
+	return entries
+}
+
+// loadFile parses the desktop file path, following symbolic links inside the sysroot of r, if any.
+func loadFile(r *xdgbasedir.Resolver, id, path string, locales []string) (*Entry, error) {
+	data, err := r.ReadFile(path)
+	if err != nil {
+		return nil, err
+	}
+	e, err := Parse(data, locales)
+	if err != nil {
+		return nil, fmt.Errorf("%s: %w", path, err)
+	}
+	e.ID = id
+	e.File = path
+	return e, nil
+}
+
+func contains(list []string, s string) bool {
+	for _, e := range list {
+		if e == s {
+			return true
+		}
+	}
+	return false
+}
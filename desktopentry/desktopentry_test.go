@@ -0,0 +1,264 @@
+// Copyright 2026 The go-xdgbasedir Authors. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package desktopentry_test
+
+import (
+	"errors"
+	"os"
+	"path/filepath"
+	"reflect"
+	"sort"
+	"testing"
+
+	"github.com/zchee/go-xdgbasedir"
+	"github.com/zchee/go-xdgbasedir/desktopentry"
+	"github.com/zchee/go-xdgbasedir/xdgtest"
+)
+
+const editor = `[Desktop Entry]
+Type=Application
+Version=1.5
+Name=Text Editor
+Name[de]=Texteditor
+GenericName=Editor
+Comment=Edit text files
+Comment[de]=Textdateien bearbeiten
+Icon=editor
+Exec=editor %U
+TryExec=editor
+Path=/tmp
+Terminal=false
+StartupNotify=true
+Categories=Utility;TextEditor;
+MimeType=text/plain;text/markdown;
+Keywords=text;plain;
+Keywords[de]=Text;
+OnlyShowIn=GNOME;Unity;
+Actions=new-window;missing;
+X-Custom=value
+
+[Desktop Action new-window]
+Name=New Window
+Name[de]=Neues Fenster
+Exec=editor --new-window
+`
+
+func TestParse(t *testing.T) {
+	t.Parallel()
+
+	e, err := desktopentry.Parse([]byte(editor), []string{"de_DE", "de"})
+	if err != nil {
+		t.Fatal(err)
+	}
+	want := &desktopentry.Entry{
+		Type:          desktopentry.Application,
+		Version:       "1.5",
+		Name:          "Texteditor",
+		GenericName:   "Editor",
+		Comment:       "Textdateien bearbeiten",
+		Icon:          "editor",
+		Exec:          "editor %U",
+		TryExec:       "editor",
+		Path:          "/tmp",
+		StartupNotify: true,
+		Categories:    []string{"Utility", "TextEditor"},
+		MimeType:      []string{"text/plain", "text/markdown"},
+		Keywords:      []string{"Text"},
+		OnlyShowIn:    []string{"GNOME", "Unity"},
+		Actions: []desktopentry.Action{
+			{ID: "new-window", Name: "Neues Fenster", Exec: "editor --new-window"},
+		},
+		KeyFile: e.KeyFile,
+	}
+	if !reflect.DeepEqual(e, want) {
+		t.Errorf("Parse() = %+v, want %+v", e, want)
+	}
+	if v, _ := e.KeyFile.String(desktopentry.Group, "X-Custom"); v != "value" {
+		t.Errorf("X-Custom = %q", v)
+	}
+
+	if _, err := desktopentry.Parse([]byte("[Other]\nType=Application\n"), nil); err == nil {
+		t.Error("Parse() without the Desktop Entry group succeeded")
+	}
+}
+
+func TestShowIn(t *testing.T) {
+	t.Parallel()
+
+	tests := []struct {
+		only, not []string
+		desktops  []string
+		want      bool
+	}{
+		{desktops: []string{"GNOME"}, want: true},
+		{desktops: nil, want: true},
+		{only: []string{"KDE"}, desktops: []string{"GNOME"}, want: false},
+		{only: []string{"GNOME"}, desktops: []string{"ubuntu", "GNOME"}, want: true},
+		{only: []string{"GNOME"}, desktops: nil, want: false},
+		{not: []string{"GNOME"}, desktops: []string{"GNOME"}, want: false},
+		{not: []string{"GNOME"}, desktops: []string{"KDE"}, want: true},
+		{only: []string{"GNOME"}, not: []string{"ubuntu"}, desktops: []string{"ubuntu", "GNOME"}, want: false},
+	}
+	for _, tt := range tests {
+		e := &desktopentry.Entry{OnlyShowIn: tt.only, NotShowIn: tt.not}
+		if got := e.ShowIn(tt.desktops); got != tt.want {
+			t.Errorf("ShowIn(%v) with OnlyShowIn=%v NotShowIn=%v = %v, want %v", tt.desktops, tt.only, tt.not, got, tt.want)
+		}
+	}
+}
+
+func TestFileID(t *testing.T) {
+	t.Parallel()
+
+	dir := filepath.Join("/usr", "share", "applications")
+	tests := []struct {
+		path string
+		want string
+	}{
+		{path: filepath.Join(dir, "gedit.desktop"), want: "gedit.desktop"},
+		{path: filepath.Join(dir, "foo", "bar.desktop"), want: "foo-bar.desktop"},
+		{path: filepath.Join(dir, "kde4", "sub", "app.desktop"), want: "kde4-sub-app.desktop"},
+	}
+	for _, tt := range tests {
+		if got, err := desktopentry.FileID(dir, tt.path); err != nil || got != tt.want {
+			t.Errorf("FileID(%s) = %q, %v, want %q", tt.path, got, err, tt.want)
+		}
+	}
+	if _, err := desktopentry.FileID(dir, filepath.Join("/opt", "app.desktop")); err == nil {
+		t.Error("FileID() outside the directory succeeded")
+	}
+}
+
+func TestApplications(t *testing.T) {
+	t.Parallel()
+
+	tr := xdgtest.New(t, xdgtest.WithFiles(map[string]string{
+		"home/.local/share/applications/editor.desktop":   editor,
+		"home/.local/share/applications/removed.desktop":  "[Desktop Entry]\nType=Application\nName=Removed\nHidden=true\n",
+		"usr/local/share/applications/editor.desktop":     "[Desktop Entry]\nType=Application\nName=Shadowed\n",
+		"usr/share/applications/foo/bar.desktop":          "[Desktop Entry]\nType=Application\nName=Bar\nNoDisplay=true\n",
+		"usr/share/applications/foo-bar.desktop":          "[Desktop Entry]\nType=Application\nName=Shadowed by foo/bar\n",
+		"usr/share/applications/removed.desktop":          "[Desktop Entry]\nType=Application\nName=System\n",
+		"usr/share/applications/broken.desktop":           "Name=Broken\n",
+		"usr/share/applications/not-a-desktop-file.txt":   "",
+		"usr/share/applications/kde4/nested/app.desktop":  "[Desktop Entry]\nType=Application\nName=Nested\n",
+		"usr/share/applications/other/ignored/README.txt": "",
+	}))
+	r := tr.Resolver(xdgbasedir.WithEnv(append(tr.Env(), "LANG=de_DE.UTF-8", "XDG_CURRENT_DESKTOP=ubuntu:GNOME")))
+
+	var got []string
+	for _, e := range desktopentry.Applications(r) {
+		got = append(got, e.ID+"="+e.Name)
+	}
+	want := []string{"editor.desktop=Texteditor", "foo-bar.desktop=Bar", "kde4-nested-app.desktop=Nested"}
+	if !reflect.DeepEqual(got, want) {
+		t.Errorf("Applications() = %q, want %q", got, want)
+	}
+
+	e, err := desktopentry.Load(r, "foo-bar.desktop")
+	if err != nil {
+		t.Fatal(err)
+	}
+	if want := tr.Path("usr/share/applications/foo/bar.desktop"); e.File != want {
+		t.Errorf("Load().File = %q, want %q", e.File, want)
+	}
+	if _, err := desktopentry.Load(r, "removed.desktop"); !errors.Is(err, desktopentry.ErrNotFound) {
+		t.Errorf("Load(hidden) error = %v, want ErrNotFound", err)
+	}
+	if _, err := desktopentry.Load(r, "missing.desktop"); !errors.Is(err, desktopentry.ErrNotFound) {
+		t.Errorf("Load(missing) error = %v, want ErrNotFound", err)
+	}
+
+	if got, want := desktopentry.CurrentDesktops(r), []string{"ubuntu", "GNOME"}; !reflect.DeepEqual(got, want) {
+		t.Errorf("CurrentDesktops() = %q, want %q", got, want)
+	}
+}
+
+func TestFilesSymlinks(t *testing.T) {
+	t.Parallel()
+
+	tr := xdgtest.New(t, xdgtest.WithFiles(map[string]string{
+		"usr/share/applications/app.desktop":          "[Desktop Entry]\nType=Application\nName=App\n",
+		"usr/share/applications/kde4/nested.desktop":  "[Desktop Entry]\nType=Application\nName=Nested\n",
+		"usr/share/applications/kde4/sub/sub.desktop": "[Desktop Entry]\nType=Application\nName=Sub\n",
+	}))
+	links := map[string]string{
+		"usr/share/applications/loop":          ".",
+		"usr/share/applications/kde4/sub/up":   "..",
+		"usr/share/applications/linked":        "kde4",
+		"usr/share/applications/kde4/absolute": tr.Path("usr/share/applications"),
+	}
+	for name, target := range links {
+		if err := os.Symlink(target, tr.Path(name)); err != nil {
+			t.Skip(err)
+		}
+	}
+
+	var got []string
+	for id := range desktopentry.Files(tr.Resolver()) {
+		got = append(got, id)
+	}
+	sort.Strings(got)
+	want := []string{
+		"app.desktop",
+		"kde4-nested.desktop",
+		"kde4-sub-sub.desktop",
+		"linked-nested.desktop",
+		"linked-sub-sub.desktop",
+	}
+	if !reflect.DeepEqual(got, want) {
+		t.Errorf("Files() = %q, want %q", got, want)
+	}
+}
+
+func TestSysroot(t *testing.T) {
+	t.Parallel()
+
+	root := t.TempDir()
+	files := map[string]string{
+		"usr/share/applications/app.desktop": "[Desktop Entry]\nType=Application\nName=App\nExec=app\nTryExec=app\n",
+		"opt/apps/linked.desktop":            "[Desktop Entry]\nType=Application\nName=Linked\nExec=tool\nTryExec=/opt/bin/tool\n",
+		"bin/app":                            "",
+		"opt/bin/tool":                       "",
+	}
+	for name, data := range files {
+		path := filepath.Join(root, filepath.FromSlash(name))
+		if err := os.MkdirAll(filepath.Dir(path), 0755); err != nil {
+			t.Fatal(err)
+		}
+		if err := os.WriteFile(path, []byte(data), 0755); err != nil {
+			t.Fatal(err)
+		}
+	}
+	// absolute symlinks must be resolved inside root, not on the host
+	links := map[string]string{
+		"usr/share/applications/opt":  "/opt/apps",
+		"usr/share/applications/loop": "/usr/share/applications",
+	}
+	for name, target := range links {
+		if err := os.Symlink(target, filepath.Join(root, filepath.FromSlash(name))); err != nil {
+			t.Skip(err)
+		}
+	}
+	r := xdgbasedir.New(xdgbasedir.WithSysroot(root), xdgbasedir.WithEnv([]string{"PATH=/bin"}))
+
+	var got []string
+	for _, e := range desktopentry.Applications(r) {
+		if !e.Installed(r) {
+			t.Errorf("%s: Installed() = false", e.ID)
+		}
+		got = append(got, e.ID+"="+e.Name)
+	}
+	if want := []string{"app.desktop=App", "opt-linked.desktop=Linked"}; !reflect.DeepEqual(got, want) {
+		t.Errorf("Applications() = %q, want %q", got, want)
+	}
+
+	if err := os.Chmod(filepath.Join(root, "bin", "app"), 0644); err != nil {
+		t.Fatal(err)
+	}
+	if (&desktopentry.Entry{TryExec: "app"}).Installed(r) {
+		t.Error("Installed() = true for a TryExec program which is not executable in the sysroot")
+	}
+}
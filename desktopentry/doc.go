@@ -0,0 +1,13 @@
+// Copyright 2026 The go-xdgbasedir Authors. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+// Package desktopentry implements the freedesktop.org Desktop Entry Specification.
+//
+//	https://specifications.freedesktop.org/desktop-entry-spec/latest/
+//
+// Desktop entries are installed in the applications subdirectory of DataHome and each DataDirs. The desktop-file
+// ID of an entry is its path relative to the applications directory with '/' replaced by '-', so that
+// applications/foo/bar.desktop has the ID foo-bar.desktop, and an entry in a higher precedence directory shadows
+// the entries of the same ID in the lower ones.
+package desktopentry // import "github.com/zchee/go-xdgbasedir/desktopentry"
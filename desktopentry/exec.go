@@ -0,0 +1,228 @@
+// Copyright 2026 The go-xdgbasedir Authors. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package desktopentry
+
+import (
+	"fmt"
+	"net/url"
+	"path/filepath"
+	"strings"
+)
+
+// ExecArg is an argument of an Exec value.
+type ExecArg struct {
+	Text   string // unquoted text
+	Quoted bool   // whether the argument was double quoted, in which field codes are not expanded
+}
+
+// ParseExec splits the Exec value, already unescaped as a key file string, into arguments by the quoting rules of
+// the specification. In a double quoted argument, '"', '`', '$' and '\' are escaped with a backslash.
+func ParseExec(exec string) ([]ExecArg, error) {
+	var args []ExecArg
+	var b strings.Builder
+	inArg, quoted := false, false
+	for i := 0; i < len(exec); i++ {
+		c := exec[i]
+		switch {
+		case quoted && c == '\\':
+			i++
+			if i == len(exec) {
+				return nil, fmt.Errorf("desktopentry: Exec %q ends with an escape character", exec)
+			}
+			switch exec[i] {
+			case '"', '`', '$', '\\':
+			default:
+				return nil, fmt.Errorf("desktopentry: Exec %q has an invalid escape sequence \\%c", exec, exec[i])
+			}
+			b.WriteByte(exec[i])
+		case quoted && c == '"':
+			quoted = false
+		case quoted:
+			b.WriteByte(c)
+		case c == '"':
+			if inArg {
+				return nil, fmt.Errorf("desktopentry: Exec %q has a quote in the middle of an argument", exec)
+			}
+			inArg, quoted = true, true
+			args = append(args, ExecArg{Quoted: true})
+		case c == ' ' || c == '\t' || c == '\n':
+			if inArg {
+				args[len(args)-1].Text = b.String()
+				b.Reset()
+				inArg = false
+			}
+		default:
+			if !inArg {
+				inArg = true
+				args = append(args, ExecArg{})
+			} else if args[len(args)-1].Quoted {
+				return nil, fmt.Errorf("desktopentry: Exec %q has text after a closing quote", exec)
+			}
+			b.WriteByte(c)
+		}
+	}
+	if quoted {
+		return nil, fmt.Errorf("desktopentry: Exec %q has an unterminated quote", exec)
+	}
+	if inArg {
+		args[len(args)-1].Text = b.String()
+	}
+	if len(args) == 0 {
+		return nil, fmt.Errorf("desktopentry: Exec %q is empty", exec)
+	}
+	return args, nil
+}
+
+// deprecatedCodes is the field codes which are deprecated and expand to nothing.
+const deprecatedCodes = "dDnNvm"
+
+// listCodes is the field codes which may only be used as a lone argument.
+const listCodes = "FUi"
+
+// Expand expands the field codes of the Exec value of e for targets, which are local file paths or URIs, and
+// returns the argv of each process to launch. If the Exec value takes a single file or URL with %f or %u, one
+// process is launched per target.
+func (e *Entry) Expand(targets ...string) ([][]string, error) {
+	return e.expand(e.Exec, targets)
+}
+
+// expand expands the field codes of exec for targets.
+func (e *Entry) expand(exec string, targets []string) ([][]string, error) {
+	args, err := ParseExec(exec)
+	if err != nil {
+		return nil, err
+	}
+
+	single, multi := false, false
+	for _, arg := range args {
+		if arg.Quoted {
+			continue
+		}
+		for i := 0; i+1 < len(arg.Text); i++ {
+			if arg.Text[i] != '%' {
+				continue
+			}
+			i++
+			switch code := arg.Text[i]; {
+			case code == 'f' || code == 'u':
+				single = true
+			case code == 'F' || code == 'U':
+				multi = true
+			}
+		}
+	}
+
+	if single && !multi && len(targets) > 1 {
+		var argvs [][]string
+		for _, target := range targets {
+			argv, err := e.expandArgs(args, []string{target})
+			if err != nil {
+				return nil, err
+			}
+			argvs = append(argvs, argv)
+		}
+		return argvs, nil
+	}
+	argv, err := e.expandArgs(args, targets)
+	if err != nil {
+		return nil, err
+	}
+	return [][]string{argv}, nil
+}
+
+// expandArgs expands the field codes of args for targets.
+func (e *Entry) expandArgs(args []ExecArg, targets []string) ([]string, error) {
+	var argv []string
+	for _, arg := range args {
+		if arg.Quoted {
+			argv = append(argv, arg.Text)
+			continue
+		}
+		if len(arg.Text) == 2 && arg.Text[0] == '%' && strings.IndexByte(listCodes, arg.Text[1]) >= 0 {
+			switch arg.Text[1] {
+			case 'F':
+				for _, target := range targets {
+					path, err := localPath(target)
+					if err != nil {
+						return nil, err
+					}
+					argv = append(argv, path)
+				}
+			case 'U':
+				argv = append(argv, targets...)
+			case 'i':
+				if e.Icon != "" {
+					argv = append(argv, "--icon", e.Icon)
+				}
+			}
+			continue
+		}
+
+		var b strings.Builder
+		empty := false
+		for i := 0; i < len(arg.Text); i++ {
+			c := arg.Text[i]
+			if c != '%' {
+				b.WriteByte(c)
+				continue
+			}
+			i++
+			if i == len(arg.Text) {
+				return nil, fmt.Errorf("desktopentry: Exec %q ends with a '%%'", arg.Text)
+			}
+			switch code := arg.Text[i]; {
+			case code == '%':
+				b.WriteByte('%')
+			case code == 'f':
+				if len(targets) == 0 {
+					empty = true
+					break
+				}
+				path, err := localPath(targets[0])
+				if err != nil {
+					return nil, err
+				}
+				b.WriteString(path)
+			case code == 'u':
+				if len(targets) == 0 {
+					empty = true
+					break
+				}
+				b.WriteString(targets[0])
+			case code == 'c':
+				b.WriteString(e.Name)
+			case code == 'k':
+				b.WriteString(e.File)
+			case strings.IndexByte(deprecatedCodes, code) >= 0:
+				empty = true
+			case strings.IndexByte(listCodes, code) >= 0:
+				return nil, fmt.Errorf("desktopentry: field code %%%c must be a lone argument", code)
+			default:
+				return nil, fmt.Errorf("desktopentry: unknown field code %%%c", code)
+			}
+		}
+		// an argument which consists of field codes expanded to nothing is removed
+		if b.Len() == 0 && empty {
+			continue
+		}
+		argv = append(argv, b.String())
+	}
+	return argv, nil
+}
+
+// localPath returns the local file path of target, which is a file path or a file URI.
+func localPath(target string) (string, error) {
+	if !strings.Contains(target, "://") && !strings.HasPrefix(target, "file:") {
+		return target, nil
+	}
+	u, err := url.Parse(target)
+	if err != nil {
+		return "", err
+	}
+	if u.Scheme != "file" || (u.Host != "" && u.Host != "localhost") {
+		return "", fmt.Errorf("desktopentry: %s is not a local file", target)
+	}
+	return filepath.FromSlash(u.Path), nil
+}
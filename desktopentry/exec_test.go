@@ -0,0 +1,186 @@
+// Copyright 2026 The go-xdgbasedir Authors. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package desktopentry_test
+
+import (
+	"net/url"
+	"os/exec"
+	"path/filepath"
+	"reflect"
+	"testing"
+
+	"github.com/zchee/go-xdgbasedir"
+	"github.com/zchee/go-xdgbasedir/desktopentry"
+)
+
+func TestParseExec(t *testing.T) {
+	t.Parallel()
+
+	tests := []struct {
+		exec string
+		want []desktopentry.ExecArg
+	}{
+		{exec: "editor %U", want: []desktopentry.ExecArg{{Text: "editor"}, {Text: "%U"}}},
+		{exec: "  sh  -c\t\"echo \\\"\\$HOME\\\" \\\\ %f\"  ", want: []desktopentry.ExecArg{{Text: "sh"}, {Text: "-c"}, {Text: `echo "$HOME" \ %f`, Quoted: true}}},
+		{exec: `"/opt/My App/app" ""`, want: []desktopentry.ExecArg{{Text: "/opt/My App/app", Quoted: true}, {Quoted: true}}},
+	}
+	for _, tt := range tests {
+		got, err := desktopentry.ParseExec(tt.exec)
+		if err != nil || !reflect.DeepEqual(got, tt.want) {
+			t.Errorf("ParseExec(%q) = %+v, %v, want %+v", tt.exec, got, err, tt.want)
+		}
+	}
+
+	for _, exec := range []string{"", "   ", `app "unterminated`, `app "bad \n escape"`, `app a"b"`, `app "a"b`} {
+		if _, err := desktopentry.ParseExec(exec); err == nil {
+			t.Errorf("ParseExec(%q) succeeded", exec)
+		}
+	}
+}
+
+func TestExpand(t *testing.T) {
+	t.Parallel()
+
+	e := &desktopentry.Entry{Name: "Editor", Icon: "editor", File: "/usr/share/applications/editor.desktop"}
+	tests := []struct {
+		exec    string
+		targets []string
+		want    [][]string
+	}{
+		{exec: "editor %F", targets: []string{"/a", "file:///b%20c"}, want: [][]string{{"editor", "/a", "/b c"}}},
+		{exec: "editor %U", targets: []string{"/a", "https://example.org"}, want: [][]string{{"editor", "/a", "https://example.org"}}},
+		{exec: "editor --file=%f", targets: []string{"/a", "/b"}, want: [][]string{{"editor", "--file=/a"}, {"editor", "--file=/b"}}},
+		{exec: "editor %u", want: [][]string{{"editor"}}},
+		{exec: "editor %i --title %c %k", want: [][]string{{"editor", "--icon", "editor", "--title", "Editor", "/usr/share/applications/editor.desktop"}}},
+		{exec: "editor 100%% %d %m", want: [][]string{{"editor", "100%"}}},
+		{exec: `editor "%f"`, targets: []string{"/a"}, want: [][]string{{"editor", "%f"}}},
+		{exec: "editor", targets: []string{"/a", "/b"}, want: [][]string{{"editor"}}},
+	}
+	for _, tt := range tests {
+		e.Exec = tt.exec
+		got, err := e.Expand(tt.targets...)
+		if err != nil || !reflect.DeepEqual(got, tt.want) {
+			t.Errorf("Expand(%q, %q) = %q, %v, want %q", tt.exec, tt.targets, got, err, tt.want)
+		}
+	}
+
+	for _, exec := range []string{"editor %x", "editor --files=%F", "editor %"} {
+		e.Exec = exec
+		if _, err := e.Expand(); err == nil {
+			t.Errorf("Expand(%q) succeeded", exec)
+		}
+	}
+	e.Exec = "editor %f"
+	if _, err := e.Expand("https://example.org"); err == nil {
+		t.Error("Expand() of a remote URI for a file field code succeeded")
+	}
+}
+
+func TestLauncher(t *testing.T) {
+	t.Parallel()
+
+	r := xdgbasedir.New(xdgbasedir.WithHermetic(xdgbasedir.Hermetic{
+		Home: "/home/gopher",
+		Env:  []string{"TERMINAL=foot"},
+	}))
+	var ran [][]string
+	l := desktopentry.NewLauncher(
+		desktopentry.WithResolver(r),
+		desktopentry.WithRunner(func(cmd *exec.Cmd) error {
+			ran = append(ran, cmd.Args)
+			return nil
+		}),
+	)
+
+	e := &desktopentry.Entry{
+		ID:       "editor.desktop",
+		Type:     desktopentry.Application,
+		Exec:     "editor %f",
+		Path:     "/work",
+		Terminal: true,
+		Actions:  []desktopentry.Action{{ID: "new-window", Exec: "editor --new-window"}},
+	}
+	cmds, err := l.Commands(e, "/a")
+	if err != nil {
+		t.Fatal(err)
+	}
+	if want := []string{"foot", "-e", "editor", "/a"}; len(cmds) != 1 || !reflect.DeepEqual(cmds[0].Args, want) {
+		t.Fatalf("Commands() = %v, want one command %q", cmds, want)
+	}
+	if cmds[0].Dir != "/work" {
+		t.Errorf("Dir = %q, want /work", cmds[0].Dir)
+	}
+
+	if err := l.Launch(e, "/a", "/b"); err != nil {
+		t.Fatal(err)
+	}
+	if err := l.LaunchAction(e, "new-window"); err != nil {
+		t.Fatal(err)
+	}
+	if err := l.LaunchAction(e, "missing"); err == nil {
+		t.Error("LaunchAction(missing) succeeded")
+	}
+	want := [][]string{
+		{"foot", "-e", "editor", "/a"},
+		{"foot", "-e", "editor", "/b"},
+		{"foot", "-e", "editor", "--new-window"},
+	}
+	if !reflect.DeepEqual(ran, want) {
+		t.Errorf("ran %q, want %q", ran, want)
+	}
+
+	if _, err := l.Commands(&desktopentry.Entry{Type: desktopentry.Link, URL: "https://example.org"}); err == nil {
+		t.Error("Commands() of a Link succeeded")
+	}
+	for _, value := range []string{"%f", "%F", "%u", "%U"} {
+		if _, err := l.Commands(&desktopentry.Entry{Type: desktopentry.Application, Exec: value}); err == nil {
+			t.Errorf("Commands() of Exec=%s without targets succeeded", value)
+		}
+	}
+}
+
+func TestLauncherDBus(t *testing.T) {
+	t.Parallel()
+
+	l := desktopentry.NewLauncher(desktopentry.WithResolver(xdgbasedir.New(xdgbasedir.WithEnv(nil))))
+	e := &desktopentry.Entry{
+		ID:              "org.example.My-App.desktop",
+		Type:            desktopentry.Application,
+		Exec:            "my-app %U",
+		DBusActivatable: true,
+		Actions:         []desktopentry.Action{{ID: "new-window"}},
+	}
+	prefix := []string{"gdbus", "call", "--session", "--dest", "org.example.My-App", "--object-path", "/org/example/My_App"}
+	tests := []struct {
+		action  string
+		targets []string
+		want    []string
+	}{
+		{want: append(prefix, "--method", "org.freedesktop.Application.Activate", "{}")},
+		{targets: []string{"/tmp/a b.txt", "https://example.org/it's"}, want: append(prefix, "--method", "org.freedesktop.Application.Open", `['file:///tmp/a%20b.txt', 'https://example.org/it\'s']`, "{}")},
+		{action: "new-window", want: append(prefix, "--method", "org.freedesktop.Application.ActivateAction", "'new-window'", "[]", "{}")},
+	}
+	for _, tt := range tests {
+		var cmds []*exec.Cmd
+		var err error
+		if tt.action != "" {
+			cmds, err = l.ActionCommands(e, tt.action, tt.targets...)
+		} else {
+			cmds, err = l.Commands(e, tt.targets...)
+		}
+		if err != nil || len(cmds) != 1 || !reflect.DeepEqual(cmds[0].Args, tt.want) {
+			t.Errorf("Commands(%q, %q) = %v, %v, want %q", tt.action, tt.targets, cmds, err, tt.want)
+		}
+	}
+
+	abs, err := filepath.Abs("a.txt")
+	if err != nil {
+		t.Fatal(err)
+	}
+	want := append(prefix, "--method", "org.freedesktop.Application.Open", "['"+(&url.URL{Scheme: "file", Path: filepath.ToSlash(abs)}).String()+"']", "{}")
+	if cmds, err := l.Commands(e, "a.txt"); err != nil || len(cmds) != 1 || !reflect.DeepEqual(cmds[0].Args, want) {
+		t.Errorf("Commands(a.txt) = %v, %v, want %q", cmds, err, want)
+	}
+}
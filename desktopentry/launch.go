@@ -0,0 +1,209 @@
+// Copyright 2026 The go-xdgbasedir Authors. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package desktopentry
+
+import (
+	"errors"
+	"fmt"
+	"net/url"
+	"os/exec"
+	"path/filepath"
+	"strings"
+
+	"github.com/zchee/go-xdgbasedir"
+)
+
+// Launcher launches desktop entries.
+type Launcher struct {
+	resolver *xdgbasedir.Resolver
+	run      func(cmd *exec.Cmd) error
+	terminal []string
+}
+
+// LaunchOption configures a Launcher.
+type LaunchOption func(*Launcher)
+
+// WithResolver makes the Launcher run the commands in the environment of r. By default, xdgbasedir.Default().
+func WithResolver(r *xdgbasedir.Resolver) LaunchOption {
+	return func(l *Launcher) {
+		l.resolver = r
+	}
+}
+
+// WithRunner sets the function which runs the built commands. By default, (*exec.Cmd).Start, which does not wait
+// for the command to exit.
+func WithRunner(run func(cmd *exec.Cmd) error) LaunchOption {
+	return func(l *Launcher) {
+		l.run = run
+	}
+}
+
+// WithTerminal sets the command prefix which runs the entries of Terminal=true in a terminal emulator, such as
+// ["gnome-terminal", "--"]. By default, $TERMINAL or xterm followed by "-e".
+func WithTerminal(argv ...string) LaunchOption {
+	return func(l *Launcher) {
+		l.terminal = argv
+	}
+}
+
+// NewLauncher returns a new Launcher configured by opts.
+func NewLauncher(opts ...LaunchOption) *Launcher {
+	l := &Launcher{
+		resolver: xdgbasedir.Default(),
+		run:      (*exec.Cmd).Start,
+	}
+	for _, opt := range opts {
+		opt(l)
+	}
+	return l
+}
+
+// Commands returns the commands which launch e for targets, which are local file paths or URIs.
+//
+// An entry of DBusActivatable=true is activated through D-Bus with gdbus, calling the Activate or Open method of
+// the org.freedesktop.Application interface. Otherwise, the Exec value is expanded, prefixed with the terminal
+// emulator if Terminal=true, and run in the working directory of Path.
+func (l *Launcher) Commands(e *Entry, targets ...string) ([]*exec.Cmd, error) {
+	return l.commands(e, "", e.Exec, targets)
+}
+
+// ActionCommands returns the commands which launch the action of e for targets.
+func (l *Launcher) ActionCommands(e *Entry, action string, targets ...string) ([]*exec.Cmd, error) {
+	for _, a := range e.Actions {
+		if a.ID == action {
+			return l.commands(e, action, a.Exec, targets)
+		}
+	}
+	return nil, fmt.Errorf("desktopentry: %s: action %q not found", e.ID, action)
+}
+
+// Launch launches e for targets with the runner.
+func (l *Launcher) Launch(e *Entry, targets ...string) error {
+	cmds, err := l.Commands(e, targets...)
+	if err != nil {
+		return err
+	}
+	return l.runAll(cmds)
+}
+
+// LaunchAction launches the action of e for targets with the runner.
+func (l *Launcher) LaunchAction(e *Entry, action string, targets ...string) error {
+	cmds, err := l.ActionCommands(e, action, targets...)
+	if err != nil {
+		return err
+	}
+	return l.runAll(cmds)
+}
+
+func (l *Launcher) runAll(cmds []*exec.Cmd) error {
+	var errs []error
+	for _, cmd := range cmds {
+		if err := l.run(cmd); err != nil {
+			errs = append(errs, err)
+		}
+	}
+	return errors.Join(errs...)
+}
+
+// commands returns the commands which run execValue, or the action of e through D-Bus.
+func (l *Launcher) commands(e *Entry, action, execValue string, targets []string) ([]*exec.Cmd, error) {
+	if e.Type != "" && e.Type != Application {
+		return nil, fmt.Errorf("desktopentry: %s: cannot launch the entry of type %s", e.ID, e.Type)
+	}
+	if e.DBusActivatable && e.ID != "" {
+		return []*exec.Cmd{l.command(e, dbusArgv(e.ID, action, targets))}, nil
+	}
+	if execValue == "" {
+		return nil, fmt.Errorf("desktopentry: %s: no Exec key", e.ID)
+	}
+
+	argvs, err := e.expand(execValue, targets)
+	if err != nil {
+		return nil, err
+	}
+	cmds := make([]*exec.Cmd, 0, len(argvs))
+	for _, argv := range argvs {
+		if len(argv) == 0 {
+			return nil, fmt.Errorf("desktopentry: %s: Exec %q expands to an empty command line", e.ID, execValue)
+		}
+		if e.Terminal {
+			argv = append(l.terminalArgv(), argv...)
+		}
+		cmds = append(cmds, l.command(e, argv))
+	}
+	return cmds, nil
+}
+
+// command returns the command of argv in the environment of l.
+func (l *Launcher) command(e *Entry, argv []string) *exec.Cmd {
+	cmd := exec.Command(argv[0], argv[1:]...)
+	cmd.Dir = e.Path
+	cmd.Env = l.resolver.Environ().Environ()
+	return cmd
+}
+
+// terminalArgv returns the command prefix of the terminal emulator.
+func (l *Launcher) terminalArgv() []string {
+	if len(l.terminal) > 0 {
+		return append([]string(nil), l.terminal...)
+	}
+	term := l.resolver.Getenv("TERMINAL")
+	if term == "" {
+		term = "xterm"
+	}
+	return []string{term, "-e"}
+}
+
+// BusName returns the D-Bus well-known name of the desktop-file ID of a DBusActivatable entry, which is the ID
+// without the .desktop extension.
+func BusName(id string) string {
+	return strings.TrimSuffix(id, Ext)
+}
+
+// ObjectPath returns the D-Bus object path of the bus name, such as "/org/example/App" for "org.example.App".
+// '-' is replaced by '_' since it is not allowed in object paths.
+func ObjectPath(name string) string {
+	return "/" + strings.ReplaceAll(strings.ReplaceAll(name, ".", "/"), "-", "_")
+}
+
+// dbusArgv returns the gdbus argv which activates the desktop-file ID, opens targets or activates the action.
+func dbusArgv(id, action string, targets []string) []string {
+	name := BusName(id)
+	argv := []string{"gdbus", "call", "--session", "--dest", name, "--object-path", ObjectPath(name)}
+	switch {
+	case action != "":
+		return append(argv, "--method", "org.freedesktop.Application.ActivateAction", gvariantString(action), "[]", "{}")
+	case len(targets) > 0:
+		uris := make([]string, len(targets))
+		for i, target := range targets {
+			uris[i] = gvariantString(toURI(target))
+		}
+		return append(argv, "--method", "org.freedesktop.Application.Open", "["+strings.Join(uris, ", ")+"]", "{}")
+	default:
+		return append(argv, "--method", "org.freedesktop.Application.Activate", "{}")
+	}
+}
+
+// gvariantString returns the GVariant text format of the string s.
+func gvariantString(s string) string {
+	return "'" + strings.NewReplacer(`\`, `\\`, `'`, `\'`).Replace(s) + "'"
+}
+
+// toURI returns the URI of target, which is a local file path or an URI. A relative path is made absolute, as a
+// file URI has no relative form.
+func toURI(target string) string {
+	if strings.Contains(target, "://") || strings.HasPrefix(target, "file:") {
+		return target
+	}
+	if abs, err := filepath.Abs(target); err == nil {
+		target = abs
+	}
+	path := filepath.ToSlash(target)
+	if !strings.HasPrefix(path, "/") {
+		// a windows path such as C:/a is file:///C:/a
+		path = "/" + path
+	}
+	return (&url.URL{Scheme: "file", Path: path}).String()
+}
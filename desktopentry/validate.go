@@ -0,0 +1,572 @@
+// Copyright 2026 The go-xdgbasedir Authors. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package desktopentry
+
+import (
+	"bufio"
+	"bytes"
+	"errors"
+	"fmt"
+	"path/filepath"
+	"regexp"
+	"strings"
+
+	"github.com/zchee/go-xdgbasedir"
+	"github.com/zchee/go-xdgbasedir/keyfile"
+)
+
+// Severity is the severity of a Problem.
+type Severity int
+
+const (
+	// Warning is a problem which desktop environments tolerate, such as a deprecated key.
+	Warning Severity = iota
+	// Error is a violation of the specification.
+	Error
+)
+
+// String implements fmt.Stringer.
+func (s Severity) String() string {
+	if s == Error {
+		return "error"
+	}
+	return "warning"
+}
+
+// Problem is a problem found by Validate.
+type Problem struct {
+	File     string
+	Line     int // 0 if the problem is not on a line, such as a missing key
+	Severity Severity
+	Msg      string
+}
+
+// String returns the problem in the form "file:line: severity: message".
+func (p Problem) String() string {
+	if p.Line > 0 {
+		return fmt.Sprintf("%s:%d: %s: %s", p.File, p.Line, p.Severity, p.Msg)
+	}
+	return fmt.Sprintf("%s: %s: %s", p.File, p.Severity, p.Msg)
+}
+
+// Problems is a list of problems.
+type Problems []Problem
+
+// HasErrors reports whether ps has any Error.
+func (ps Problems) HasErrors() bool {
+	for _, p := range ps {
+		if p.Severity == Error {
+			return true
+		}
+	}
+	return false
+}
+
+// keyType is the value type of a registered key.
+type keyType int
+
+const (
+	typeString keyType = iota
+	typeLocaleString
+	typeIconString
+	typeBoolean
+	typeStrings
+	typeLocaleStrings
+)
+
+// keySpec is the specification of a registered key.
+type keySpec struct {
+	typ keyType
+	app bool // only valid for Type=Application
+}
+
+// keys is the registry of the keys of the Desktop Entry group.
+var keys = map[string]keySpec{
+	"Type":                 {typ: typeString},
+	"Version":              {typ: typeString},
+	"Name":                 {typ: typeLocaleString},
+	"GenericName":          {typ: typeLocaleString},
+	"NoDisplay":            {typ: typeBoolean},
+	"Comment":              {typ: typeLocaleString},
+	"Icon":                 {typ: typeIconString},
+	"Hidden":               {typ: typeBoolean},
+	"OnlyShowIn":           {typ: typeStrings},
+	"NotShowIn":            {typ: typeStrings},
+	"DBusActivatable":      {typ: typeBoolean, app: true},
+	"TryExec":              {typ: typeString, app: true},
+	"Exec":                 {typ: typeString, app: true},
+	"Path":                 {typ: typeString, app: true},
+	"Terminal":             {typ: typeBoolean, app: true},
+	"Actions":              {typ: typeStrings, app: true},
+	"MimeType":             {typ: typeStrings, app: true},
+	"Categories":           {typ: typeStrings, app: true},
+	"Implements":           {typ: typeStrings},
+	"Keywords":             {typ: typeLocaleStrings, app: true},
+	"StartupNotify":        {typ: typeBoolean, app: true},
+	"StartupWMClass":       {typ: typeString, app: true},
+	"URL":                  {typ: typeString},
+	"PrefersNonDefaultGPU": {typ: typeBoolean, app: true},
+	"SingleMainWindow":     {typ: typeBoolean, app: true},
+}
+
+// actionKeys is the registry of the keys of the Desktop Action groups.
+var actionKeys = map[string]keySpec{
+	"Name": {typ: typeLocaleString},
+	"Icon": {typ: typeIconString},
+	"Exec": {typ: typeString},
+}
+
+// deprecatedKeys is the keys which were removed from the specification.
+var deprecatedKeys = map[string]bool{
+	"Encoding": true, "MiniIcon": true, "TerminalOptions": true, "Protocols": true, "Extensions": true,
+	"BinaryPattern": true, "MapNotify": true, "SwallowTitle": true, "SwallowExec": true, "SortOrder": true,
+	"FilePattern": true, "Patterns": true, "DefaultApp": true, "Dev": true, "FSType": true, "MountPoint": true,
+	"ReadOnly": true, "UnmountIcon": true,
+}
+
+// MainCategories is the registry of the main categories.
+var MainCategories = []string{
+	"AudioVideo", "Audio", "Video", "Development", "Education", "Game", "Graphics", "Network", "Office",
+	"Science", "Settings", "System", "Utility",
+}
+
+// AdditionalCategories is the registry of the additional categories.
+var AdditionalCategories = []string{
+	"Building", "Debugger", "IDE", "GUIDesigner", "Profiling", "RevisionControl", "Translation", "Calendar",
+	"ContactManagement", "Database", "Dictionary", "Chart", "Email", "Finance", "FlowChart", "PDA",
+	"ProjectManagement", "Presentation", "Spreadsheet", "WordProcessor", "2DGraphics", "VectorGraphics",
+	"RasterGraphics", "3DGraphics", "Scanning", "OCR", "Photography", "Publishing", "Viewer", "TextTools",
+	"DesktopSettings", "HardwareSettings", "Printing", "PackageManager", "Dialup", "InstantMessaging", "Chat",
+	"IRCClient", "Feed", "FileTransfer", "HamRadio", "News", "P2P", "RemoteAccess", "Telephony", "TelephonyTools",
+	"VideoConference", "WebBrowser", "WebDevelopment", "Midi", "Mixer", "Sequencer", "Tuner", "TV",
+	"AudioVideoEditing", "Player", "Recorder", "DiscBurning", "ActionGame", "AdventureGame", "ArcadeGame",
+	"BoardGame", "BlocksGame", "CardGame", "KidsGame", "LogicGame", "RolePlaying", "Shooter", "Simulation",
+	"SportsGame", "StrategyGame", "Art", "Construction", "Music", "Languages", "ArtificialIntelligence",
+	"Astronomy", "Biology", "Chemistry", "ComputerScience", "DataVisualization", "Economy", "Electricity",
+	"Geography", "Geology", "Geoscience", "History", "Humanities", "ImageProcessing", "Literature", "Maps", "Math",
+	"NumericalAnalysis", "MedicalSoftware", "Physics", "Robotics", "Spirituality", "Sports", "ParallelComputing",
+	"Amusement", "Archiving", "Compression", "Electronics", "Emulator", "Engineering", "FileTools", "FileManager",
+	"TerminalEmulator", "Filesystem", "Monitor", "Security", "Accessibility", "Calculator", "Clock", "TextEditor",
+	"Documentation", "Adult", "Core", "KDE", "GNOME", "XFCE", "DDE", "GTK", "Qt", "Motif", "Java", "ConsoleOnly",
+	"Screensaver", "TrayIcon", "Applet", "Shell",
+}
+
+// Desktops is the registry of the desktop environment names of OnlyShowIn and NotShowIn.
+var Desktops = []string{
+	"GNOME", "GNOME-Classic", "GNOME-Flashback", "KDE", "LXDE", "LXQt", "MATE", "Razor", "ROX", "TDE", "Unity",
+	"XFCE", "EDE", "Cinnamon", "Pantheon", "Budgie", "Enlightenment", "DDE", "Endless", "Old",
+}
+
+// versions is the known versions of the specification.
+var versions = []string{"1.0", "1.1", "1.2", "1.3", "1.4", "1.5"}
+
+var (
+	localeRe   = regexp.MustCompile(`^[a-z]{2,3}(_[A-Z]{2})?(\.[A-Za-z0-9-]+)?(@[A-Za-z0-9]+)?$`)
+	mimeTypeRe = regexp.MustCompile(`^[A-Za-z0-9!#$&^_.+-]+/[A-Za-z0-9!#$&^_.+-]+$`)
+)
+
+// validator validates a desktop entry.
+type validator struct {
+	r        *xdgbasedir.Resolver
+	file     string
+	f        *keyfile.File
+	lines    map[string]map[string]int // group → key → line
+	headers  map[string]int            // group → line
+	problems Problems
+}
+
+// ValidateFile validates the desktop file path like desktop-file-validate, resolving Icon and TryExec with r.
+// If r is nil, xdgbasedir.Default() is used.
+func ValidateFile(r *xdgbasedir.Resolver, path string) (Problems, error) {
+	if r == nil {
+		r = xdgbasedir.Default()
+	}
+	data, err := r.FS().ReadFile(path)
+	if err != nil {
+		return nil, err
+	}
+	return Validate(r, path, data), nil
+}
+
+// Validate validates the desktop entry data of the file name like desktop-file-validate, resolving Icon and
+// TryExec with r. If r is nil, xdgbasedir.Default() is used.
+func Validate(r *xdgbasedir.Resolver, name string, data []byte) Problems {
+	if r == nil {
+		r = xdgbasedir.Default()
+	}
+	v := &validator{r: r, file: name}
+	f, err := keyfile.ParseBytes(data)
+	if err != nil {
+		var perr *keyfile.ParseError
+		if errors.As(err, &perr) {
+			v.errorf(perr.Line, "%s", perr.Msg)
+		} else {
+			v.errorf(0, "%v", err)
+		}
+		return v.problems
+	}
+	v.f = f
+	v.scan(data)
+	v.validate()
+	return v.problems
+}
+
+func (v *validator) errorf(line int, format string, args ...interface{}) {
+	v.problems = append(v.problems, Problem{File: v.file, Line: line, Severity: Error, Msg: fmt.Sprintf(format, args...)})
+}
+
+func (v *validator) warnf(line int, format string, args ...interface{}) {
+	v.problems = append(v.problems, Problem{File: v.file, Line: line, Severity: Warning, Msg: fmt.Sprintf(format, args...)})
+}
+
+// scan records the lines of the groups and keys, and reports duplicates.
+func (v *validator) scan(data []byte) {
+	v.lines = make(map[string]map[string]int)
+	v.headers = make(map[string]int)
+	group := ""
+	sc := bufio.NewScanner(bytes.NewReader(data))
+	for n := 1; sc.Scan(); n++ {
+		s := strings.TrimSpace(sc.Text())
+		switch {
+		case s == "" || s[0] == '#':
+		case s[0] == '[':
+			group = s[1 : len(s)-1]
+			if line, ok := v.headers[group]; ok {
+				v.errorf(n, "group %q is already defined at line %d", group, line)
+				continue
+			}
+			v.headers[group] = n
+			v.lines[group] = make(map[string]int)
+		default:
+			key := strings.TrimSpace(s[:strings.IndexByte(s, '=')])
+			if line, ok := v.lines[group][key]; ok {
+				v.errorf(n, "key %q in group %q is already defined at line %d", key, group, line)
+				continue
+			}
+			v.lines[group][key] = n
+		}
+	}
+}
+
+func (v *validator) validate() {
+	groups := v.f.Groups()
+	if len(groups) == 0 {
+		v.errorf(0, "file has no %q group", Group)
+		return
+	}
+	if groups[0] != Group {
+		v.errorf(v.headers[groups[0]], "first group must be %q, not %q", Group, groups[0])
+		if !v.f.HasGroup(Group) {
+			return
+		}
+	}
+
+	typ, _ := v.f.String(Group, "Type")
+	v.validateGroup(Group, keys, typ)
+
+	switch typ {
+	case "":
+		v.errorf(0, "required key %q in group %q is not present", "Type", Group)
+	case Application, Link, Directory:
+	case "FSDevice", "MimeType", "Service", "ServiceType":
+		v.warnf(v.lines[Group]["Type"], "type %q is deprecated", typ)
+	default:
+		v.errorf(v.lines[Group]["Type"], "unknown type %q", typ)
+	}
+	if !v.f.HasKey(Group, "Name") {
+		v.errorf(0, "required key %q in group %q is not present", "Name", Group)
+	}
+	dbus, _ := v.f.Bool(Group, "DBusActivatable")
+	if typ == Application && !dbus && !v.f.HasKey(Group, "Exec") {
+		v.errorf(0, "required key %q in group %q is not present for type %q", "Exec", Group, Application)
+	}
+	if typ == Link && !v.f.HasKey(Group, "URL") {
+		v.errorf(0, "required key %q in group %q is not present for type %q", "URL", Group, Link)
+	}
+
+	if version, err := v.f.String(Group, "Version"); err == nil && !contains(versions, version) {
+		v.warnf(v.lines[Group]["Version"], "unknown version %q of the specification", version)
+	}
+	v.validateExec(Group)
+	v.validateCategories()
+	v.validateShowIn()
+	v.validateMimeType()
+	v.validateIcon(Group)
+	v.validateTryExec()
+	v.validateActions(groups, dbus)
+
+	for _, group := range groups {
+		if group != Group && !strings.HasPrefix(group, ActionGroupPrefix) && !strings.HasPrefix(group, "X-") {
+			v.errorf(v.headers[group], "unknown group %q; extension groups must start with \"X-\"", group)
+		}
+	}
+}
+
+// validateGroup validates the key names, locales and value types of the group.
+func (v *validator) validateGroup(group string, registry map[string]keySpec, typ string) {
+	names, _ := v.f.Keys(group)
+	for _, key := range names {
+		line := v.lines[group][key]
+		base, loc := keyfile.SplitKey(key)
+		if loc != "" {
+			if !localeRe.MatchString(loc) {
+				v.errorf(line, "invalid locale %q of key %q", loc, key)
+			} else if strings.Contains(loc, ".") {
+				v.warnf(line, "locale %q of key %q has an encoding, which is deprecated", loc, key)
+			}
+		}
+
+		if strings.HasPrefix(base, "X-") {
+			continue
+		}
+		if deprecatedKeys[base] {
+			v.warnf(line, "key %q is deprecated", base)
+			continue
+		}
+		spec, ok := registry[base]
+		if !ok {
+			v.errorf(line, "unknown key %q in group %q; extension keys must start with \"X-\"", base, group)
+			continue
+		}
+		if spec.app && typ != "" && typ != Application {
+			v.warnf(line, "key %q is only valid for type %q", base, Application)
+		}
+		if loc != "" && spec.typ != typeLocaleString && spec.typ != typeIconString && spec.typ != typeLocaleStrings {
+			v.errorf(line, "key %q cannot be localized", base)
+			continue
+		}
+
+		raw, _ := v.f.Value(group, key)
+		switch spec.typ {
+		case typeBoolean:
+			switch raw {
+			case "true", "false":
+			case "1", "0":
+				v.warnf(line, "boolean value %q of key %q is deprecated; use \"true\" or \"false\"", raw, key)
+			default:
+				v.errorf(line, "value %q of key %q is not a boolean", raw, key)
+			}
+		case typeStrings, typeLocaleStrings:
+			if _, err := v.f.StringList(group, key); err != nil {
+				v.errorf(line, "value of key %q: %v", key, err)
+			} else if raw != "" && !strings.HasSuffix(raw, ";") {
+				v.warnf(line, "value of key %q is a list and should end with a semicolon", key)
+			}
+		default:
+			if _, err := v.f.String(group, key); err != nil {
+				v.errorf(line, "value of key %q: %v", key, err)
+			}
+		}
+	}
+}
+
+// validateExec validates the Exec field codes of the group.
+func (v *validator) validateExec(group string) {
+	exec, err := v.f.String(group, "Exec")
+	if err != nil {
+		return
+	}
+	line := v.lines[group]["Exec"]
+	args, err := ParseExec(exec)
+	if err != nil {
+		v.errorf(line, "%v", strings.TrimPrefix(err.Error(), "desktopentry: "))
+		return
+	}
+	targets := 0
+	for _, arg := range args {
+		if arg.Quoted {
+			for _, code := range []string{"%f", "%F", "%u", "%U"} {
+				if strings.Contains(arg.Text, code) {
+					v.errorf(line, "field code %s must not be used inside a quoted argument", code)
+				}
+			}
+			continue
+		}
+		for i := 0; i < len(arg.Text); i++ {
+			if arg.Text[i] != '%' {
+				continue
+			}
+			i++
+			if i == len(arg.Text) {
+				v.errorf(line, "Exec argument %q ends with a '%%'", arg.Text)
+				break
+			}
+			switch code := arg.Text[i]; {
+			case code == '%', code == 'c', code == 'k':
+			case code == 'f', code == 'u':
+				targets++
+			case strings.IndexByte(listCodes, code) >= 0:
+				if len(arg.Text) != 2 {
+					v.errorf(line, "field code %%%c must be a lone argument", code)
+				}
+				if code != 'i' {
+					targets++
+				}
+			case strings.IndexByte(deprecatedCodes, code) >= 0:
+				v.warnf(line, "field code %%%c is deprecated", code)
+			default:
+				v.errorf(line, "unknown field code %%%c", code)
+			}
+		}
+	}
+	if targets > 1 {
+		v.errorf(line, "Exec may contain at most one of the field codes %%f, %%F, %%u and %%U")
+	}
+}
+
+// validateCategories validates the Categories against the registry.
+func (v *validator) validateCategories() {
+	categories, err := v.f.StringList(Group, "Categories")
+	if err != nil {
+		return
+	}
+	line := v.lines[Group]["Categories"]
+	main := false
+	for _, c := range categories {
+		switch {
+		case strings.HasPrefix(c, "X-"):
+		case contains(MainCategories, c):
+			main = true
+		case contains(AdditionalCategories, c):
+		default:
+			v.errorf(line, "category %q is not registered; extension categories must start with \"X-\"", c)
+		}
+	}
+	if !main && len(categories) > 0 {
+		v.warnf(line, "Categories has no main category")
+	}
+}
+
+// validateShowIn validates the OnlyShowIn and NotShowIn desktop environments.
+func (v *validator) validateShowIn() {
+	only, _ := v.f.StringList(Group, "OnlyShowIn")
+	not, _ := v.f.StringList(Group, "NotShowIn")
+	for _, key := range []string{"OnlyShowIn", "NotShowIn"} {
+		list, _ := v.f.StringList(Group, key)
+		for _, d := range list {
+			if !strings.HasPrefix(d, "X-") && !contains(Desktops, d) {
+				v.warnf(v.lines[Group][key], "desktop environment %q of key %q is not registered", d, key)
+			}
+		}
+	}
+	for _, d := range only {
+		if contains(not, d) {
+			v.errorf(v.lines[Group]["NotShowIn"], "desktop environment %q is in both OnlyShowIn and NotShowIn", d)
+		}
+	}
+}
+
+// validateMimeType validates the syntax of the MIME types.
+func (v *validator) validateMimeType() {
+	types, _ := v.f.StringList(Group, "MimeType")
+	for _, t := range types {
+		if !mimeTypeRe.MatchString(t) {
+			v.errorf(v.lines[Group]["MimeType"], "value %q of key %q is not a MIME type", t, "MimeType")
+		}
+	}
+}
+
+// iconExts is the file name extensions of icons.
+var iconExts = []string{".png", ".svg", ".xpm"}
+
+// validateIcon validates that the Icon of the group can be resolved against the data directories.
+func (v *validator) validateIcon(group string) {
+	icon, err := v.f.String(group, "Icon")
+	if err != nil || icon == "" {
+		return
+	}
+	line := v.lines[group]["Icon"]
+	if filepath.IsAbs(icon) {
+		if !v.r.Exists(icon) {
+			v.warnf(line, "icon file %q does not exist", icon)
+		}
+		return
+	}
+	if ext := filepath.Ext(icon); contains(iconExts, ext) {
+		v.warnf(line, "icon name %q should not have the extension %q", icon, ext)
+		icon = strings.TrimSuffix(icon, ext)
+	}
+	if !v.findIcon(icon) {
+		v.warnf(line, "icon %q is not found in the icon themes or pixmaps of the data directories", icon)
+	}
+}
+
+// findIcon searches the icon name in icons/<theme>/<size>/<context> and pixmaps of the data directories.
+func (v *validator) findIcon(name string) bool {
+	fsys := v.r.FS()
+	dirs := append([]string{v.r.DataHome()}, filepath.SplitList(v.r.DataDirs())...)
+	var candidates []string
+	if home := v.r.Home(); home != "" {
+		candidates = append(candidates, filepath.Join(home, ".icons"))
+	}
+	for _, dir := range dirs {
+		if dir == "" {
+			continue
+		}
+		candidates = append(candidates, filepath.Join(dir, "icons"))
+		for _, ext := range iconExts {
+			if v.r.Exists(filepath.Join(dir, "pixmaps", name+ext)) {
+				return true
+			}
+		}
+	}
+	for _, base := range candidates {
+		themes, _ := fsys.ReadDir(base)
+		for _, theme := range themes {
+			sizes, _ := fsys.ReadDir(filepath.Join(base, theme.Name()))
+			for _, size := range sizes {
+				contexts, _ := fsys.ReadDir(filepath.Join(base, theme.Name(), size.Name()))
+				for _, context := range contexts {
+					for _, ext := range iconExts {
+						if v.r.Exists(filepath.Join(base, theme.Name(), size.Name(), context.Name(), name+ext)) {
+							return true
+						}
+					}
+				}
+			}
+		}
+	}
+	return false
+}
+
+// validateTryExec validates that the TryExec program is an executable file in $PATH.
+func (v *validator) validateTryExec() {
+	prog, err := v.f.String(Group, "TryExec")
+	if err != nil || prog == "" {
+		return
+	}
+	if lookPath(v.r, prog) == "" {
+		v.warnf(v.lines[Group]["TryExec"], "TryExec program %q is not found in $PATH or is not executable", prog)
+	}
+}
+
+// validateActions validates the Desktop Action groups against the Actions key.
+func (v *validator) validateActions(groups []string, dbus bool) {
+	actions, _ := v.f.StringList(Group, "Actions")
+	for _, id := range actions {
+		group := ActionGroupPrefix + id
+		if !v.f.HasGroup(group) {
+			v.errorf(v.lines[Group]["Actions"], "action %q has no group %q", id, group)
+		}
+	}
+	for _, group := range groups {
+		if !strings.HasPrefix(group, ActionGroupPrefix) {
+			continue
+		}
+		id := strings.TrimPrefix(group, ActionGroupPrefix)
+		if !contains(actions, id) {
+			v.errorf(v.headers[group], "group %q is not listed in the Actions key", group)
+		}
+		v.validateGroup(group, actionKeys, "")
+		if !v.f.HasKey(group, "Name") {
+			v.errorf(v.headers[group], "required key %q in group %q is not present", "Name", group)
+		}
+		if !dbus && !v.f.HasKey(group, "Exec") {
+			v.errorf(v.headers[group], "required key %q in group %q is not present", "Exec", group)
+		}
+		v.validateExec(group)
+		v.validateIcon(group)
+	}
+}
@@ -0,0 +1,180 @@
+// Copyright 2026 The go-xdgbasedir Authors. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package desktopentry_test
+
+import (
+	"os"
+	"strings"
+	"testing"
+
+	"github.com/zchee/go-xdgbasedir"
+	"github.com/zchee/go-xdgbasedir/desktopentry"
+	"github.com/zchee/go-xdgbasedir/xdgtest"
+)
+
+type problem struct {
+	line     int
+	severity desktopentry.Severity
+	msg      string
+}
+
+func TestValidate(t *testing.T) {
+	t.Parallel()
+
+	tr := xdgtest.New(t, xdgtest.WithFiles(map[string]string{
+		"usr/share/icons/hicolor/48x48/apps/editor.png": "",
+		"usr/share/pixmaps/viewer.xpm":                  "",
+		"bin/editor":                                    "",
+	}))
+	if err := os.Chmod(tr.Path("bin/editor"), 0755); err != nil {
+		t.Fatal(err)
+	}
+	r := tr.Resolver(xdgbasedir.WithEnv(append(tr.Env(), "PATH="+tr.Path("bin"))))
+
+	tests := []struct {
+		name string
+		data string
+		want []problem
+	}{
+		{
+			name: "valid",
+			data: "[Desktop Entry]\nType=Application\nVersion=1.5\nName=Editor\nName[de]=Editor\nIcon=editor\nExec=editor %F\nTryExec=editor\nCategories=Utility;TextEditor;\nMimeType=text/plain;\nActions=new;\nX-Custom=1\n\n[Desktop Action new]\nName=New\nExec=editor --new\nIcon=viewer\n",
+		},
+		{
+			name: "parse error",
+			data: "[Desktop Entry]\nType=Application\ngarbage\n",
+			want: []problem{{3, desktopentry.Error, "is not a group, key or comment"}},
+		},
+		{
+			name: "empty",
+			data: "",
+			want: []problem{{0, desktopentry.Error, "has no \"Desktop Entry\" group"}},
+		},
+		{
+			name: "comments only",
+			data: "# nothing here\n\n",
+			want: []problem{{0, desktopentry.Error, "has no \"Desktop Entry\" group"}},
+		},
+		{
+			name: "missing keys",
+			data: "[Desktop Entry]\nComment=nothing\n",
+			want: []problem{{0, desktopentry.Error, "\"Type\""}, {0, desktopentry.Error, "\"Name\""}},
+		},
+		{
+			name: "application without Exec",
+			data: "[Desktop Entry]\nType=Application\nName=App\n",
+			want: []problem{{0, desktopentry.Error, "\"Exec\""}},
+		},
+		{
+			name: "dbus activatable without Exec",
+			data: "[Desktop Entry]\nType=Application\nName=App\nDBusActivatable=true\n",
+		},
+		{
+			name: "link without URL",
+			data: "[Desktop Entry]\nType=Link\nName=Link\n",
+			want: []problem{{0, desktopentry.Error, "\"URL\""}},
+		},
+		{
+			name: "invalid type",
+			data: "[Desktop Entry]\nType=Program\nName=App\n",
+			want: []problem{{2, desktopentry.Error, "unknown type"}},
+		},
+		{
+			name: "first group",
+			data: "[X-Other]\nA=b\n[Desktop Entry]\nType=Directory\nName=Dir\n",
+			want: []problem{{1, desktopentry.Error, "first group"}},
+		},
+		{
+			name: "keys",
+			data: "[Desktop Entry]\nType=Application\nName=App\nExec=app\nName=Dup\nEncoding=UTF-8\nFoo=bar\nExec[de]=app\nName[de_DE.UTF-8]=App\nName[DE]=App\nTerminal=1\nNoDisplay=yes\nURL=https://example.org\n",
+			want: []problem{
+				{5, desktopentry.Error, "already defined at line 3"},
+				{6, desktopentry.Warning, "\"Encoding\" is deprecated"},
+				{7, desktopentry.Error, "unknown key \"Foo\""},
+				{8, desktopentry.Error, "\"Exec\" cannot be localized"},
+				{9, desktopentry.Warning, "has an encoding"},
+				{10, desktopentry.Error, "invalid locale"},
+				{11, desktopentry.Warning, "boolean value \"1\""},
+				{12, desktopentry.Error, "not a boolean"},
+			},
+		},
+		{
+			name: "exec",
+			data: "[Desktop Entry]\nType=Application\nName=App\nExec=app %f %U --x=%F %d %z \"%u\"\n",
+			want: []problem{
+				{4, desktopentry.Error, "%F must be a lone argument"},
+				{4, desktopentry.Warning, "%d is deprecated"},
+				{4, desktopentry.Error, "unknown field code %z"},
+				{4, desktopentry.Error, "%u must not be used inside a quoted argument"},
+				{4, desktopentry.Error, "at most one"},
+			},
+		},
+		{
+			name: "categories and desktops",
+			data: "[Desktop Entry]\nType=Application\nName=App\nExec=app\nCategories=TextEditor;Bogus;X-Mine;\nOnlyShowIn=GNOME;Weird;\nNotShowIn=GNOME;\nMimeType=text;\n",
+			want: []problem{
+				{5, desktopentry.Error, "category \"Bogus\""},
+				{5, desktopentry.Warning, "no main category"},
+				{6, desktopentry.Warning, "\"Weird\""},
+				{7, desktopentry.Error, "in both OnlyShowIn and NotShowIn"},
+				{8, desktopentry.Error, "\"text\" of key \"MimeType\" is not a MIME type"},
+			},
+		},
+		{
+			name: "icon and tryexec",
+			data: "[Desktop Entry]\nType=Application\nName=App\nExec=app\nIcon=editor.png\nTryExec=missing\n",
+			want: []problem{
+				{5, desktopentry.Warning, "should not have the extension"},
+				{6, desktopentry.Warning, "TryExec program \"missing\""},
+			},
+		},
+		{
+			name: "unresolvable icon",
+			data: "[Desktop Entry]\nType=Application\nName=App\nExec=app\nIcon=nowhere\n",
+			want: []problem{{5, desktopentry.Warning, "icon \"nowhere\" is not found"}},
+		},
+		{
+			name: "actions",
+			data: "[Desktop Entry]\nType=Application\nName=App\nExec=app\nActions=a;b;\n\n[Desktop Action a]\nExec=app -a\n\n[Desktop Action c]\nName=C\nExec=app -c\n\n[Other]\nK=v\n",
+			want: []problem{
+				{5, desktopentry.Error, "action \"b\" has no group"},
+				{7, desktopentry.Error, "\"Name\" in group \"Desktop Action a\""},
+				{10, desktopentry.Error, "\"Desktop Action c\" is not listed"},
+				{14, desktopentry.Error, "unknown group \"Other\""},
+			},
+		},
+	}
+	for _, tt := range tests {
+		tt := tt
+		t.Run(tt.name, func(t *testing.T) {
+			t.Parallel()
+
+			problems := desktopentry.Validate(r, "app.desktop", []byte(tt.data))
+			var got []string
+			for _, p := range problems {
+				got = append(got, p.String())
+			}
+			if len(problems) != len(tt.want) {
+				t.Fatalf("Validate() = %d problems, want %d:\n%s", len(problems), len(tt.want), strings.Join(got, "\n"))
+			}
+			hasErrors := false
+			for _, want := range tt.want {
+				hasErrors = hasErrors || want.severity == desktopentry.Error
+				found := false
+				for _, p := range problems {
+					if p.Line == want.line && p.Severity == want.severity && strings.Contains(p.Msg, want.msg) {
+						found = true
+					}
+				}
+				if !found {
+					t.Errorf("%s problem at line %d with %q is not reported:\n%s", want.severity, want.line, want.msg, strings.Join(got, "\n"))
+				}
+			}
+			if got := problems.HasErrors(); got != hasErrors {
+				t.Errorf("HasErrors() = %v, want %v", got, hasErrors)
+			}
+		})
+	}
+}
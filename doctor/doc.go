@@ -0,0 +1,11 @@
+// Copyright 2026 The go-xdgbasedir Authors. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+// Package doctor implements a conformance audit of the current environment against the XDG Base Directory Specification 0.8.
+//
+//	https://specifications.freedesktop.org/basedir-spec/0.8/
+//
+// Each problem found is reported as a Finding with a Severity and a suggested fix,
+// so that it can be run on user machines by support staff via the xdg-doctor command.
+package doctor // import "github.com/zchee/go-xdgbasedir/doctor"
@@ -0,0 +1,441 @@
+// Copyright 2026 The go-xdgbasedir Authors. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package doctor
+
+import (
+	"fmt"
+	"os"
+	"path/filepath"
+	"runtime"
+	"strings"
+
+	"github.com/zchee/go-xdgbasedir"
+)
+
+// Severity represents a severity of the Finding.
+type Severity int
+
+const (
+	// Info is a finding which is allowed by the specification but worth knowing.
+	Info Severity = iota
+	// Warning is a finding which may cause applications to misbehave.
+	Warning
+	// Error is a violation of the specification.
+	Error
+)
+
+// String implements fmt.Stringer.
+func (s Severity) String() string {
+	switch s {
+	case Info:
+		return "info"
+	case Warning:
+		return "warning"
+	case Error:
+		return "error"
+	default:
+		return fmt.Sprintf("Severity(%d)", int(s))
+	}
+}
+
+// MarshalText implements encoding.TextMarshaler.
+func (s Severity) MarshalText() ([]byte, error) {
+	return []byte(s.String()), nil
+}
+
+// List of Finding.Check names.
+const (
+	CheckHome        = "home"
+	CheckRelative    = "relative-path"
+	CheckTilde       = "tilde-path"
+	CheckEmptyEntry  = "empty-entry"
+	CheckMissing     = "missing-dir"
+	CheckNotDir      = "not-dir"
+	CheckUnwritable  = "unwritable"
+	CheckDuplicate   = "duplicate-dir"
+	CheckNetworkFS   = "network-fs"
+	CheckPermissions = "permissions"
+	CheckRuntimeDir  = "runtime-dir"
+	CheckOwner       = "owner"
+	CheckPath        = "local-bin-path"
+)
+
+// Finding represents a problem found by the audit.
+type Finding struct {
+	// Severity is the severity of the finding.
+	Severity Severity `json:"severity"`
+	// Check is the name of the check which reported the finding.
+	Check string `json:"check"`
+	// Var is the name of the environment variable the finding relates to, if any.
+	Var string `json:"var,omitempty"`
+	// Path is the directory path the finding relates to, if any.
+	Path string `json:"path,omitempty"`
+	// Message describes the problem.
+	Message string `json:"message"`
+	// Fix is a suggested fix for the problem.
+	Fix string `json:"fix,omitempty"`
+}
+
+// String implements fmt.Stringer.
+func (f Finding) String() string {
+	var b strings.Builder
+	b.WriteString(f.Severity.String())
+	b.WriteString(": ")
+	if f.Var != "" {
+		b.WriteString(f.Var)
+		b.WriteString(": ")
+	}
+	b.WriteString(f.Message)
+	if f.Fix != "" {
+		b.WriteString("\n\tfix: ")
+		b.WriteString(f.Fix)
+	}
+	return b.String()
+}
+
+// Findings represents a list of Finding.
+type Findings []Finding
+
+// Max returns the highest Severity in fs. It returns -1 if fs is empty.
+func (fs Findings) Max() Severity {
+	sev := Severity(-1)
+	for _, f := range fs {
+		if f.Severity > sev {
+			sev = f.Severity
+		}
+	}
+	return sev
+}
+
+// baseHomes is the list of single base directories of the specification.
+var baseHomes = []struct {
+	env string
+	fn  func() string
+}{
+	{env: xdgbasedir.EnvDataHome, fn: xdgbasedir.DataHome},
+	{env: xdgbasedir.EnvConfigHome, fn: xdgbasedir.ConfigHome},
+	{env: xdgbasedir.EnvStateHome, fn: xdgbasedir.StateHome},
+	{env: xdgbasedir.EnvCacheHome, fn: xdgbasedir.CacheHome},
+}
+
+// baseDirs is the list of preference ordered base directories of the specification.
+var baseDirs = []struct {
+	env string
+	fn  func() string
+}{
+	{env: xdgbasedir.EnvDataDirs, fn: xdgbasedir.DataDirs},
+	{env: xdgbasedir.EnvConfigDirs, fn: xdgbasedir.ConfigDirs},
+}
+
+// Run audits the current environment and returns the list of Finding in the order of checks.
+func Run() Findings {
+	var fs Findings
+	report := func(f Finding) { fs = append(fs, f) }
+
+	checkHome(report)
+	for _, b := range baseHomes {
+		checkBaseHome(report, b.env, b.fn())
+	}
+	checkConfigPermissions(report, xdgbasedir.ConfigHome())
+	for _, b := range baseDirs {
+		checkBaseDirs(report, b.env, b.fn())
+	}
+	checkRuntimeDir(report)
+	checkLocalBin(report)
+
+	return fs
+}
+
+// checkHome checks the $HOME directory which all default values are based on.
+func checkHome(report func(Finding)) {
+	env := "HOME"
+	if runtime.GOOS == "windows" {
+		env = "USERPROFILE"
+	}
+	dir := os.Getenv(env)
+	if dir == "" {
+		report(Finding{
+			Severity: Error,
+			Check:    CheckHome,
+			Var:      env,
+			Message:  "not set; default base directories cannot be determined reliably",
+			Fix:      fmt.Sprintf("set $%s to the user home directory", env),
+		})
+		return
+	}
+	if !filepath.IsAbs(dir) {
+		report(Finding{
+			Severity: Error,
+			Check:    CheckHome,
+			Var:      env,
+			Path:     dir,
+			Message:  fmt.Sprintf("%q is not an absolute path", dir),
+			Fix:      fmt.Sprintf("set $%s to the absolute path of the user home directory", env),
+		})
+		return
+	}
+	checkDir(report, env, dir, Error)
+}
+
+// checkValue checks the raw environment variable value of a single base directory.
+// It reports whether the value can be used as a base directory.
+func checkValue(report func(Finding), env, val string) bool {
+	switch {
+	case strings.HasPrefix(val, "~"):
+		report(Finding{
+			Severity: Warning,
+			Check:    CheckTilde,
+			Var:      env,
+			Path:     val,
+			Message:  fmt.Sprintf("%q starts with a tilde which is only expanded by some applications", val),
+			Fix:      fmt.Sprintf("replace the tilde in $%s with the absolute home directory path", env),
+		})
+		return true
+	case !filepath.IsAbs(val):
+		report(Finding{
+			Severity: Error,
+			Check:    CheckRelative,
+			Var:      env,
+			Path:     val,
+			Message:  fmt.Sprintf("%q is a relative path; the specification requires applications to ignore it", val),
+			Fix:      fmt.Sprintf("set $%s to an absolute path or unset it", env),
+		})
+		return false
+	}
+	return true
+}
+
+// checkBaseHome checks the single base directory.
+func checkBaseHome(report func(Finding), env, dir string) {
+	if val := os.Getenv(env); val != "" && !checkValue(report, env, val) {
+		return
+	}
+	if !checkDir(report, env, dir, Warning) {
+		return
+	}
+	if !isWritable(dir) {
+		report(Finding{
+			Severity: Error,
+			Check:    CheckUnwritable,
+			Var:      env,
+			Path:     dir,
+			Message:  fmt.Sprintf("%s is not writable by the current user", dir),
+			Fix:      fmt.Sprintf("chown %s %s && chmod u+rwx %s", currentUser(), dir, dir),
+		})
+	}
+	checkNetworkFS(report, env, dir, Warning)
+}
+
+// checkDir checks that dir exists and is a directory. missing is the Severity reported if dir does not exist.
+// It reports whether dir is an existing directory.
+func checkDir(report func(Finding), env, dir string, missing Severity) bool {
+	fi, err := os.Stat(dir)
+	switch {
+	case os.IsNotExist(err):
+		report(Finding{
+			Severity: missing,
+			Check:    CheckMissing,
+			Var:      env,
+			Path:     dir,
+			Message:  fmt.Sprintf("%s does not exist", dir),
+			Fix:      fmt.Sprintf("mkdir -p -m 0700 %s", dir),
+		})
+		return false
+	case err != nil:
+		report(Finding{
+			Severity: Error,
+			Check:    CheckMissing,
+			Var:      env,
+			Path:     dir,
+			Message:  fmt.Sprintf("could not stat %s: %v", dir, err),
+		})
+		return false
+	case !fi.IsDir():
+		report(Finding{
+			Severity: Error,
+			Check:    CheckNotDir,
+			Var:      env,
+			Path:     dir,
+			Message:  fmt.Sprintf("%s is not a directory", dir),
+			Fix:      fmt.Sprintf("move %s away and create it as a directory", dir),
+		})
+		return false
+	}
+	return true
+}
+
+// checkConfigPermissions checks that the user configuration directory is not accessible by other users.
+func checkConfigPermissions(report func(Finding), dir string) {
+	if runtime.GOOS == "windows" {
+		return
+	}
+	fi, err := os.Stat(dir)
+	if err != nil || !fi.IsDir() {
+		// already reported by checkBaseHome
+		return
+	}
+	perm := fi.Mode().Perm()
+	switch {
+	case perm&0022 != 0:
+		report(Finding{
+			Severity: Error,
+			Check:    CheckPermissions,
+			Var:      xdgbasedir.EnvConfigHome,
+			Path:     dir,
+			Message:  fmt.Sprintf("%s is group or world-writable (mode %#o)", dir, perm),
+			Fix:      fmt.Sprintf("chmod go-rwx %s", dir),
+		})
+	case perm&0044 != 0:
+		report(Finding{
+			Severity: Warning,
+			Check:    CheckPermissions,
+			Var:      xdgbasedir.EnvConfigHome,
+			Path:     dir,
+			Message:  fmt.Sprintf("%s is group or world-readable (mode %#o)", dir, perm),
+			Fix:      fmt.Sprintf("chmod go-rwx %s", dir),
+		})
+	}
+}
+
+// checkBaseDirs checks the preference ordered set of base directories.
+func checkBaseDirs(report func(Finding), env, dirs string) {
+	raw := os.Getenv(env) != ""
+	seen := make(map[string]bool)
+	for _, dir := range strings.Split(dirs, string(filepath.ListSeparator)) {
+		if dir == "" {
+			report(Finding{
+				Severity: Warning,
+				Check:    CheckEmptyEntry,
+				Var:      env,
+				Message:  fmt.Sprintf("%q contains an empty entry", dirs),
+				Fix:      fmt.Sprintf("remove the empty entry from $%s", env),
+			})
+			continue
+		}
+		if raw && !checkValue(report, env, dir) {
+			continue
+		}
+		clean := filepath.Clean(dir)
+		if seen[clean] {
+			report(Finding{
+				Severity: Warning,
+				Check:    CheckDuplicate,
+				Var:      env,
+				Path:     dir,
+				Message:  fmt.Sprintf("%s appears more than once", dir),
+				Fix:      fmt.Sprintf("remove the duplicate entry from $%s", env),
+			})
+			continue
+		}
+		seen[clean] = true
+
+		if checkDir(report, env, dir, Info) {
+			checkNetworkFS(report, env, dir, Warning)
+		}
+	}
+}
+
+// checkRuntimeDir checks the ownership and the access mode of the user runtime directory.
+func checkRuntimeDir(report func(Finding)) {
+	if runtime.GOOS == "windows" {
+		return
+	}
+
+	const env = xdgbasedir.EnvRuntimeDir
+	val := os.Getenv(env)
+	if val == "" {
+		report(Finding{
+			Severity: Warning,
+			Check:    CheckRuntimeDir,
+			Var:      env,
+			Message:  "not set; applications fall back to a replacement directory",
+			Fix:      "log in through a session manager which sets $XDG_RUNTIME_DIR, such as pam_systemd",
+		})
+		return
+	}
+	if !checkValue(report, env, val) {
+		return
+	}
+	dir := xdgbasedir.RuntimeDir()
+	if !checkDir(report, env, dir, Error) {
+		return
+	}
+
+	fi, err := os.Stat(dir)
+	if err != nil {
+		return
+	}
+	if uid, ok := owner(fi); ok && uid != os.Getuid() {
+		report(Finding{
+			Severity: Error,
+			Check:    CheckOwner,
+			Var:      env,
+			Path:     dir,
+			Message:  fmt.Sprintf("%s is owned by uid %d, not by the current user (uid %d)", dir, uid, os.Getuid()),
+			Fix:      fmt.Sprintf("chown %s %s", currentUser(), dir),
+		})
+	}
+	if perm := fi.Mode().Perm(); perm != 0700 {
+		report(Finding{
+			Severity: Error,
+			Check:    CheckPermissions,
+			Var:      env,
+			Path:     dir,
+			Message:  fmt.Sprintf("%s has mode %#o; the specification requires 0700", dir, perm),
+			Fix:      fmt.Sprintf("chmod 0700 %s", dir),
+		})
+	}
+	checkNetworkFS(report, env, dir, Error)
+}
+
+// checkLocalBin checks that $HOME/.local/bin is in the $PATH.
+func checkLocalBin(report func(Finding)) {
+	if runtime.GOOS == "windows" {
+		return
+	}
+	home := os.Getenv("HOME")
+	if home == "" {
+		return
+	}
+
+	bin := filepath.Join(home, ".local", "bin")
+	for _, dir := range filepath.SplitList(os.Getenv("PATH")) {
+		if dir != "" && filepath.Clean(dir) == bin {
+			return
+		}
+	}
+	report(Finding{
+		Severity: Warning,
+		Check:    CheckPath,
+		Var:      "PATH",
+		Path:     bin,
+		Message:  fmt.Sprintf("%s is not in $PATH; user executables installed there are not found", bin),
+		Fix:      `export PATH="$HOME/.local/bin:$PATH"`,
+	})
+}
+
+// checkNetworkFS reports the finding with sev if dir is on a network filesystem.
+func checkNetworkFS(report func(Finding), env, dir string, sev Severity) {
+	fstype, ok := networkFS(dir)
+	if !ok {
+		return
+	}
+	report(Finding{
+		Severity: sev,
+		Check:    CheckNetworkFS,
+		Var:      env,
+		Path:     dir,
+		Message:  fmt.Sprintf("%s is on a network filesystem (%s); locking and atomic renames may be unreliable", dir, fstype),
+		Fix:      fmt.Sprintf("set $%s to a directory on a local filesystem", env),
+	})
+}
+
+// currentUser returns the current user name for use in suggested fixes.
+func currentUser() string {
+	if u := os.Getenv("USER"); u != "" {
+		return u
+	}
+	return "$USER"
+}
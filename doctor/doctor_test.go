@@ -0,0 +1,225 @@
+// Copyright 2026 The go-xdgbasedir Authors. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package doctor
+
+import (
+	"os"
+	"path/filepath"
+	"runtime"
+	"strings"
+	"testing"
+)
+
+// setupEnv sets all XDG environment variables to a conforming layout under a temporary directory.
+func setupEnv(t *testing.T) string {
+	t.Helper()
+
+	root := t.TempDir()
+	dirs := map[string]string{
+		"HOME":            "home",
+		"XDG_DATA_HOME":   filepath.Join("home", ".local", "share"),
+		"XDG_CONFIG_HOME": filepath.Join("home", ".config"),
+		"XDG_STATE_HOME":  filepath.Join("home", ".local", "state"),
+		"XDG_CACHE_HOME":  filepath.Join("home", ".cache"),
+		"XDG_DATA_DIRS":   filepath.Join("usr", "share"),
+		"XDG_CONFIG_DIRS": filepath.Join("etc", "xdg"),
+		"XDG_RUNTIME_DIR": "run",
+	}
+	for env, dir := range dirs {
+		dir = filepath.Join(root, dir)
+		if err := os.MkdirAll(dir, 0700); err != nil {
+			t.Fatal(err)
+		}
+		if err := os.Chmod(dir, 0700); err != nil {
+			t.Fatal(err)
+		}
+		t.Setenv(env, dir)
+	}
+	t.Setenv("PATH", filepath.Join(root, "home", ".local", "bin"))
+
+	return root
+}
+
+func findCheck(fs Findings, check string) (Finding, bool) {
+	for _, f := range fs {
+		if f.Check == check {
+			return f, true
+		}
+	}
+	return Finding{}, false
+}
+
+func TestRunClean(t *testing.T) {
+	setupEnv(t)
+
+	fs := Run()
+	for _, f := range fs {
+		if f.Severity > Info {
+			t.Errorf("unexpected finding: %v", f)
+		}
+	}
+}
+
+func TestRun(t *testing.T) {
+	if runtime.GOOS == "windows" {
+		t.Skip("permission checks are unix only")
+	}
+
+	tests := []struct {
+		name  string
+		setup func(t *testing.T, root string)
+		check string
+		sev   Severity
+	}{
+		{
+			name: "relative data home",
+			setup: func(t *testing.T, root string) {
+				t.Setenv("XDG_DATA_HOME", filepath.Join("relative", "share"))
+			},
+			check: CheckRelative,
+			sev:   Error,
+		},
+		{
+			name: "tilde cache home",
+			setup: func(t *testing.T, root string) {
+				t.Setenv("XDG_CACHE_HOME", "~/.cache")
+			},
+			check: CheckTilde,
+			sev:   Warning,
+		},
+		{
+			name: "nonexistent state home",
+			setup: func(t *testing.T, root string) {
+				t.Setenv("XDG_STATE_HOME", filepath.Join(root, "nonexistent"))
+			},
+			check: CheckMissing,
+			sev:   Warning,
+		},
+		{
+			name: "config home is a file",
+			setup: func(t *testing.T, root string) {
+				file := filepath.Join(root, "file")
+				if err := os.WriteFile(file, nil, 0600); err != nil {
+					t.Fatal(err)
+				}
+				t.Setenv("XDG_CONFIG_HOME", file)
+			},
+			check: CheckNotDir,
+			sev:   Error,
+		},
+		{
+			name: "world-readable config home",
+			setup: func(t *testing.T, root string) {
+				if err := os.Chmod(os.Getenv("XDG_CONFIG_HOME"), 0755); err != nil {
+					t.Fatal(err)
+				}
+			},
+			check: CheckPermissions,
+			sev:   Warning,
+		},
+		{
+			name: "world-writable config home",
+			setup: func(t *testing.T, root string) {
+				if err := os.Chmod(os.Getenv("XDG_CONFIG_HOME"), 0777); err != nil {
+					t.Fatal(err)
+				}
+			},
+			check: CheckPermissions,
+			sev:   Error,
+		},
+		{
+			name: "duplicate data dirs",
+			setup: func(t *testing.T, root string) {
+				dir := os.Getenv("XDG_DATA_DIRS")
+				t.Setenv("XDG_DATA_DIRS", dir+string(filepath.ListSeparator)+dir+string(filepath.Separator))
+			},
+			check: CheckDuplicate,
+			sev:   Warning,
+		},
+		{
+			name: "empty entry in config dirs",
+			setup: func(t *testing.T, root string) {
+				t.Setenv("XDG_CONFIG_DIRS", os.Getenv("XDG_CONFIG_DIRS")+string(filepath.ListSeparator))
+			},
+			check: CheckEmptyEntry,
+			sev:   Warning,
+		},
+		{
+			name: "missing config dirs entry",
+			setup: func(t *testing.T, root string) {
+				t.Setenv("XDG_CONFIG_DIRS", filepath.Join(root, "nonexistent"))
+			},
+			check: CheckMissing,
+			sev:   Info,
+		},
+		{
+			name: "runtime dir not set",
+			setup: func(t *testing.T, root string) {
+				t.Setenv("XDG_RUNTIME_DIR", "")
+			},
+			check: CheckRuntimeDir,
+			sev:   Warning,
+		},
+		{
+			name: "runtime dir mode",
+			setup: func(t *testing.T, root string) {
+				if err := os.Chmod(os.Getenv("XDG_RUNTIME_DIR"), 0750); err != nil {
+					t.Fatal(err)
+				}
+			},
+			check: CheckPermissions,
+			sev:   Error,
+		},
+		{
+			name: "local bin not in path",
+			setup: func(t *testing.T, root string) {
+				t.Setenv("PATH", filepath.Join("/usr", "bin"))
+			},
+			check: CheckPath,
+			sev:   Warning,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			root := setupEnv(t)
+			tt.setup(t, root)
+
+			fs := Run()
+			f, ok := findCheck(fs, tt.check)
+			if !ok {
+				t.Fatalf("Run() = %v, want %q finding", fs, tt.check)
+			}
+			if f.Severity != tt.sev {
+				t.Errorf("Severity = %v, want %v: %v", f.Severity, tt.sev, f)
+			}
+			if f.Message == "" {
+				t.Errorf("empty Message: %v", f)
+			}
+			if f.Severity > Info && f.Fix == "" {
+				t.Errorf("empty Fix: %v", f)
+			}
+		})
+	}
+}
+
+func TestFindingString(t *testing.T) {
+	f := Finding{
+		Severity: Error,
+		Check:    CheckPermissions,
+		Var:      "XDG_RUNTIME_DIR",
+		Message:  "bad mode",
+		Fix:      "chmod 0700 /run/user/1000",
+	}
+	want := "error: XDG_RUNTIME_DIR: bad mode\n\tfix: chmod 0700 /run/user/1000"
+	if got := f.String(); got != want {
+		t.Errorf("String() = %q, want %q", got, want)
+	}
+	if got := (Findings{f, {Severity: Info}}).Max(); got != Error {
+		t.Errorf("Max() = %v, want %v", got, Error)
+	}
+	if !strings.Contains(Severity(9).String(), "9") {
+		t.Errorf("unknown Severity String() = %q", Severity(9).String())
+	}
+}
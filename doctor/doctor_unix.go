@@ -0,0 +1,30 @@
+// Copyright 2026 The go-xdgbasedir Authors. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+//go:build !windows
+// +build !windows
+
+package doctor
+
+import (
+	"os"
+	"syscall"
+)
+
+// wOK is the access(2) mode for testing write permission.
+const wOK = 0x2
+
+// owner returns the owner uid of fi.
+func owner(fi os.FileInfo) (int, bool) {
+	st, ok := fi.Sys().(*syscall.Stat_t)
+	if !ok {
+		return 0, false
+	}
+	return int(st.Uid), true
+}
+
+// isWritable reports whether the current user can write to dir.
+func isWritable(dir string) bool {
+	return syscall.Access(dir, wOK) == nil
+}
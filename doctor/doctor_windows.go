@@ -0,0 +1,26 @@
+// Copyright 2026 The go-xdgbasedir Authors. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+//go:build windows
+// +build windows
+
+package doctor
+
+import (
+	"os"
+)
+
+// owner returns the owner uid of fi. Windows has no uid, so it always returns false.
+func owner(fi os.FileInfo) (int, bool) {
+	return 0, false
+}
+
+// isWritable reports whether the current user can write to dir.
+func isWritable(dir string) bool {
+	fi, err := os.Stat(dir)
+	if err != nil {
+		return false
+	}
+	return fi.Mode().Perm()&0200 != 0
+}
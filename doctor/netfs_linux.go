@@ -0,0 +1,33 @@
+// Copyright 2026 The go-xdgbasedir Authors. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package doctor
+
+import (
+	"syscall"
+)
+
+// networkFSMagic is the list of statfs(2) f_type magic numbers of network filesystems.
+var networkFSMagic = map[uint32]string{
+	0x6969:     "nfs",
+	0x517b:     "smb",
+	0xff534d42: "cifs",
+	0xfe534d42: "smb2",
+	0x5346414f: "afs",
+	0x73757245: "coda",
+	0x00c36400: "ceph",
+	0x01021997: "9p",
+	0x47504653: "gpfs",
+	0x0bd00bd0: "lustre",
+}
+
+// networkFS returns the filesystem type name if dir is on a network filesystem.
+func networkFS(dir string) (string, bool) {
+	var st syscall.Statfs_t
+	if err := syscall.Statfs(dir, &st); err != nil {
+		return "", false
+	}
+	name, ok := networkFSMagic[uint32(st.Type)]
+	return name, ok
+}
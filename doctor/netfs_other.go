@@ -0,0 +1,14 @@
+// Copyright 2026 The go-xdgbasedir Authors. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+//go:build !linux
+// +build !linux
+
+package doctor
+
+// networkFS returns the filesystem type name if dir is on a network filesystem.
+// Detection is only implemented on linux.
+func networkFS(dir string) (string, bool) {
+	return "", false
+}
@@ -0,0 +1,129 @@
+// Copyright 2026 The go-xdgbasedir Authors. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package xdgbasedir
+
+import (
+	"errors"
+	"io/fs"
+	"path/filepath"
+	"sort"
+	"strings"
+)
+
+// devNull is the symbolic link target which masks a file, same as systemd.
+const devNull = "/dev/null"
+
+// DropIns returns the effective configuration files of rel with its drop-in fragments, gathered across
+// ConfigHome and each ConfigDirs in the way of systemd.
+//
+// See Resolver.DropIns for the details.
+func DropIns(rel string) ([]string, error) {
+	return std.DropIns(rel)
+}
+
+// DropIns returns the effective configuration files of rel with its drop-in fragments, gathered across
+// r's ConfigHome and each ConfigDirs in the way of systemd.
+//
+// The first element is the highest precedence rel file, such as "app/app.conf", if any. The rest are the
+// fragments in the rel+".d" directories, such as "app/app.conf.d/10-foo.conf", sorted by filename. The fragments
+// must have the same extension as rel, if any. A fragment in a higher precedence directory replaces the same
+// named fragment in lower ones. An empty file or a symbolic link to /dev/null masks the same named file,
+// including rel itself, of the same and lower precedence directories, and is not returned.
+func (r *Resolver) DropIns(rel string) ([]string, error) {
+	dirs := append([]string{r.ConfigHome()}, splitList(r.ConfigDirs())...)
+
+	var files []string
+	for _, dir := range dirs {
+		path := filepath.Join(dir, rel)
+		masked, ok, err := r.maskable(path)
+		if err != nil {
+			return nil, err
+		}
+		if !ok {
+			continue
+		}
+		if !masked {
+			files = append(files, path)
+		}
+		break
+	}
+
+	ext := filepath.Ext(rel)
+	fragments := make(map[string]string) // name to path, empty if masked
+	for _, dir := range dirs {
+		dropin := filepath.Join(dir, rel+".d")
+		entries, err := r.FS().ReadDir(dropin)
+		if errors.Is(err, fs.ErrNotExist) {
+			continue
+		}
+		if err != nil {
+			return nil, err
+		}
+		for _, e := range entries {
+			name := e.Name()
+			if _, ok := fragments[name]; ok {
+				continue
+			}
+			if ext != "" && !strings.HasSuffix(name, ext) {
+				continue
+			}
+			path := filepath.Join(dropin, name)
+			masked, ok, err := r.maskable(path)
+			if err != nil {
+				return nil, err
+			}
+			if !ok {
+				continue
+			}
+			if masked {
+				path = ""
+			}
+			fragments[name] = path
+		}
+	}
+
+	names := make([]string, 0, len(fragments))
+	for name, path := range fragments {
+		if path != "" {
+			names = append(names, name)
+		}
+	}
+	sort.Strings(names)
+	for _, name := range names {
+		files = append(files, fragments[name])
+	}
+
+	return files, nil
+}
+
+// maskable reports whether path is a regular file or a mask, and whether it is a mask.
+func (r *Resolver) maskable(path string) (masked, ok bool, err error) {
+	fsys := r.FS()
+	lfi, err := fsys.Lstat(path)
+	if errors.Is(err, fs.ErrNotExist) {
+		return false, false, nil
+	}
+	if err != nil {
+		return false, false, err
+	}
+	if lfi.Mode()&fs.ModeSymlink != 0 {
+		if target, err := fsys.Readlink(path); err == nil && filepath.ToSlash(target) == devNull {
+			return true, true, nil
+		}
+	}
+
+	fi, err := r.stat(path)
+	if errors.Is(err, fs.ErrNotExist) {
+		// dangling symbolic link
+		return false, false, nil
+	}
+	if err != nil {
+		return false, false, err
+	}
+	if fi.IsDir() {
+		return false, false, nil
+	}
+	return fi.Size() == 0, true, nil
+}
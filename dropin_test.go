@@ -0,0 +1,80 @@
+// Copyright 2026 The go-xdgbasedir Authors. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package xdgbasedir
+
+import (
+	"path/filepath"
+	"reflect"
+	"testing"
+)
+
+func TestDropIns(t *testing.T) {
+	home := filepath.Join("/home", "gopher", ".config", "app")
+	etc := filepath.Join("/etc", "xdg", "app")
+	opt := filepath.Join("/opt", "etc", "xdg", "app")
+	m := NewMemFS(map[string]string{
+		filepath.Join(etc, "app.conf"):                     "etc",
+		filepath.Join(opt, "app.conf"):                     "opt",
+		filepath.Join(opt, "app.conf.d", "10-a.conf"):      "opt a",
+		filepath.Join(etc, "app.conf.d", "10-a.conf"):      "etc a",
+		filepath.Join(opt, "app.conf.d", "20-b.conf"):      "opt b",
+		filepath.Join(opt, "app.conf.d", "30-c.conf"):      "opt c",
+		filepath.Join(opt, "app.conf.d", "README"):         "ignored",
+		filepath.Join(home, "app.conf.d", "05-z.conf"):     "user z",
+		filepath.Join(home, "app.conf.d", "20-b.conf"):     "",
+		filepath.Join(etc, "app.conf.d", "40-d.conf"):      "etc d",
+		filepath.Join(home, "other.conf.d", "x.conf"):      "other",
+		filepath.Join(home, "masked.conf.d", "x.conf"):     "x",
+		filepath.Join(etc, "masked.conf"):                  "etc",
+		filepath.Join(opt, "masked.conf"):                  "opt",
+		filepath.Join(opt, "masked.conf.d", "y.conf"):      "y",
+		filepath.Join(home, "masked.conf.d", "y.conf"):     "y",
+		filepath.Join(home, "masked.conf.d", "empty.conf"): "",
+	})
+	if err := m.Symlink("/dev/null", filepath.Join(home, "app.conf.d", "30-c.conf")); err != nil {
+		t.Fatal(err)
+	}
+	if err := m.Symlink("/dev/null", filepath.Join(home, "masked.conf")); err != nil {
+		t.Fatal(err)
+	}
+	r := memResolver(m)
+
+	tests := []struct {
+		rel  string
+		want []string
+	}{
+		{
+			rel: filepath.Join("app", "app.conf"),
+			want: []string{
+				filepath.Join(etc, "app.conf"),
+				filepath.Join(home, "app.conf.d", "05-z.conf"),
+				filepath.Join(etc, "app.conf.d", "10-a.conf"),
+				filepath.Join(etc, "app.conf.d", "40-d.conf"),
+			},
+		},
+		{
+			rel: filepath.Join("app", "masked.conf"),
+			want: []string{
+				filepath.Join(home, "masked.conf.d", "x.conf"),
+				filepath.Join(home, "masked.conf.d", "y.conf"),
+			},
+		},
+		{
+			rel:  filepath.Join("app", "missing.conf"),
+			want: nil,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.rel, func(t *testing.T) {
+			got, err := r.DropIns(tt.rel)
+			if err != nil {
+				t.Fatal(err)
+			}
+			if !reflect.DeepEqual(got, tt.want) {
+				t.Errorf("DropIns(%q) = %v, want %v", tt.rel, got, tt.want)
+			}
+		})
+	}
+}
@@ -0,0 +1,229 @@
+// Copyright 2026 The go-xdgbasedir Authors. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package xdgbasedir
+
+import (
+	"path/filepath"
+	"sort"
+	"strings"
+)
+
+// List of the XDG base directory environment variable names.
+const (
+	EnvDataHome   = "XDG_DATA_HOME"
+	EnvConfigHome = "XDG_CONFIG_HOME"
+	EnvStateHome  = "XDG_STATE_HOME"
+	EnvDataDirs   = "XDG_DATA_DIRS"
+	EnvConfigDirs = "XDG_CONFIG_DIRS"
+	EnvCacheHome  = "XDG_CACHE_HOME"
+	EnvRuntimeDir = "XDG_RUNTIME_DIR"
+)
+
+// Environ builds the environment of a child process with an adjusted XDG layout.
+//
+// It starts from the environment of the Resolver it was created by, and the result of
+// Environ is ready to be used as exec.Cmd.Env.
+//
+//	cmd := exec.Command("helper")
+//	cmd.Env = xdgbasedir.Default().Environ().
+//		Prepend(xdgbasedir.EnvDataDirs, pluginDataDir).
+//		Environ()
+type Environ struct {
+	r    *Resolver
+	vars map[string]*string // nil value means unset
+	pin  bool
+}
+
+// Environ returns a new Environ which starts from r's environment.
+func (r *Resolver) Environ() *Environ {
+	return &Environ{
+		r:    r,
+		vars: make(map[string]*string),
+	}
+}
+
+// Set sets the key environment variable to value. If value is a list, its elements are joined with filepath.ListSeparator.
+func (e *Environ) Set(key string, value ...string) *Environ {
+	v := joinList(dedupe(value))
+	e.vars[key] = &v
+	return e
+}
+
+// Unset removes the key environment variable, so that the child process uses its default.
+func (e *Environ) Unset(key string) *Environ {
+	e.vars[key] = nil
+	return e
+}
+
+// Prepend adds dirs to the front of the key list environment variable.
+//
+// The list starts from the value resolved by the Resolver, so prepending to an unset XDG_DATA_DIRS
+// keeps the default directories. The first occurrence of a directory wins, so dirs already
+// in the list are moved to the front.
+func (e *Environ) Prepend(key string, dirs ...string) *Environ {
+	list := append(append([]string(nil), dirs...), e.list(key)...)
+	return e.Set(key, list...)
+}
+
+// Append adds dirs to the end of the key list environment variable.
+//
+// The list starts from the value resolved by the Resolver. The first occurrence of a directory wins,
+// so dirs already in the list keep their position.
+func (e *Environ) Append(key string, dirs ...string) *Environ {
+	list := append(e.list(key), dirs...)
+	return e.Set(key, list...)
+}
+
+// Pin makes Environ set all XDG base directory environment variables to their resolved absolute form,
+// so that the child process sees the same directories regardless of its own defaults or working directory.
+func (e *Environ) Pin() *Environ {
+	e.pin = true
+	return e
+}
+
+// Get returns the value of the key environment variable as it will be passed to the child process.
+func (e *Environ) Get(key string) (string, bool) {
+	if v, ok := e.vars[key]; ok {
+		if v == nil {
+			return "", false
+		}
+		return e.pinned(key, *v), true
+	}
+	if e.pin {
+		if v := e.resolve(key); v != "" {
+			return e.pinned(key, v), true
+		}
+	}
+	v := e.r.getenv(key)
+	return v, v != ""
+}
+
+// Environ returns the built environment in the form "key=value", ready for exec.Cmd.Env.
+func (e *Environ) Environ() []string {
+	keys := make(map[string]bool, len(e.vars))
+	for k := range e.vars {
+		keys[k] = true
+	}
+	if e.pin {
+		for _, k := range envKeys {
+			keys[k] = true
+		}
+	}
+
+	env := make([]string, 0, len(keys))
+	for _, kv := range e.r.environ() {
+		k := kv
+		if i := strings.IndexByte(kv, '='); i >= 0 {
+			k = kv[:i]
+		}
+		if !keys[k] {
+			env = append(env, kv)
+		}
+	}
+
+	sorted := make([]string, 0, len(keys))
+	for k := range keys {
+		sorted = append(sorted, k)
+	}
+	sort.Strings(sorted)
+	for _, k := range sorted {
+		if v, ok := e.Get(k); ok {
+			env = append(env, k+"="+v)
+		}
+	}
+
+	return env
+}
+
+// envKeys is the list of the XDG base directory environment variable names.
+var envKeys = []string{
+	EnvDataHome,
+	EnvConfigHome,
+	EnvStateHome,
+	EnvDataDirs,
+	EnvConfigDirs,
+	EnvCacheHome,
+	EnvRuntimeDir,
+}
+
+// resolve returns the value of the key XDG base directory resolved by the Resolver.
+// It returns the raw environment value for other keys.
+func (e *Environ) resolve(key string) string {
+	if isEnvKey(key) {
+		return e.r.resolve(key)
+	}
+	return e.r.getenv(key)
+}
+
+// list returns the current value of the key list environment variable.
+func (e *Environ) list(key string) []string {
+	if v, ok := e.vars[key]; ok {
+		if v == nil {
+			return nil
+		}
+		return splitList(*v)
+	}
+	return splitList(e.resolve(key))
+}
+
+// pinned returns the absolute form of the key XDG base directory value v if e is pinned.
+func (e *Environ) pinned(key, v string) string {
+	if !e.pin || !isEnvKey(key) {
+		return v
+	}
+	list := splitList(v)
+	for i, dir := range list {
+		dir = e.r.expandUser(dir)
+		if abs, err := filepath.Abs(dir); err == nil {
+			dir = abs
+		}
+		list[i] = dir
+	}
+	return joinList(dedupe(list))
+}
+
+// isEnvKey reports whether key is an XDG base directory environment variable name.
+func isEnvKey(key string) bool {
+	for _, k := range envKeys {
+		if k == key {
+			return true
+		}
+	}
+	return false
+}
+
+// splitList splits s by filepath.ListSeparator and drops empty elements.
+func splitList(s string) []string {
+	var list []string
+	for _, dir := range strings.Split(s, string(filepath.ListSeparator)) {
+		if dir != "" {
+			list = append(list, dir)
+		}
+	}
+	return list
+}
+
+// joinList joins list with filepath.ListSeparator.
+func joinList(list []string) string {
+	return strings.Join(list, string(filepath.ListSeparator))
+}
+
+// dedupe removes the empty and duplicate elements from list, keeping the first occurrence.
+func dedupe(list []string) []string {
+	seen := make(map[string]bool, len(list))
+	out := list[:0:0]
+	for _, dir := range list {
+		if dir == "" {
+			continue
+		}
+		clean := filepath.Clean(dir)
+		if seen[clean] {
+			continue
+		}
+		seen[clean] = true
+		out = append(out, dir)
+	}
+	return out
+}
@@ -0,0 +1,123 @@
+// Copyright 2026 The go-xdgbasedir Authors. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package xdgbasedir
+
+import (
+	"os"
+	"path/filepath"
+	"reflect"
+	"sort"
+	"strings"
+	"testing"
+)
+
+func list(dirs ...string) string {
+	return strings.Join(dirs, string(filepath.ListSeparator))
+}
+
+func TestEnviron(t *testing.T) {
+	wd, err := os.Getwd()
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	usrShare := filepath.Join("/usr", "share")
+	optShare := filepath.Join("/opt", "share")
+	plugin := filepath.Join("/opt", "plugin", "share")
+
+	tests := []struct {
+		name  string
+		env   []string
+		build func(e *Environ)
+		want  []string
+	}{
+		{
+			name:  "no changes",
+			env:   []string{"PATH=/bin", EnvDataHome + "=/data"},
+			build: func(e *Environ) {},
+			want:  []string{"PATH=/bin", EnvDataHome + "=/data"},
+		},
+		{
+			name: "set and unset",
+			env:  []string{"PATH=/bin", EnvDataHome + "=/data", EnvCacheHome + "=/cache"},
+			build: func(e *Environ) {
+				e.Set(EnvConfigHome, "/config").Unset(EnvCacheHome)
+			},
+			want: []string{"PATH=/bin", EnvDataHome + "=/data", EnvConfigHome + "=/config"},
+		},
+		{
+			name: "prepend to set list",
+			env:  []string{EnvDataDirs + "=" + list(usrShare, optShare)},
+			build: func(e *Environ) {
+				e.Prepend(EnvDataDirs, plugin)
+			},
+			want: []string{EnvDataDirs + "=" + list(plugin, usrShare, optShare)},
+		},
+		{
+			name: "prepend moves existing entry to front",
+			env:  []string{EnvDataDirs + "=" + list(usrShare, optShare)},
+			build: func(e *Environ) {
+				e.Prepend(EnvDataDirs, optShare+string(filepath.Separator))
+			},
+			want: []string{EnvDataDirs + "=" + list(optShare+string(filepath.Separator), usrShare)},
+		},
+		{
+			name: "append keeps existing entry position",
+			env:  []string{EnvDataDirs + "=" + list(usrShare, optShare)},
+			build: func(e *Environ) {
+				e.Append(EnvDataDirs, usrShare, plugin, plugin)
+			},
+			want: []string{EnvDataDirs + "=" + list(usrShare, optShare, plugin)},
+		},
+		{
+			name: "prepend to unset list keeps defaults",
+			env:  nil,
+			build: func(e *Environ) {
+				e.Prepend(EnvConfigDirs, plugin)
+			},
+			want: []string{EnvConfigDirs + "=" + list(append([]string{plugin}, splitList(configDirs())...)...)},
+		},
+		{
+			name: "pin",
+			env:  []string{"HOME=/home/gopher", EnvDataHome + "=~/data", EnvCacheHome + "=cache"},
+			build: func(e *Environ) {
+				e.Set(EnvConfigHome, "config").Pin()
+			},
+			want: []string{
+				"HOME=/home/gopher",
+				EnvDataHome + "=" + filepath.Join("/home", "gopher", "data"),
+				EnvConfigHome + "=" + filepath.Join(wd, "config"),
+				EnvStateHome + "=" + gopherDir(EnvStateHome),
+				EnvDataDirs + "=" + gopherDir(EnvDataDirs),
+				EnvConfigDirs + "=" + gopherDir(EnvConfigDirs),
+				EnvCacheHome + "=" + filepath.Join(wd, "cache"),
+				EnvRuntimeDir + "=" + gopherDir(EnvRuntimeDir),
+			},
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			e := New(WithEnv(tt.env)).Environ()
+			tt.build(e)
+			got := e.Environ()
+			sort.Strings(got)
+			sort.Strings(tt.want)
+			if !reflect.DeepEqual(got, tt.want) {
+				t.Errorf("Environ() = %q, want %q", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestEnvironGet(t *testing.T) {
+	e := New(WithEnv([]string{EnvDataHome + "=/data"})).Environ().Unset(EnvDataHome)
+	if v, ok := e.Get(EnvDataHome); ok {
+		t.Errorf("Get(%s) = %q, want unset", EnvDataHome, v)
+	}
+	e.Set(EnvDataHome, "/other")
+	if v, ok := e.Get(EnvDataHome); !ok || v != "/other" {
+		t.Errorf("Get(%s) = %q, %v, want %q", EnvDataHome, v, ok, "/other")
+	}
+}
@@ -0,0 +1,114 @@
+// Copyright 2026 The go-xdgbasedir Authors. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package xdgbasedir
+
+import (
+	"fmt"
+	"io/fs"
+	"os"
+	"sync/atomic"
+)
+
+// FileSystem is the filesystem used by the file operations of a Resolver, such as FindConfig and ConfigFile.
+//
+// Unlike io/fs.FS, names are host paths as returned by the Resolver, such as "/home/gopher/.config/app/app.conf".
+// The default is the OS filesystem, and MemFS is an in-memory implementation for unit tests and sandboxes.
+type FileSystem interface {
+	Open(name string) (fs.File, error)
+	Stat(name string) (fs.FileInfo, error)
+	Lstat(name string) (fs.FileInfo, error)
+	Readlink(name string) (string, error)
+	ReadDir(name string) ([]fs.DirEntry, error)
+	ReadFile(name string) ([]byte, error)
+	WriteFile(name string, data []byte, perm fs.FileMode) error
+	MkdirAll(path string, perm fs.FileMode) error
+	Remove(name string) error
+	Rename(oldpath, newpath string) error
+	Symlink(oldname, newname string) error
+}
+
+// OS returns the FileSystem of the operating system.
+func OS() FileSystem {
+	return osFS{}
+}
+
+// osFS is the FileSystem implemented by the os package.
+type osFS struct{}
+
+func (osFS) Open(name string) (fs.File, error) {
+	return os.Open(name)
+}
+
+func (osFS) Stat(name string) (fs.FileInfo, error) {
+	return os.Stat(name)
+}
+
+func (osFS) Lstat(name string) (fs.FileInfo, error) {
+	return os.Lstat(name)
+}
+
+func (osFS) Readlink(name string) (string, error) {
+	return os.Readlink(name)
+}
+
+func (osFS) ReadDir(name string) ([]fs.DirEntry, error) {
+	return os.ReadDir(name)
+}
+
+func (osFS) ReadFile(name string) ([]byte, error) {
+	return os.ReadFile(name)
+}
+
+func (osFS) WriteFile(name string, data []byte, perm fs.FileMode) error {
+	return os.WriteFile(name, data, perm)
+}
+
+func (osFS) MkdirAll(path string, perm fs.FileMode) error {
+	return os.MkdirAll(path, perm)
+}
+
+func (osFS) Remove(name string) error {
+	return os.Remove(name)
+}
+
+func (osFS) Rename(oldpath, newpath string) error {
+	return os.Rename(oldpath, newpath)
+}
+
+func (osFS) Symlink(oldname, newname string) error {
+	return os.Symlink(oldname, newname)
+}
+
+// WithFileSystem makes the file operations of the Resolver run against fsys instead of the OS filesystem.
+func WithFileSystem(fsys FileSystem) Option {
+	return func(r *Resolver) {
+		r.fsys = fsys
+	}
+}
+
+// FS returns the FileSystem of r.
+func (r *Resolver) FS() FileSystem {
+	if r.fsys == nil {
+		return osFS{}
+	}
+	return r.fsys
+}
+
+// tmpSeq makes the temporary file names of WriteFileAtomic unique within the process.
+var tmpSeq uint32
+
+// WriteFileAtomic writes data to the file name in fsys atomically, by writing a temporary file in the same
+// directory and renaming it to name, so that readers never see a partially written file.
+func WriteFileAtomic(fsys FileSystem, name string, data []byte, perm fs.FileMode) error {
+	tmp := fmt.Sprintf("%s.%d.%d.tmp", name, os.Getpid(), atomic.AddUint32(&tmpSeq, 1))
+	if err := fsys.WriteFile(tmp, data, perm); err != nil {
+		return err
+	}
+	if err := fsys.Rename(tmp, name); err != nil {
+		fsys.Remove(tmp)
+		return err
+	}
+	return nil
+}
@@ -0,0 +1,31 @@
+// Copyright 2026 The go-xdgbasedir Authors. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package xdgbasedir
+
+import (
+	"errors"
+	"io/fs"
+	"path/filepath"
+	"testing"
+)
+
+func TestWriteFileAtomic(t *testing.T) {
+	m := NewMemFS(map[string]string{
+		filepath.Join("/a", "f.txt"): "old",
+	})
+	name := filepath.Join("/a", "f.txt")
+	if err := WriteFileAtomic(m, name, []byte("new"), 0644); err != nil {
+		t.Fatal(err)
+	}
+	if data, err := m.ReadFile(name); err != nil || string(data) != "new" {
+		t.Errorf("ReadFile() = %q, %v", data, err)
+	}
+	if entries, err := m.ReadDir("/a"); err != nil || len(entries) != 1 {
+		t.Errorf("ReadDir() = %v, %v, want no temporary file left", entries, err)
+	}
+	if err := WriteFileAtomic(m, filepath.Join("/missing", "f.txt"), nil, 0644); !errors.Is(err, fs.ErrNotExist) {
+		t.Errorf("WriteFileAtomic() without parent error = %v, want ErrNotExist", err)
+	}
+}
@@ -0,0 +1,142 @@
+// Copyright 2026 The go-xdgbasedir Authors. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package xdgbasedir
+
+import (
+	"fmt"
+	"io/fs"
+	"path/filepath"
+)
+
+// FindConfig searches the rel file in ConfigHome and then each ConfigDirs, and returns the first existing path.
+func FindConfig(rel string) (string, error) {
+	return std.FindConfig(rel)
+}
+
+// FindData searches the rel file in DataHome and then each DataDirs, and returns the first existing path.
+func FindData(rel string) (string, error) {
+	return std.FindData(rel)
+}
+
+// ConfigPaths returns the paths of the rel file in ConfigHome and each ConfigDirs which exist, in precedence order.
+func ConfigPaths(rel string) []string {
+	return std.ConfigPaths(rel)
+}
+
+// DataPaths returns the paths of the rel file in DataHome and each DataDirs which exist, in precedence order.
+func DataPaths(rel string) []string {
+	return std.DataPaths(rel)
+}
+
+// Exists reports whether the path exists.
+func Exists(path string) bool {
+	return std.Exists(path)
+}
+
+// FindConfig searches the rel file in r's ConfigHome and then each ConfigDirs, and returns the first existing path.
+func (r *Resolver) FindConfig(rel string) (string, error) {
+	return r.find(rel, r.ConfigHome(), r.ConfigDirs())
+}
+
+// FindData searches the rel file in r's DataHome and then each DataDirs, and returns the first existing path.
+func (r *Resolver) FindData(rel string) (string, error) {
+	return r.find(rel, r.DataHome(), r.DataDirs())
+}
+
+// ConfigPaths returns the paths of the rel file in r's ConfigHome and each ConfigDirs which exist, in precedence order.
+func (r *Resolver) ConfigPaths(rel string) []string {
+	return r.findAll(rel, r.ConfigHome(), r.ConfigDirs())
+}
+
+// DataPaths returns the paths of the rel file in r's DataHome and each DataDirs which exist, in precedence order.
+func (r *Resolver) DataPaths(rel string) []string {
+	return r.findAll(rel, r.DataHome(), r.DataDirs())
+}
+
+// Exists reports whether the path exists. If r has a sysroot, symbolic links are followed inside the sysroot.
+func (r *Resolver) Exists(path string) bool {
+	_, err := r.stat(path)
+	return err == nil
+}
+
+// find returns the first existing rel file in home and dirs.
+func (r *Resolver) find(rel, home, dirs string) (string, error) {
+	if paths := r.findAll(rel, home, dirs); len(paths) > 0 {
+		return paths[0], nil
+	}
+	return "", fmt.Errorf("xdgbasedir: %s: %w", rel, fs.ErrNotExist)
+}
+
+// findAll returns the existing rel files in home and dirs in precedence order.
+func (r *Resolver) findAll(rel, home, dirs string) []string {
+	var paths []string
+	for _, dir := range append([]string{home}, splitList(dirs)...) {
+		if dir == "" {
+			continue
+		}
+		path := filepath.Join(dir, rel)
+		if r.Exists(path) {
+			paths = append(paths, path)
+		}
+	}
+	return paths
+}
+
+// Stat returns the fs.FileInfo of path in r's FileSystem. If r has a sysroot, symbolic links are followed inside
+// the sysroot.
+func (r *Resolver) Stat(path string) (fs.FileInfo, error) {
+	return r.stat(path)
+}
+
+// ReadFile reads the path file in r's FileSystem. If r has a sysroot, symbolic links are followed inside the sysroot.
+func (r *Resolver) ReadFile(path string) ([]byte, error) {
+	path, err := r.inRoot(path)
+	if err != nil {
+		return nil, err
+	}
+	return r.FS().ReadFile(path)
+}
+
+// ReadDir reads the path directory in r's FileSystem. If r has a sysroot, symbolic links are followed inside the
+// sysroot.
+func (r *Resolver) ReadDir(path string) ([]fs.DirEntry, error) {
+	path, err := r.inRoot(path)
+	if err != nil {
+		return nil, err
+	}
+	return r.FS().ReadDir(path)
+}
+
+// EvalSymlinks returns path with its symbolic links in r's FileSystem resolved, like filepath.EvalSymlinks.
+// If r has a sysroot, symbolic links are followed inside the sysroot. Nonexistent trailing elements are kept as is.
+func (r *Resolver) EvalSymlinks(path string) (string, error) {
+	root := r.sysroot.root
+	if root == "" {
+		abs, err := filepath.Abs(path)
+		if err != nil {
+			return "", err
+		}
+		path = abs
+		root = filepath.VolumeName(path) + string(filepath.Separator)
+	}
+	return inRoot(r.FS(), root, path)
+}
+
+// stat returns the fs.FileInfo of path in r's FileSystem. If r has a sysroot, symbolic links are followed inside the sysroot.
+func (r *Resolver) stat(path string) (fs.FileInfo, error) {
+	path, err := r.inRoot(path)
+	if err != nil {
+		return nil, err
+	}
+	return r.FS().Stat(path)
+}
+
+// inRoot resolves path inside the sysroot of r, if any.
+func (r *Resolver) inRoot(path string) (string, error) {
+	if root := r.sysroot.root; root != "" {
+		return inRoot(r.FS(), root, path)
+	}
+	return path, nil
+}
@@ -0,0 +1,74 @@
+// Copyright 2026 The go-xdgbasedir Authors. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package xdgbasedir
+
+// Hermetic is the explicit inputs of a hermetic Resolver.
+type Hermetic struct {
+	// Home is the user home directory the default base directories are based on.
+	Home string
+	// UID is the user id used for the default XDG_RUNTIME_DIR.
+	UID int
+	// Profile is the platform layout of the default base directories.
+	Profile Profile
+	// Env is the list of environment variables such as XDG_CONFIG_HOME overrides, in the form "key=value".
+	// It may be nil.
+	Env []string
+}
+
+// WithHermetic makes the Resolver resolve only from the explicit inputs h, and never read the process environment.
+//
+// This is for build tools and reproducible test harnesses which must not pick up the XDG_* overrides of a developer.
+// A hermetic Resolver panics with a *ProcessEnvError if any code path falls back to the process environment,
+// unless WithEnvGuard installs another guard.
+func WithHermetic(h Hermetic) Option {
+	return func(r *Resolver) {
+		WithEnv(h.Env)(r)
+		r.layout = &layout{
+			profile: h.Profile,
+			home:    h.Home,
+			uid:     h.UID,
+		}
+		if r.guard == nil {
+			r.guard = PanicOnProcessEnv
+		}
+	}
+}
+
+// WithEnvGuard installs guard, which is called with the environment variable name whenever the Resolver
+// falls back to the process environment. The name is "*" if the whole environment is read.
+//
+// In tests, the guard can report an error instead of panicking:
+//
+//	r := xdgbasedir.New(
+//		xdgbasedir.WithHermetic(h),
+//		xdgbasedir.WithEnvGuard(func(key string) { t.Errorf("read process env %s", key) }),
+//	)
+func WithEnvGuard(guard func(key string)) Option {
+	return func(r *Resolver) {
+		r.guard = guard
+	}
+}
+
+// ProcessEnvError is the panic value of PanicOnProcessEnv.
+type ProcessEnvError struct {
+	Key string
+}
+
+// Error implements error.
+func (e *ProcessEnvError) Error() string {
+	return "xdgbasedir: fell back to the process environment: " + e.Key
+}
+
+// PanicOnProcessEnv is an env guard which panics with a *ProcessEnvError.
+func PanicOnProcessEnv(key string) {
+	panic(&ProcessEnvError{Key: key})
+}
+
+// fallback calls the guard of r, if any, before falling back to the key process environment variable.
+func (r *Resolver) fallback(key string) {
+	if r.guard != nil {
+		r.guard(key)
+	}
+}
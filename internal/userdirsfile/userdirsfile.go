@@ -0,0 +1,92 @@
+// Copyright 2026 The go-xdgbasedir Authors. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+// Package userdirsfile parses the xdg-user-dirs user-dirs.dirs file, shared by the xdgbasedir
+// standard locations and the userdirs package.
+package userdirsfile // import "github.com/zchee/go-xdgbasedir/internal/userdirsfile"
+
+import (
+	"bufio"
+	"bytes"
+	"path/filepath"
+	"strings"
+)
+
+// Entry is an entry of user-dirs.dirs, such as XDG_DESKTOP_DIR="$HOME/Desktop".
+type Entry struct {
+	Name string // such as "DESKTOP"
+	Dir  string // absolute path
+}
+
+// Parse parses the user-dirs.dirs data the way xdg-user-dirs does, in order. Malformed lines are ignored.
+func Parse(data []byte, home string) []Entry {
+	var entries []Entry
+	sc := bufio.NewScanner(bytes.NewReader(data))
+	for sc.Scan() {
+		s := strings.TrimLeft(sc.Text(), " \t")
+		if !strings.HasPrefix(s, "XDG_") {
+			continue
+		}
+		i := strings.Index(s, "_DIR")
+		if i < 0 {
+			continue
+		}
+		name := s[len("XDG_"):i]
+		s = strings.TrimLeft(s[i+len("_DIR"):], " \t")
+		if !strings.HasPrefix(s, "=") {
+			continue
+		}
+		s = strings.TrimLeft(s[1:], " \t")
+		if !strings.HasPrefix(s, `"`) {
+			continue
+		}
+		value, ok := unquote(s[1:])
+		if !ok {
+			continue
+		}
+		if dir, ok := Expand(value, home); ok {
+			entries = append(entries, Entry{Name: name, Dir: dir})
+		}
+	}
+	return entries
+}
+
+// Lookup returns the directory of name in entries. The last entry wins, same as sourcing the file in a shell.
+func Lookup(entries []Entry, name string) (string, bool) {
+	for i := len(entries) - 1; i >= 0; i-- {
+		if entries[i].Name == name {
+			return entries[i].Dir, true
+		}
+	}
+	return "", false
+}
+
+// unquote returns the contents of a double quoted string without the opening quote, unescaping backslashes.
+func unquote(s string) (string, bool) {
+	var b strings.Builder
+	for i := 0; i < len(s); i++ {
+		switch c := s[i]; c {
+		case '"':
+			return b.String(), true
+		case '\\':
+			if i++; i < len(s) {
+				b.WriteByte(s[i])
+			}
+		default:
+			b.WriteByte(c)
+		}
+	}
+	return "", false
+}
+
+// Expand expands the "$HOME" or "$HOME/..." value relative to home. Other values must be absolute.
+func Expand(value, home string) (string, bool) {
+	if value == "$HOME" || strings.HasPrefix(value, "$HOME/") {
+		return filepath.Join(home, filepath.FromSlash(value[len("$HOME"):])), true
+	}
+	if strings.HasPrefix(value, "/") {
+		return filepath.FromSlash(value), true
+	}
+	return "", false
+}
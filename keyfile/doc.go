@@ -0,0 +1,21 @@
+// Copyright 2026 The go-xdgbasedir Authors. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+// Package keyfile implements a parser and writer of the GLib key-file format.
+//
+//	https://docs.gtk.org/glib/struct.KeyFile.html
+//	https://specifications.freedesktop.org/desktop-entry-spec/latest/basic-format.html
+//
+// Most freedesktop files, such as desktop entries, mimeapps.list, user-dirs.defaults, trashinfo and autostart
+// entries, use this format:
+//
+//	# comment
+//	[Desktop Entry]
+//	Name=Text Editor
+//	Name[de_DE@euro]=Texteditor
+//	MimeType=text/plain;text/markdown;
+//
+// A File keeps the comments, blank lines and the order of groups and keys, and unmodified lines are written
+// back as is, so that files edited by users can be updated without clobbering their comments.
+package keyfile // import "github.com/zchee/go-xdgbasedir/keyfile"
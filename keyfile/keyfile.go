@@ -0,0 +1,394 @@
+// Copyright 2026 The go-xdgbasedir Authors. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package keyfile
+
+import (
+	"bufio"
+	"bytes"
+	"errors"
+	"fmt"
+	"io"
+	"strings"
+)
+
+var (
+	// ErrGroupNotFound is returned if the group is not found.
+	ErrGroupNotFound = errors.New("keyfile: group not found")
+	// ErrKeyNotFound is returned if the key is not found in the group.
+	ErrKeyNotFound = errors.New("keyfile: key not found")
+)
+
+// ParseError is returned by Parse for a malformed line.
+type ParseError struct {
+	Line int
+	Msg  string
+}
+
+// Error implements error.
+func (e *ParseError) Error() string {
+	return fmt.Sprintf("keyfile: line %d: %s", e.Line, e.Msg)
+}
+
+// line is a line of a key file.
+type line struct {
+	raw   string // original text, empty if the entry was modified or added
+	key   string // empty for comments and blank lines
+	value string // escaped value
+}
+
+// text returns the text of l to be written.
+func (l *line) text() string {
+	if l.raw != "" || l.key == "" {
+		return l.raw
+	}
+	return l.key + "=" + l.value
+}
+
+// section is a group of a key file.
+type section struct {
+	name  string
+	raw   string // original header text
+	lines []*line
+}
+
+// File is a key file.
+type File struct {
+	head   []*line // comments and blank lines before the first group
+	groups []*section
+}
+
+// New returns a new empty File.
+func New() *File {
+	return new(File)
+}
+
+// Parse parses a key file from r.
+func Parse(r io.Reader) (*File, error) {
+	f := New()
+	var cur *section
+
+	sc := bufio.NewScanner(r)
+	sc.Buffer(make([]byte, 0, 64*1024), 16*1024*1024)
+	for n := 1; sc.Scan(); n++ {
+		text := strings.TrimSuffix(sc.Text(), "\r")
+		trimmed := strings.TrimSpace(text)
+
+		switch {
+		case trimmed == "" || trimmed[0] == '#':
+			l := &line{raw: text}
+			if cur == nil {
+				f.head = append(f.head, l)
+			} else {
+				cur.lines = append(cur.lines, l)
+			}
+
+		case trimmed[0] == '[':
+			if !strings.HasSuffix(trimmed, "]") {
+				return nil, &ParseError{Line: n, Msg: fmt.Sprintf("invalid group header %q", text)}
+			}
+			name := trimmed[1 : len(trimmed)-1]
+			if !validGroupName(name) {
+				return nil, &ParseError{Line: n, Msg: fmt.Sprintf("invalid group name %q", name)}
+			}
+			cur = &section{name: name, raw: text}
+			f.groups = append(f.groups, cur)
+
+		default:
+			i := strings.IndexByte(text, '=')
+			if i < 0 {
+				return nil, &ParseError{Line: n, Msg: fmt.Sprintf("%q is not a group, key or comment", text)}
+			}
+			if cur == nil {
+				return nil, &ParseError{Line: n, Msg: "key file does not start with a group"}
+			}
+			key := strings.TrimSpace(text[:i])
+			if !validKey(key) {
+				return nil, &ParseError{Line: n, Msg: fmt.Sprintf("invalid key name %q", key)}
+			}
+			value := strings.TrimLeft(text[i+1:], " \t")
+			cur.lines = append(cur.lines, &line{raw: text, key: key, value: value})
+		}
+	}
+	if err := sc.Err(); err != nil {
+		return nil, err
+	}
+
+	return f, nil
+}
+
+// ParseBytes parses a key file from data.
+func ParseBytes(data []byte) (*File, error) {
+	return Parse(bytes.NewReader(data))
+}
+
+// WriteTo writes f to w. It implements io.WriterTo.
+func (f *File) WriteTo(w io.Writer) (int64, error) {
+	bw := bufio.NewWriter(w)
+	var n int64
+	write := func(s string) {
+		m, _ := bw.WriteString(s)
+		n += int64(m)
+		m, _ = bw.WriteString("\n")
+		n += int64(m)
+	}
+
+	for _, l := range f.head {
+		write(l.text())
+	}
+	for _, g := range f.groups {
+		if g.raw != "" {
+			write(g.raw)
+		} else {
+			write("[" + g.name + "]")
+		}
+		for _, l := range g.lines {
+			write(l.text())
+		}
+	}
+
+	return n, bw.Flush()
+}
+
+// Bytes returns the contents of f.
+func (f *File) Bytes() []byte {
+	var buf bytes.Buffer
+	f.WriteTo(&buf)
+	return buf.Bytes()
+}
+
+// Groups returns the names of the groups in order.
+func (f *File) Groups() []string {
+	var names []string
+	seen := make(map[string]bool)
+	for _, g := range f.groups {
+		if !seen[g.name] {
+			seen[g.name] = true
+			names = append(names, g.name)
+		}
+	}
+	return names
+}
+
+// HasGroup reports whether f has the group.
+func (f *File) HasGroup(group string) bool {
+	return len(f.lookupGroup(group)) > 0
+}
+
+// Keys returns the keys of the group in order, including the localized keys such as "Name[de]".
+func (f *File) Keys(group string) ([]string, error) {
+	groups := f.lookupGroup(group)
+	if len(groups) == 0 {
+		return nil, ErrGroupNotFound
+	}
+	var keys []string
+	seen := make(map[string]bool)
+	for _, g := range groups {
+		for _, l := range g.lines {
+			if l.key != "" && !seen[l.key] {
+				seen[l.key] = true
+				keys = append(keys, l.key)
+			}
+		}
+	}
+	return keys, nil
+}
+
+// HasKey reports whether the group of f has the key.
+func (f *File) HasKey(group, key string) bool {
+	_, err := f.Value(group, key)
+	return err == nil
+}
+
+// Value returns the raw, still escaped, value of the key in the group.
+// If the key appears more than once, the last one wins, same as GLib.
+func (f *File) Value(group, key string) (string, error) {
+	l, err := f.lookup(group, key)
+	if err != nil {
+		return "", err
+	}
+	return l.value, nil
+}
+
+// SetValue sets the raw value of the key in the group, adding the group and the key if needed.
+// value must be escaped already. It panics if group, key or value would not parse back, such as a key with "=".
+func (f *File) SetValue(group, key, value string) {
+	if !validGroupName(group) {
+		panic(fmt.Sprintf("keyfile: invalid group name %q", group))
+	}
+	if !validKey(key) {
+		panic(fmt.Sprintf("keyfile: invalid key %q", key))
+	}
+	if strings.ContainsAny(value, "\n\r") {
+		panic(fmt.Sprintf("keyfile: value of key %q contains a line break", key))
+	}
+	if l, err := f.lookup(group, key); err == nil {
+		if l.value != value {
+			l.raw = ""
+			l.value = value
+		}
+		return
+	}
+
+	groups := f.lookupGroup(group)
+	var g *section
+	if len(groups) == 0 {
+		g = f.addGroup(group)
+	} else {
+		g = groups[len(groups)-1]
+	}
+
+	// insert after the last entry, before the trailing comments and blank lines
+	i := len(g.lines)
+	for i > 0 && g.lines[i-1].key == "" {
+		i--
+	}
+	if i == 0 {
+		i = len(g.lines)
+		for i > 0 && strings.TrimSpace(g.lines[i-1].text()) == "" {
+			i--
+		}
+	}
+	g.lines = append(g.lines, nil)
+	copy(g.lines[i+1:], g.lines[i:])
+	g.lines[i] = &line{key: key, value: value}
+}
+
+// AddGroup adds the group at the end of f if it does not exist. It panics if name is not a valid group name,
+// such as a name with "]".
+func (f *File) AddGroup(name string) {
+	if !validGroupName(name) {
+		panic(fmt.Sprintf("keyfile: invalid group name %q", name))
+	}
+	f.addGroup(name)
+}
+
+// addGroup adds the group at the end of f, separated by a blank line, if it does not exist.
+func (f *File) addGroup(name string) *section {
+	if groups := f.lookupGroup(name); len(groups) > 0 {
+		return groups[0]
+	}
+	if n := len(f.groups); n > 0 {
+		last := f.groups[n-1]
+		if m := len(last.lines); m == 0 || strings.TrimSpace(last.lines[m-1].text()) != "" {
+			last.lines = append(last.lines, &line{})
+		}
+	}
+	g := &section{name: name}
+	f.groups = append(f.groups, g)
+	return g
+}
+
+// DeleteKey deletes the key, and its localized keys such as "Name[de]", from the group.
+func (f *File) DeleteKey(group, key string) error {
+	groups := f.lookupGroup(group)
+	if len(groups) == 0 {
+		return ErrGroupNotFound
+	}
+	found := false
+	for _, g := range groups {
+		lines := g.lines[:0]
+		for _, l := range g.lines {
+			if l.key != "" {
+				if base, _ := SplitKey(l.key); l.key == key || base == key {
+					found = true
+					continue
+				}
+			}
+			lines = append(lines, l)
+		}
+		g.lines = lines
+	}
+	if !found {
+		return ErrKeyNotFound
+	}
+	return nil
+}
+
+// DeleteGroup deletes the group.
+func (f *File) DeleteGroup(group string) error {
+	groups := f.groups[:0]
+	found := false
+	for _, g := range f.groups {
+		if g.name == group {
+			found = true
+			continue
+		}
+		groups = append(groups, g)
+	}
+	f.groups = groups
+	if !found {
+		return ErrGroupNotFound
+	}
+	return nil
+}
+
+// lookupGroup returns the groups named name. GLib merges duplicated groups.
+func (f *File) lookupGroup(name string) []*section {
+	var groups []*section
+	for _, g := range f.groups {
+		if g.name == name {
+			groups = append(groups, g)
+		}
+	}
+	return groups
+}
+
+// lookup returns the last line of the key in the group.
+func (f *File) lookup(group, key string) (*line, error) {
+	groups := f.lookupGroup(group)
+	if len(groups) == 0 {
+		return nil, ErrGroupNotFound
+	}
+	for i := len(groups) - 1; i >= 0; i-- {
+		lines := groups[i].lines
+		for j := len(lines) - 1; j >= 0; j-- {
+			if lines[j].key == key {
+				return lines[j], nil
+			}
+		}
+	}
+	return nil, ErrKeyNotFound
+}
+
+// SplitKey splits a localized key such as "Name[de_DE@euro]" into the key "Name" and the locale "de_DE@euro".
+// The locale is empty if key is not localized.
+func SplitKey(key string) (base, locale string) {
+	i := strings.IndexByte(key, '[')
+	if i < 0 || !strings.HasSuffix(key, "]") {
+		return key, ""
+	}
+	return key[:i], key[i+1 : len(key)-1]
+}
+
+// LocaleKey returns the localized key of key for locale, such as "Name[de]".
+func LocaleKey(key, locale string) string {
+	if locale == "" {
+		return key
+	}
+	return key + "[" + locale + "]"
+}
+
+// validGroupName reports whether name is a valid group name, which may contain all ASCII characters
+// except for '[', ']' and control characters.
+func validGroupName(name string) bool {
+	if name == "" {
+		return false
+	}
+	for i := 0; i < len(name); i++ {
+		if c := name[i]; c == '[' || c == ']' || c < 0x20 || c == 0x7f {
+			return false
+		}
+	}
+	return true
+}
+
+// validKey reports whether key is a valid key, optionally followed by a bracketed locale.
+func validKey(key string) bool {
+	base, locale := SplitKey(key)
+	if base == "" || strings.ContainsAny(base, "[]=") || strings.ContainsAny(locale, "[]=") {
+		return false
+	}
+	return !strings.ContainsAny(key, "\n\r")
+}
@@ -0,0 +1,260 @@
+// Copyright 2026 The go-xdgbasedir Authors. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package keyfile_test
+
+import (
+	"errors"
+	"reflect"
+	"strings"
+	"testing"
+
+	"github.com/zchee/go-xdgbasedir/keyfile"
+)
+
+const desktop = `# generated by hand
+# keep this comment
+
+[Desktop Entry]
+Type=Application
+# the name
+Name=Text Editor
+Name[de]=Texteditor
+Name[de_DE@euro]=Texteditor (Euro)
+Comment=Edit\stext\nfiles
+Categories=Utility;TextEditor;
+Keywords=a\;b;c;
+Terminal=false
+X-Priority = 10
+X-Scale=1.5
+
+[Desktop Action new-window]
+Name=New Window
+Exec=editor --new-window
+`
+
+func parse(t *testing.T, s string) *keyfile.File {
+	t.Helper()
+	f, err := keyfile.ParseBytes([]byte(s))
+	if err != nil {
+		t.Fatal(err)
+	}
+	return f
+}
+
+func TestRoundTrip(t *testing.T) {
+	t.Parallel()
+
+	f := parse(t, desktop)
+	if got := string(f.Bytes()); got != desktop {
+		t.Fatalf("round trip:\n%s\nwant:\n%s", got, desktop)
+	}
+
+	f.SetString("Desktop Entry", "Name", "Editor")
+	f.SetBool("Desktop Entry", "StartupNotify", true)
+	f.SetStringList("Desktop Action new-window", "OnlyShowIn", []string{"GNOME", "KDE"})
+	f.SetInt("Settings", "Width", 640)
+	if err := f.DeleteKey("Desktop Entry", "Comment"); err != nil {
+		t.Fatal(err)
+	}
+
+	want := strings.NewReplacer(
+		"Name=Text Editor\n", "Name=Editor\n",
+		"Comment=Edit\\stext\\nfiles\n", "",
+		"X-Scale=1.5\n", "X-Scale=1.5\nStartupNotify=true\n",
+		"Exec=editor --new-window\n", "Exec=editor --new-window\nOnlyShowIn=GNOME;KDE;\n\n[Settings]\nWidth=640\n",
+	).Replace(desktop)
+	if got := string(f.Bytes()); got != want {
+		t.Fatalf("modified:\n%s\nwant:\n%s", got, want)
+	}
+}
+
+func TestParseError(t *testing.T) {
+	t.Parallel()
+
+	tests := []struct {
+		name string
+		in   string
+		line int
+	}{
+		{name: "key before group", in: "# comment\nKey=value\n", line: 2},
+		{name: "unterminated group", in: "[Group]\n[Other\n", line: 2},
+		{name: "invalid group", in: "[Gr]oup]\n", line: 1},
+		{name: "not a key", in: "[Group]\nKey=value\ngarbage\n", line: 3},
+		{name: "empty key", in: "[Group]\n=value\n", line: 2},
+	}
+	for _, tt := range tests {
+		tt := tt
+		t.Run(tt.name, func(t *testing.T) {
+			t.Parallel()
+
+			_, err := keyfile.ParseBytes([]byte(tt.in))
+			var perr *keyfile.ParseError
+			if !errors.As(err, &perr) {
+				t.Fatalf("ParseBytes(%q) = %v, want a *ParseError", tt.in, err)
+			}
+			if perr.Line != tt.line {
+				t.Fatalf("ParseBytes(%q) failed at line %d, want %d", tt.in, perr.Line, tt.line)
+			}
+		})
+	}
+}
+
+func TestGetters(t *testing.T) {
+	t.Parallel()
+
+	f := parse(t, desktop)
+	const g = "Desktop Entry"
+
+	if got, err := f.String(g, "Comment"); err != nil || got != "Edit text\nfiles" {
+		t.Fatalf("String(Comment) = %q, %v", got, err)
+	}
+	if got, err := f.StringList(g, "Categories"); err != nil || !reflect.DeepEqual(got, []string{"Utility", "TextEditor"}) {
+		t.Fatalf("StringList(Categories) = %q, %v", got, err)
+	}
+	if got, err := f.StringList(g, "Keywords"); err != nil || !reflect.DeepEqual(got, []string{"a;b", "c"}) {
+		t.Fatalf("StringList(Keywords) = %q, %v", got, err)
+	}
+	if got, err := f.Bool(g, "Terminal"); err != nil || got {
+		t.Fatalf("Bool(Terminal) = %v, %v", got, err)
+	}
+	if got, err := f.Int(g, "X-Priority"); err != nil || got != 10 {
+		t.Fatalf("Int(X-Priority) = %v, %v", got, err)
+	}
+	if got, err := f.Float(g, "X-Scale"); err != nil || got != 1.5 {
+		t.Fatalf("Float(X-Scale) = %v, %v", got, err)
+	}
+	if _, err := f.Bool(g, "Name"); err == nil {
+		t.Fatal("Bool(Name) succeeded")
+	}
+	if _, err := f.String(g, "Missing"); err != keyfile.ErrKeyNotFound {
+		t.Fatalf("String(Missing) = %v, want ErrKeyNotFound", err)
+	}
+	if _, err := f.String("Missing", "Name"); err != keyfile.ErrGroupNotFound {
+		t.Fatalf("String(Missing group) = %v, want ErrGroupNotFound", err)
+	}
+
+	if got, want := f.Groups(), []string{"Desktop Entry", "Desktop Action new-window"}; !reflect.DeepEqual(got, want) {
+		t.Fatalf("Groups() = %q, want %q", got, want)
+	}
+	if got, err := f.Locales(g, "Name"); err != nil || !reflect.DeepEqual(got, []string{"de", "de_DE@euro"}) {
+		t.Fatalf("Locales(Name) = %q, %v", got, err)
+	}
+}
+
+func TestLocaleString(t *testing.T) {
+	t.Parallel()
+
+	f := parse(t, desktop)
+	tests := []struct {
+		locales []string
+		want    string
+	}{
+		{locales: []string{"de_DE@euro", "de_DE", "de@euro", "de"}, want: "Texteditor (Euro)"},
+		{locales: []string{"de_AT", "de"}, want: "Texteditor"},
+		{locales: []string{"fr_FR", "fr"}, want: "Text Editor"},
+		{want: "Text Editor"},
+	}
+	for _, tt := range tests {
+		got, err := f.LocaleString("Desktop Entry", "Name", tt.locales...)
+		if err != nil || got != tt.want {
+			t.Errorf("LocaleString(Name, %q) = %q, %v, want %q", tt.locales, got, err, tt.want)
+		}
+	}
+
+	f.SetLocaleString("Desktop Entry", "Name", "fr", "Éditeur")
+	if got, _ := f.LocaleString("Desktop Entry", "Name", "fr"); got != "Éditeur" {
+		t.Fatalf("LocaleString(Name, fr) = %q after SetLocaleString", got)
+	}
+	if err := f.DeleteKey("Desktop Entry", "Name"); err != nil {
+		t.Fatal(err)
+	}
+	if locales, _ := f.Locales("Desktop Entry", "Name"); len(locales) != 0 {
+		t.Fatalf("DeleteKey(Name) kept the localized keys %q", locales)
+	}
+}
+
+func TestEscape(t *testing.T) {
+	t.Parallel()
+
+	tests := []struct {
+		in, escaped string
+	}{
+		{in: "plain", escaped: "plain"},
+		{in: "  indented", escaped: `\s\sindented`},
+		{in: "a b", escaped: "a b"},
+		{in: "tab\there\r\nnext", escaped: `tab\there\r\nnext`},
+		{in: `C:\path`, escaped: `C:\\path`},
+	}
+	for _, tt := range tests {
+		if got := keyfile.Escape(tt.in); got != tt.escaped {
+			t.Errorf("Escape(%q) = %q, want %q", tt.in, got, tt.escaped)
+		}
+		if got, err := keyfile.Unescape(tt.escaped); err != nil || got != tt.in {
+			t.Errorf("Unescape(%q) = %q, %v, want %q", tt.escaped, got, err, tt.in)
+		}
+	}
+
+	for _, s := range []string{`trailing\`, `invalid\x`} {
+		if _, err := keyfile.Unescape(s); err == nil {
+			t.Errorf("Unescape(%q) succeeded", s)
+		}
+	}
+}
+
+func TestDeleteGroup(t *testing.T) {
+	t.Parallel()
+
+	f := parse(t, desktop)
+	if err := f.DeleteGroup("Desktop Action new-window"); err != nil {
+		t.Fatal(err)
+	}
+	if f.HasGroup("Desktop Action new-window") {
+		t.Fatal("HasGroup returns true after DeleteGroup")
+	}
+	if err := f.DeleteGroup("Desktop Action new-window"); err != keyfile.ErrGroupNotFound {
+		t.Fatalf("DeleteGroup twice = %v, want ErrGroupNotFound", err)
+	}
+	if !strings.HasPrefix(string(f.Bytes()), "# generated by hand\n") {
+		t.Fatal("DeleteGroup dropped the leading comments")
+	}
+}
+
+func TestSetInvalid(t *testing.T) {
+	t.Parallel()
+
+	tests := []struct {
+		name string
+		fn   func(f *keyfile.File)
+	}{
+		{name: "key with =", fn: func(f *keyfile.File) { f.SetValue("Group", "a=b", "v") }},
+		{name: "key with line break", fn: func(f *keyfile.File) { f.SetValue("Group", "a\nb", "v") }},
+		{name: "empty key", fn: func(f *keyfile.File) { f.SetValue("Group", "", "v") }},
+		{name: "value with line break", fn: func(f *keyfile.File) { f.SetValue("Group", "Key", "a\nb") }},
+		{name: "group with ]", fn: func(f *keyfile.File) { f.SetValue("a]b", "Key", "v") }},
+		{name: "AddGroup with ]", fn: func(f *keyfile.File) { f.AddGroup("a]b") }},
+		{name: "AddGroup empty", fn: func(f *keyfile.File) { f.AddGroup("") }},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			f := parse(t, desktop)
+			want := string(f.Bytes())
+			defer func() {
+				if recover() == nil {
+					t.Error("did not panic")
+				}
+				if got := string(f.Bytes()); got != want {
+					t.Errorf("file is modified:\n%s", got)
+				}
+			}()
+			tt.fn(f)
+		})
+	}
+
+	f := parse(t, desktop)
+	f.SetValue("Desktop Entry", "Name[de]", "Editor")
+	if _, err := keyfile.ParseBytes(f.Bytes()); err != nil {
+		t.Errorf("ParseBytes() after SetValue() of a localized key = %v", err)
+	}
+}
@@ -0,0 +1,286 @@
+// Copyright 2026 The go-xdgbasedir Authors. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package keyfile
+
+import (
+	"fmt"
+	"strconv"
+	"strings"
+)
+
+// ListSeparator is the separator of list values.
+const ListSeparator = ';'
+
+// Escape escapes s as a key file value. Leading spaces, newlines, tabs, carriage returns and
+// backslashes are escaped.
+func Escape(s string) string {
+	return escape(s, false)
+}
+
+// escape escapes s. If list is true, ListSeparator is also escaped.
+func escape(s string, list bool) string {
+	var b strings.Builder
+	leading := true
+	for i := 0; i < len(s); i++ {
+		c := s[i]
+		switch {
+		case c == ' ' && leading:
+			b.WriteString(`\s`)
+			continue
+		case c == '\n':
+			b.WriteString(`\n`)
+		case c == '\t':
+			b.WriteString(`\t`)
+		case c == '\r':
+			b.WriteString(`\r`)
+		case c == '\\':
+			b.WriteString(`\\`)
+		case c == ListSeparator && list:
+			b.WriteString(`\;`)
+		default:
+			b.WriteByte(c)
+		}
+		leading = false
+	}
+	return b.String()
+}
+
+// Unescape unescapes the key file value s.
+func Unescape(s string) (string, error) {
+	return unescape(s, false)
+}
+
+// unescape unescapes s. If list is true, the escaped ListSeparator is also unescaped.
+func unescape(s string, list bool) (string, error) {
+	if strings.IndexByte(s, '\\') < 0 {
+		return s, nil
+	}
+	var b strings.Builder
+	for i := 0; i < len(s); i++ {
+		c := s[i]
+		if c != '\\' {
+			b.WriteByte(c)
+			continue
+		}
+		i++
+		if i == len(s) {
+			return "", fmt.Errorf("keyfile: %q ends with an escape character", s)
+		}
+		switch s[i] {
+		case 's':
+			b.WriteByte(' ')
+		case 'n':
+			b.WriteByte('\n')
+		case 't':
+			b.WriteByte('\t')
+		case 'r':
+			b.WriteByte('\r')
+		case '\\':
+			b.WriteByte('\\')
+		case ListSeparator:
+			if !list {
+				// GLib keeps the escaped separator of non-list values as is
+				b.WriteString(`\;`)
+				break
+			}
+			b.WriteByte(ListSeparator)
+		default:
+			return "", fmt.Errorf("keyfile: %q contains an invalid escape sequence \\%c", s, s[i])
+		}
+	}
+	return b.String(), nil
+}
+
+// splitList splits the escaped list value s by the unescaped ListSeparator. A trailing separator is optional.
+func splitList(s string) []string {
+	var list []string
+	start := 0
+	for i := 0; i < len(s); i++ {
+		switch s[i] {
+		case '\\':
+			i++
+		case ListSeparator:
+			list = append(list, s[start:i])
+			start = i + 1
+		}
+	}
+	if start < len(s) {
+		list = append(list, s[start:])
+	}
+	return list
+}
+
+// String returns the unescaped value of the key in the group.
+func (f *File) String(group, key string) (string, error) {
+	v, err := f.Value(group, key)
+	if err != nil {
+		return "", err
+	}
+	return Unescape(v)
+}
+
+// SetString sets the key in the group to the escaped value.
+func (f *File) SetString(group, key, value string) {
+	f.SetValue(group, key, Escape(value))
+}
+
+// LocaleString returns the unescaped value of the localized key, trying the locales in order and then
+// the untranslated key. locales is a preference list such as the one returned by the locale package,
+// for example []string{"de_DE@euro", "de_DE", "de@euro", "de"}.
+func (f *File) LocaleString(group, key string, locales ...string) (string, error) {
+	for _, locale := range locales {
+		if v, err := f.String(group, LocaleKey(key, locale)); err != ErrKeyNotFound {
+			return v, err
+		}
+	}
+	return f.String(group, key)
+}
+
+// SetLocaleString sets the localized key for locale in the group to the escaped value.
+func (f *File) SetLocaleString(group, key, locale, value string) {
+	f.SetString(group, LocaleKey(key, locale), value)
+}
+
+// Locales returns the locales of the localized key in the group, in order.
+func (f *File) Locales(group, key string) ([]string, error) {
+	keys, err := f.Keys(group)
+	if err != nil {
+		return nil, err
+	}
+	var locales []string
+	for _, k := range keys {
+		if base, locale := SplitKey(k); base == key && locale != "" {
+			locales = append(locales, locale)
+		}
+	}
+	return locales, nil
+}
+
+// StringList returns the unescaped list value of the key in the group.
+func (f *File) StringList(group, key string) ([]string, error) {
+	v, err := f.Value(group, key)
+	if err != nil {
+		return nil, err
+	}
+	var list []string
+	for _, e := range splitList(v) {
+		u, err := unescape(e, true)
+		if err != nil {
+			return nil, err
+		}
+		list = append(list, u)
+	}
+	return list, nil
+}
+
+// LocaleStringList returns the unescaped list value of the localized key, like LocaleString.
+func (f *File) LocaleStringList(group, key string, locales ...string) ([]string, error) {
+	for _, locale := range locales {
+		if v, err := f.StringList(group, LocaleKey(key, locale)); err != ErrKeyNotFound {
+			return v, err
+		}
+	}
+	return f.StringList(group, key)
+}
+
+// SetStringList sets the key in the group to the list value, with a trailing separator.
+func (f *File) SetStringList(group, key string, list []string) {
+	var b strings.Builder
+	for _, e := range list {
+		b.WriteString(escape(e, true))
+		b.WriteByte(ListSeparator)
+	}
+	f.SetValue(group, key, b.String())
+}
+
+// Bool returns the boolean value of the key in the group. "true" and "1" are true, "false" and "0" are false.
+func (f *File) Bool(group, key string) (bool, error) {
+	v, err := f.Value(group, key)
+	if err != nil {
+		return false, err
+	}
+	return parseBool(v)
+}
+
+func parseBool(v string) (bool, error) {
+	switch strings.TrimRight(v, " \t") {
+	case "true", "1":
+		return true, nil
+	case "false", "0":
+		return false, nil
+	}
+	return false, fmt.Errorf("keyfile: %q is not a boolean", v)
+}
+
+// SetBool sets the key in the group to the boolean value.
+func (f *File) SetBool(group, key string, value bool) {
+	f.SetValue(group, key, strconv.FormatBool(value))
+}
+
+// BoolList returns the boolean list value of the key in the group.
+func (f *File) BoolList(group, key string) ([]bool, error) {
+	list, err := f.StringList(group, key)
+	if err != nil {
+		return nil, err
+	}
+	bools := make([]bool, len(list))
+	for i, v := range list {
+		if bools[i], err = parseBool(v); err != nil {
+			return nil, err
+		}
+	}
+	return bools, nil
+}
+
+// Int returns the integer value of the key in the group.
+func (f *File) Int(group, key string) (int64, error) {
+	v, err := f.Value(group, key)
+	if err != nil {
+		return 0, err
+	}
+	n, err := strconv.ParseInt(strings.TrimSpace(v), 10, 64)
+	if err != nil {
+		return 0, fmt.Errorf("keyfile: %q is not an integer", v)
+	}
+	return n, nil
+}
+
+// SetInt sets the key in the group to the integer value.
+func (f *File) SetInt(group, key string, value int64) {
+	f.SetValue(group, key, strconv.FormatInt(value, 10))
+}
+
+// IntList returns the integer list value of the key in the group.
+func (f *File) IntList(group, key string) ([]int64, error) {
+	list, err := f.StringList(group, key)
+	if err != nil {
+		return nil, err
+	}
+	ints := make([]int64, len(list))
+	for i, v := range list {
+		if ints[i], err = strconv.ParseInt(strings.TrimSpace(v), 10, 64); err != nil {
+			return nil, fmt.Errorf("keyfile: %q is not an integer", v)
+		}
+	}
+	return ints, nil
+}
+
+// Float returns the floating point value of the key in the group.
+func (f *File) Float(group, key string) (float64, error) {
+	v, err := f.Value(group, key)
+	if err != nil {
+		return 0, err
+	}
+	n, err := strconv.ParseFloat(strings.TrimSpace(v), 64)
+	if err != nil {
+		return 0, fmt.Errorf("keyfile: %q is not a number", v)
+	}
+	return n, nil
+}
+
+// SetFloat sets the key in the group to the floating point value.
+func (f *File) SetFloat(group, key string, value float64) {
+	f.SetValue(group, key, strconv.FormatFloat(value, 'g', -1, 64))
+}
@@ -0,0 +1,112 @@
+// Copyright 2026 The go-xdgbasedir Authors. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package xdgbasedir
+
+import (
+	"fmt"
+	"path/filepath"
+	"strconv"
+)
+
+// Profile is a platform directory layout of the default base directories.
+type Profile int
+
+const (
+	// ProfileUnix is the specification default layout, used on linux and on darwin with the Unix mode.
+	ProfileUnix Profile = iota
+	// ProfileDarwin is the Apple FileSystemProgrammingGuide layout, used on darwin with the Native mode.
+	ProfileDarwin
+	// ProfileWindows is the AppData layout used on windows.
+	ProfileWindows
+)
+
+// String implements fmt.Stringer.
+func (p Profile) String() string {
+	switch p {
+	case ProfileUnix:
+		return "unix"
+	case ProfileDarwin:
+		return "darwin"
+	case ProfileWindows:
+		return "windows"
+	default:
+		return fmt.Sprintf("Profile(%d)", int(p))
+	}
+}
+
+// layout is the explicit inputs of the default base directories.
+type layout struct {
+	profile Profile
+	home    string
+	uid     int // negative if unknown
+}
+
+// dir returns the key default base directory of l.
+func (l *layout) dir(key string) string {
+	switch l.profile {
+	case ProfileDarwin:
+		return darwinDir(key, l.home)
+	case ProfileWindows:
+		return windowsDir(key, l.home)
+	default:
+		return unixDir(key, l.home, l.uid)
+	}
+}
+
+// unixDir returns the key base directory of the specification default layout for the user home directory and uid.
+// XDG_RUNTIME_DIR is empty if uid is negative.
+func unixDir(key, home string, uid int) string {
+	switch key {
+	case EnvDataHome:
+		return filepath.Join(home, ".local", "share")
+	case EnvConfigHome:
+		return filepath.Join(home, ".config")
+	case EnvStateHome:
+		return filepath.Join(home, ".local", "state")
+	case EnvDataDirs:
+		return filepath.Join("/usr", "local", "share") + string(filepath.ListSeparator) + filepath.Join("/usr", "share")
+	case EnvConfigDirs:
+		return filepath.Join("/etc", "xdg")
+	case EnvCacheHome:
+		return filepath.Join(home, ".cache")
+	case EnvRuntimeDir:
+		if uid < 0 {
+			return ""
+		}
+		return filepath.Join("/run", "user", strconv.Itoa(uid))
+	}
+	return ""
+}
+
+// darwinDir returns the key base directory of the Apple FileSystemProgrammingGuide layout for the user home directory.
+//
+// ref: https://developer.apple.com/library/content/documentation/FileManagement/Conceptual/FileSystemProgrammingGuide/MacOSXDirectories/MacOSXDirectories.html
+func darwinDir(key, home string) string {
+	switch key {
+	case EnvDataHome, EnvDataDirs, EnvStateHome, EnvRuntimeDir:
+		// Apple has no location for state data, which is application data kept across restarts
+		return filepath.Join(home, "Library", "Application Support")
+	case EnvConfigHome, EnvConfigDirs:
+		return filepath.Join(home, "Library", "Preferences")
+	case EnvCacheHome:
+		return filepath.Join(home, "Library", "Caches")
+	}
+	return ""
+}
+
+// windowsDir returns the key base directory of the AppData layout for the user home directory.
+func windowsDir(key, home string) string {
+	switch key {
+	case EnvDataHome, EnvConfigHome, EnvDataDirs, EnvConfigDirs:
+		return filepath.Join(home, "AppData", "Roaming")
+	case EnvStateHome:
+		return filepath.Join(home, "AppData", "Local", "state")
+	case EnvCacheHome:
+		return filepath.Join(home, "AppData", "Local", "cache")
+	case EnvRuntimeDir:
+		return home
+	}
+	return ""
+}
@@ -0,0 +1,20 @@
+// Copyright 2026 The go-xdgbasedir Authors. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+// Package locale implements the locale matching of the freedesktop.org specifications for localized keys.
+//
+//	https://specifications.freedesktop.org/desktop-entry-spec/latest/localized-keys.html
+//
+// A locale has the form lang_COUNTRY.ENCODING@MODIFIER, where _COUNTRY, .ENCODING and @MODIFIER may be
+// omitted. The messages locale is taken from LC_ALL, LC_MESSAGES and LANG in that order, and LANGUAGE
+// lists the preferred languages unless the messages locale is "C". Each locale expands to
+//
+//	lang_COUNTRY.ENCODING@MODIFIER
+//	lang_COUNTRY@MODIFIER
+//	lang_COUNTRY
+//	lang@MODIFIER
+//	lang
+//
+// in this order of preference. The environment is read through xdgbasedir.Resolver.Getenv.
+package locale // import "github.com/zchee/go-xdgbasedir/locale"
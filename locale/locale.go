@@ -0,0 +1,150 @@
+// Copyright 2026 The go-xdgbasedir Authors. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package locale
+
+import (
+	"strings"
+
+	"github.com/zchee/go-xdgbasedir"
+)
+
+// List of the locale environment variables.
+const (
+	EnvAll      = "LC_ALL"
+	EnvMessages = "LC_MESSAGES"
+	EnvLang     = "LANG"
+	EnvLanguage = "LANGUAGE"
+)
+
+// Locale is a parsed locale of the form lang_COUNTRY.ENCODING@MODIFIER.
+type Locale struct {
+	Lang     string
+	Country  string
+	Encoding string
+	Modifier string
+}
+
+// Parse parses the locale s such as "de_DE.UTF-8@euro".
+func Parse(s string) Locale {
+	var l Locale
+	if i := strings.IndexByte(s, '@'); i >= 0 {
+		s, l.Modifier = s[:i], s[i+1:]
+	}
+	if i := strings.IndexByte(s, '.'); i >= 0 {
+		s, l.Encoding = s[:i], s[i+1:]
+	}
+	if i := strings.IndexByte(s, '_'); i >= 0 {
+		s, l.Country = s[:i], s[i+1:]
+	}
+	l.Lang = s
+	return l
+}
+
+// String returns the locale in the form lang_COUNTRY.ENCODING@MODIFIER.
+func (l Locale) String() string {
+	s := l.Lang
+	if l.Country != "" {
+		s += "_" + l.Country
+	}
+	if l.Encoding != "" {
+		s += "." + l.Encoding
+	}
+	if l.Modifier != "" {
+		s += "@" + l.Modifier
+	}
+	return s
+}
+
+// IsC reports whether l is the "C" or "POSIX" locale, or empty, which has no translations.
+func (l Locale) IsC() bool {
+	return l.Lang == "" || l.Lang == "C" || l.Lang == "POSIX"
+}
+
+// Variants returns the locales matching l, most specific first.
+func (l Locale) Variants() []string {
+	if l.IsC() {
+		return nil
+	}
+	var variants []string
+	add := func(v Locale) {
+		s := v.String()
+		for _, e := range variants {
+			if e == s {
+				return
+			}
+		}
+		variants = append(variants, s)
+	}
+	add(l)
+	if l.Country != "" {
+		add(Locale{Lang: l.Lang, Country: l.Country, Modifier: l.Modifier})
+		add(Locale{Lang: l.Lang, Country: l.Country})
+	}
+	if l.Modifier != "" {
+		add(Locale{Lang: l.Lang, Modifier: l.Modifier})
+	}
+	add(Locale{Lang: l.Lang})
+	return variants
+}
+
+// Messages returns the messages locale of r's environment, from LC_ALL, LC_MESSAGES and LANG in that order.
+// If r is nil, xdgbasedir.Default() is used.
+func Messages(r *xdgbasedir.Resolver) Locale {
+	if r == nil {
+		r = xdgbasedir.Default()
+	}
+	for _, key := range []string{EnvAll, EnvMessages, EnvLang} {
+		if v := r.Getenv(key); v != "" {
+			return Parse(v)
+		}
+	}
+	return Locale{}
+}
+
+// Preferences returns the preference list of locales of r's environment, most preferred first, to look up
+// localized keys such as "Name[de_DE]". The list is empty for the "C" locale.
+// If r is nil, xdgbasedir.Default() is used.
+func Preferences(r *xdgbasedir.Resolver) []string {
+	if r == nil {
+		r = xdgbasedir.Default()
+	}
+	messages := Messages(r)
+	if messages.IsC() {
+		return nil
+	}
+
+	locales := []Locale{messages}
+	if language := r.Getenv(EnvLanguage); language != "" {
+		locales = locales[:0]
+		for _, s := range strings.Split(language, ":") {
+			if s != "" {
+				locales = append(locales, Parse(s))
+			}
+		}
+	}
+
+	var prefs []string
+	seen := make(map[string]bool)
+	for _, l := range locales {
+		for _, v := range l.Variants() {
+			if !seen[v] {
+				seen[v] = true
+				prefs = append(prefs, v)
+			}
+		}
+	}
+	return prefs
+}
+
+// Match returns the index in prefs of the locale of a localized key such as "de_DE@euro", or -1 if it does not
+// match. A lower index is a better match.
+func Match(prefs []string, locale string) int {
+	for i, p := range prefs {
+		if p == locale {
+			return i
+		}
+	}
+	return -1
+}
@@ -0,0 +1,130 @@
+// Copyright 2026 The go-xdgbasedir Authors. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package locale_test
+
+import (
+	"reflect"
+	"testing"
+
+	"github.com/zchee/go-xdgbasedir"
+	"github.com/zchee/go-xdgbasedir/locale"
+)
+
+func TestParse(t *testing.T) {
+	t.Parallel()
+
+	tests := []struct {
+		in   string
+		want locale.Locale
+	}{
+		{in: "de", want: locale.Locale{Lang: "de"}},
+		{in: "de_DE", want: locale.Locale{Lang: "de", Country: "DE"}},
+		{in: "de_DE.UTF-8", want: locale.Locale{Lang: "de", Country: "DE", Encoding: "UTF-8"}},
+		{in: "de_DE.UTF-8@euro", want: locale.Locale{Lang: "de", Country: "DE", Encoding: "UTF-8", Modifier: "euro"}},
+		{in: "sr@latin", want: locale.Locale{Lang: "sr", Modifier: "latin"}},
+		{in: "C.UTF-8", want: locale.Locale{Lang: "C", Encoding: "UTF-8"}},
+	}
+	for _, tt := range tests {
+		got := locale.Parse(tt.in)
+		if got != tt.want {
+			t.Errorf("Parse(%q) = %#v, want %#v", tt.in, got, tt.want)
+		}
+		if s := got.String(); s != tt.in {
+			t.Errorf("Parse(%q).String() = %q", tt.in, s)
+		}
+	}
+}
+
+func TestVariants(t *testing.T) {
+	t.Parallel()
+
+	tests := []struct {
+		in   string
+		want []string
+	}{
+		{in: "de_DE.UTF-8@euro", want: []string{"de_DE.UTF-8@euro", "de_DE@euro", "de_DE", "de@euro", "de"}},
+		{in: "de_DE.UTF-8", want: []string{"de_DE.UTF-8", "de_DE", "de"}},
+		{in: "sr@latin", want: []string{"sr@latin", "sr"}},
+		{in: "fr", want: []string{"fr"}},
+		{in: "C", want: nil},
+		{in: "POSIX", want: nil},
+	}
+	for _, tt := range tests {
+		if got := locale.Parse(tt.in).Variants(); !reflect.DeepEqual(got, tt.want) {
+			t.Errorf("Parse(%q).Variants() = %q, want %q", tt.in, got, tt.want)
+		}
+	}
+}
+
+func TestPreferences(t *testing.T) {
+	t.Parallel()
+
+	tests := []struct {
+		name     string
+		env      []string
+		messages string
+		want     []string
+	}{
+		{
+			name:     "LANG",
+			env:      []string{"LANG=de_DE.UTF-8"},
+			messages: "de_DE.UTF-8",
+			want:     []string{"de_DE.UTF-8", "de_DE", "de"},
+		},
+		{
+			name:     "LC_MESSAGES overrides LANG",
+			env:      []string{"LANG=de_DE.UTF-8", "LC_MESSAGES=fr_FR@euro"},
+			messages: "fr_FR@euro",
+			want:     []string{"fr_FR@euro", "fr_FR", "fr@euro", "fr"},
+		},
+		{
+			name:     "LC_ALL overrides LC_MESSAGES",
+			env:      []string{"LC_ALL=ja_JP", "LC_MESSAGES=fr_FR", "LANG=de_DE"},
+			messages: "ja_JP",
+			want:     []string{"ja_JP", "ja"},
+		},
+		{
+			name:     "LANGUAGE",
+			env:      []string{"LANG=en_US.UTF-8", "LANGUAGE=pt_BR:pt::en"},
+			messages: "en_US.UTF-8",
+			want:     []string{"pt_BR", "pt", "en"},
+		},
+		{
+			name:     "LANGUAGE is ignored for C",
+			env:      []string{"LANG=C", "LANGUAGE=de"},
+			messages: "C",
+			want:     nil,
+		},
+		{
+			name: "unset",
+			want: nil,
+		},
+	}
+	for _, tt := range tests {
+		tt := tt
+		t.Run(tt.name, func(t *testing.T) {
+			t.Parallel()
+
+			r := xdgbasedir.New(xdgbasedir.WithHermetic(xdgbasedir.Hermetic{Home: "/home/gopher", Env: tt.env}))
+			if got := locale.Messages(r).String(); got != tt.messages {
+				t.Errorf("Messages() = %q, want %q", got, tt.messages)
+			}
+			if got := locale.Preferences(r); !reflect.DeepEqual(got, tt.want) {
+				t.Errorf("Preferences() = %q, want %q", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestMatch(t *testing.T) {
+	t.Parallel()
+
+	prefs := locale.Parse("de_DE.UTF-8@euro").Variants()
+	for key, want := range map[string]int{"de_DE@euro": 1, "de": 4, "de_AT": -1} {
+		if got := locale.Match(prefs, key); got != want {
+			t.Errorf("Match(%q) = %d, want %d", key, got, want)
+		}
+	}
+}
@@ -0,0 +1,437 @@
+// Copyright 2026 The go-xdgbasedir Authors. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package xdgbasedir
+
+import (
+	"bytes"
+	"errors"
+	"io"
+	"io/fs"
+	"path/filepath"
+	"sort"
+	"strings"
+	"sync"
+	"syscall"
+	"time"
+)
+
+// MemFS is an in-memory FileSystem.
+//
+// It lets unit tests and sandboxes exercise search precedence, masking and directory creation without touching disk.
+// The zero value is an empty filesystem which has only the root directory. MemFS is safe for concurrent use.
+type MemFS struct {
+	mu    sync.RWMutex
+	nodes map[string]*memNode
+	now   func() time.Time
+}
+
+// memNode is a file, directory or symbolic link of MemFS.
+type memNode struct {
+	mode    fs.FileMode
+	data    []byte
+	target  string // symbolic link target
+	modTime time.Time
+}
+
+// NewMemFS returns a new MemFS seeded with files, keyed by host paths.
+func NewMemFS(files map[string]string) *MemFS {
+	m := new(MemFS)
+	for name, data := range files {
+		if err := m.MkdirAll(filepath.Dir(name), 0755); err != nil {
+			panic(err)
+		}
+		if err := m.WriteFile(name, []byte(data), 0644); err != nil {
+			panic(err)
+		}
+	}
+	return m
+}
+
+// init initializes m with the root directory. m.mu must be held.
+func (m *MemFS) init() {
+	if m.nodes == nil {
+		m.nodes = map[string]*memNode{
+			string(filepath.Separator): {mode: fs.ModeDir | 0755, modTime: m.time()},
+		}
+	}
+}
+
+func (m *MemFS) time() time.Time {
+	if m.now != nil {
+		return m.now()
+	}
+	return time.Now()
+}
+
+// clean returns the canonical form of name.
+func (m *MemFS) clean(name string) string {
+	name = filepath.Clean(name)
+	if !filepath.IsAbs(name) && !strings.HasPrefix(name, string(filepath.Separator)) {
+		name = string(filepath.Separator) + name
+	}
+	return name
+}
+
+// maxMemLinks is the maximum number of symbolic links followed by MemFS.
+const maxMemLinks = 40
+
+// resolve follows the symbolic links in name and returns the canonical path and its node. m.mu must be held.
+// If follow is false, the last element of name is not followed.
+func (m *MemFS) resolve(op, name string, follow bool) (string, *memNode, error) {
+	m.init()
+	name = m.clean(name)
+
+	links := 0
+	var walk func(name string, follow bool) (string, *memNode, error)
+	walk = func(name string, follow bool) (string, *memNode, error) {
+		if name == string(filepath.Separator) || name == filepath.VolumeName(name)+string(filepath.Separator) {
+			return name, m.nodes[string(filepath.Separator)], nil
+		}
+		dir, base := filepath.Split(name)
+		dir, parent, err := walk(filepath.Clean(dir), true)
+		if err != nil {
+			return "", nil, err
+		}
+		if parent == nil || !parent.mode.IsDir() {
+			return "", nil, &fs.PathError{Op: op, Path: name, Err: fs.ErrNotExist}
+		}
+		path := filepath.Join(dir, base)
+		n, ok := m.nodes[path]
+		if !ok {
+			return path, nil, nil
+		}
+		if n.mode&fs.ModeSymlink == 0 || !follow {
+			return path, n, nil
+		}
+		links++
+		if links > maxMemLinks {
+			return "", nil, &fs.PathError{Op: op, Path: name, Err: errors.New("too many levels of symbolic links")}
+		}
+		target := n.target
+		if !filepath.IsAbs(target) {
+			target = filepath.Join(dir, target)
+		}
+		return walk(m.clean(target), true)
+	}
+
+	return walk(name, follow)
+}
+
+// lookup returns the node of name, or fs.ErrNotExist. m.mu must be held.
+func (m *MemFS) lookup(op, name string, follow bool) (string, *memNode, error) {
+	path, n, err := m.resolve(op, name, follow)
+	if err != nil {
+		return "", nil, err
+	}
+	if n == nil {
+		return "", nil, &fs.PathError{Op: op, Path: name, Err: fs.ErrNotExist}
+	}
+	return path, n, nil
+}
+
+// Open implements FileSystem.
+func (m *MemFS) Open(name string) (fs.File, error) {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+
+	path, n, err := m.lookup("open", name, true)
+	if err != nil {
+		return nil, err
+	}
+	f := &memFile{info: m.info(path, n), r: bytes.NewReader(n.data)}
+	if n.mode.IsDir() {
+		f.entries = m.readDir(path)
+	}
+	return f, nil
+}
+
+// Stat implements FileSystem.
+func (m *MemFS) Stat(name string) (fs.FileInfo, error) {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+
+	path, n, err := m.lookup("stat", name, true)
+	if err != nil {
+		return nil, err
+	}
+	return m.info(path, n), nil
+}
+
+// Lstat implements FileSystem.
+func (m *MemFS) Lstat(name string) (fs.FileInfo, error) {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+
+	path, n, err := m.lookup("lstat", name, false)
+	if err != nil {
+		return nil, err
+	}
+	return m.info(path, n), nil
+}
+
+// Readlink implements FileSystem.
+func (m *MemFS) Readlink(name string) (string, error) {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+
+	_, n, err := m.lookup("readlink", name, false)
+	if err != nil {
+		return "", err
+	}
+	if n.mode&fs.ModeSymlink == 0 {
+		return "", &fs.PathError{Op: "readlink", Path: name, Err: fs.ErrInvalid}
+	}
+	return n.target, nil
+}
+
+// ReadDir implements FileSystem.
+func (m *MemFS) ReadDir(name string) ([]fs.DirEntry, error) {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+
+	path, n, err := m.lookup("readdirent", name, true)
+	if err != nil {
+		return nil, err
+	}
+	if !n.mode.IsDir() {
+		return nil, &fs.PathError{Op: "readdirent", Path: name, Err: errors.New("not a directory")}
+	}
+	return m.readDir(path), nil
+}
+
+// readDir returns the sorted entries of the dir directory. m.mu must be held.
+func (m *MemFS) readDir(dir string) []fs.DirEntry {
+	var entries []fs.DirEntry
+	for path, n := range m.nodes {
+		if path != dir && filepath.Dir(path) == dir {
+			entries = append(entries, fs.FileInfoToDirEntry(m.info(path, n)))
+		}
+	}
+	sort.Slice(entries, func(i, j int) bool { return entries[i].Name() < entries[j].Name() })
+	return entries
+}
+
+// ReadFile implements FileSystem.
+func (m *MemFS) ReadFile(name string) ([]byte, error) {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+
+	_, n, err := m.lookup("open", name, true)
+	if err != nil {
+		return nil, err
+	}
+	if n.mode.IsDir() {
+		return nil, &fs.PathError{Op: "read", Path: name, Err: errors.New("is a directory")}
+	}
+	return append([]byte(nil), n.data...), nil
+}
+
+// WriteFile implements FileSystem.
+func (m *MemFS) WriteFile(name string, data []byte, perm fs.FileMode) error {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+
+	path, n, err := m.resolve("open", name, true)
+	if err != nil {
+		return err
+	}
+	if n != nil {
+		if n.mode.IsDir() {
+			return &fs.PathError{Op: "open", Path: name, Err: errors.New("is a directory")}
+		}
+		n.data = append([]byte(nil), data...)
+		n.modTime = m.time()
+		return nil
+	}
+	m.nodes[path] = &memNode{mode: perm.Perm(), data: append([]byte(nil), data...), modTime: m.time()}
+	return nil
+}
+
+// MkdirAll implements FileSystem.
+func (m *MemFS) MkdirAll(path string, perm fs.FileMode) error {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+
+	m.init()
+	return m.mkdirAll(m.clean(path), perm)
+}
+
+// mkdirAll creates the path directory and its parents. m.mu must be held.
+func (m *MemFS) mkdirAll(path string, perm fs.FileMode) error {
+	resolved, n, err := m.resolve("mkdir", path, true)
+	if err != nil {
+		// the parent does not exist
+		dir := filepath.Dir(path)
+		if dir == path {
+			return err
+		}
+		if err := m.mkdirAll(dir, perm); err != nil {
+			return err
+		}
+		if resolved, n, err = m.resolve("mkdir", path, true); err != nil {
+			return err
+		}
+	}
+	if n != nil {
+		if !n.mode.IsDir() {
+			return &fs.PathError{Op: "mkdir", Path: path, Err: errors.New("not a directory")}
+		}
+		return nil
+	}
+	m.nodes[resolved] = &memNode{mode: fs.ModeDir | perm.Perm(), modTime: m.time()}
+	return nil
+}
+
+// Remove implements FileSystem.
+func (m *MemFS) Remove(name string) error {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+
+	path, n, err := m.lookup("remove", name, false)
+	if err != nil {
+		return err
+	}
+	if n.mode.IsDir() && len(m.readDir(path)) > 0 {
+		return &fs.PathError{Op: "remove", Path: name, Err: errors.New("directory not empty")}
+	}
+	delete(m.nodes, path)
+	return nil
+}
+
+// Rename implements FileSystem.
+func (m *MemFS) Rename(oldpath, newpath string) error {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+
+	src, n, err := m.lookup("rename", oldpath, false)
+	if err != nil {
+		return err
+	}
+	dst, existing, err := m.resolve("rename", newpath, false)
+	if err != nil {
+		return err
+	}
+	if existing != nil && existing.mode.IsDir() {
+		return &fs.PathError{Op: "rename", Path: newpath, Err: errors.New("file exists")}
+	}
+	if src == dst {
+		return nil
+	}
+	prefix := src + string(filepath.Separator)
+	if n.mode.IsDir() && strings.HasPrefix(dst, prefix) {
+		return &fs.PathError{Op: "rename", Path: newpath, Err: syscall.EINVAL}
+	}
+	m.nodes[dst] = n
+	delete(m.nodes, src)
+	if n.mode.IsDir() {
+		var children []string
+		for path := range m.nodes {
+			if strings.HasPrefix(path, prefix) {
+				children = append(children, path)
+			}
+		}
+		for _, path := range children {
+			m.nodes[filepath.Join(dst, path[len(prefix):])] = m.nodes[path]
+			delete(m.nodes, path)
+		}
+	}
+	return nil
+}
+
+// Symlink implements FileSystem.
+func (m *MemFS) Symlink(oldname, newname string) error {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+
+	path, n, err := m.resolve("symlink", newname, false)
+	if err != nil {
+		return err
+	}
+	if n != nil {
+		return &fs.PathError{Op: "symlink", Path: newname, Err: fs.ErrExist}
+	}
+	m.nodes[path] = &memNode{mode: fs.ModeSymlink | 0777, target: oldname, modTime: m.time()}
+	return nil
+}
+
+// Chtimes sets the modification time of name. It is useful for testing staleness checks.
+func (m *MemFS) Chtimes(name string, mtime time.Time) error {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+
+	_, n, err := m.lookup("chtimes", name, true)
+	if err != nil {
+		return err
+	}
+	n.modTime = mtime
+	return nil
+}
+
+// info returns the fs.FileInfo of n at path.
+func (m *MemFS) info(path string, n *memNode) fs.FileInfo {
+	return &memInfo{
+		name:    filepath.Base(path),
+		size:    int64(len(n.data)),
+		mode:    n.mode,
+		modTime: n.modTime,
+	}
+}
+
+// memInfo implements fs.FileInfo.
+type memInfo struct {
+	name    string
+	size    int64
+	mode    fs.FileMode
+	modTime time.Time
+}
+
+func (fi *memInfo) Name() string       { return fi.name }
+func (fi *memInfo) Size() int64        { return fi.size }
+func (fi *memInfo) Mode() fs.FileMode  { return fi.mode }
+func (fi *memInfo) ModTime() time.Time { return fi.modTime }
+func (fi *memInfo) IsDir() bool        { return fi.mode.IsDir() }
+func (fi *memInfo) Sys() interface{}   { return nil }
+
+// memFile implements fs.File and fs.ReadDirFile. It is also used for the merged directories of OverlayFS.
+type memFile struct {
+	info    fs.FileInfo
+	r       *bytes.Reader
+	entries []fs.DirEntry
+}
+
+func (f *memFile) Stat() (fs.FileInfo, error) { return f.info, nil }
+func (f *memFile) Read(p []byte) (int, error) {
+	if f.info.IsDir() {
+		return 0, &fs.PathError{Op: "read", Path: f.info.Name(), Err: errors.New("is a directory")}
+	}
+	return f.r.Read(p)
+}
+func (f *memFile) Close() error { return nil }
+
+// Seek implements io.Seeker.
+func (f *memFile) Seek(offset int64, whence int) (int64, error) {
+	return f.r.Seek(offset, whence)
+}
+
+// ReadDir implements fs.ReadDirFile.
+func (f *memFile) ReadDir(n int) ([]fs.DirEntry, error) {
+	if !f.info.IsDir() {
+		return nil, &fs.PathError{Op: "readdir", Path: f.info.Name(), Err: errors.New("not a directory")}
+	}
+	if n <= 0 {
+		entries := f.entries
+		f.entries = nil
+		return entries, nil
+	}
+	if len(f.entries) == 0 {
+		return nil, io.EOF
+	}
+	if n > len(f.entries) {
+		n = len(f.entries)
+	}
+	entries := f.entries[:n]
+	f.entries = f.entries[n:]
+	return entries, nil
+}
@@ -0,0 +1,202 @@
+// Copyright 2026 The go-xdgbasedir Authors. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package xdgbasedir
+
+import (
+	"context"
+	"errors"
+	"io"
+	"io/fs"
+	"path/filepath"
+	"reflect"
+	"syscall"
+	"testing"
+	"time"
+)
+
+func memResolver(fsys FileSystem) *Resolver {
+	return New(
+		WithHermetic(Hermetic{
+			Home: filepath.Join("/home", "gopher"),
+			UID:  1000,
+			Env: []string{
+				EnvConfigDirs + "=" + list(filepath.Join("/etc", "xdg"), filepath.Join("/opt", "etc", "xdg")),
+			},
+		}),
+		WithFileSystem(fsys),
+	)
+}
+
+func TestMemFS(t *testing.T) {
+	m := NewMemFS(map[string]string{
+		filepath.Join("/a", "b", "c.txt"): "c",
+		filepath.Join("/a", "d.txt"):      "d",
+	})
+
+	if data, err := m.ReadFile(filepath.Join("/a", "b", "c.txt")); err != nil || string(data) != "c" {
+		t.Errorf("ReadFile() = %q, %v", data, err)
+	}
+	if _, err := m.Stat(filepath.Join("/a", "missing")); !errors.Is(err, fs.ErrNotExist) {
+		t.Errorf("Stat() error = %v, want ErrNotExist", err)
+	}
+	if err := m.WriteFile(filepath.Join("/x", "y.txt"), nil, 0644); !errors.Is(err, fs.ErrNotExist) {
+		t.Errorf("WriteFile() without parent error = %v, want ErrNotExist", err)
+	}
+
+	entries, err := m.ReadDir("/a")
+	if err != nil {
+		t.Fatal(err)
+	}
+	var names []string
+	for _, e := range entries {
+		names = append(names, e.Name())
+	}
+	if want := []string{"b", "d.txt"}; !reflect.DeepEqual(names, want) {
+		t.Errorf("ReadDir() = %v, want %v", names, want)
+	}
+
+	if err := m.Symlink(filepath.Join("..", "d.txt"), filepath.Join("/a", "b", "link")); err != nil {
+		t.Fatal(err)
+	}
+	if data, err := m.ReadFile(filepath.Join("/a", "b", "link")); err != nil || string(data) != "d" {
+		t.Errorf("ReadFile(link) = %q, %v", data, err)
+	}
+	if fi, err := m.Lstat(filepath.Join("/a", "b", "link")); err != nil || fi.Mode()&fs.ModeSymlink == 0 {
+		t.Errorf("Lstat(link) = %v, %v, want symlink", fi, err)
+	}
+
+	if err := m.Rename(filepath.Join("/a", "b"), filepath.Join("/a", "e")); err != nil {
+		t.Fatal(err)
+	}
+	f, err := m.Open(filepath.Join("/a", "e", "c.txt"))
+	if err != nil {
+		t.Fatal(err)
+	}
+	if data, err := io.ReadAll(f); err != nil || string(data) != "c" {
+		t.Errorf("Read() = %q, %v", data, err)
+	}
+
+	if err := m.Remove("/a"); err == nil {
+		t.Error("Remove() of non-empty directory succeeded")
+	}
+	if err := m.Remove(filepath.Join("/a", "d.txt")); err != nil {
+		t.Error(err)
+	}
+}
+
+func TestMemFSRename(t *testing.T) {
+	m := NewMemFS(map[string]string{
+		filepath.Join("/a", "f.txt"):      "f",
+		filepath.Join("/a", "b", "c.txt"): "c",
+	})
+
+	name := filepath.Join("/a", "f.txt")
+	if err := m.Rename(name, name); err != nil {
+		t.Fatal(err)
+	}
+	if data, err := m.ReadFile(name); err != nil || string(data) != "f" {
+		t.Errorf("ReadFile() after Rename() to itself = %q, %v", data, err)
+	}
+
+	if err := m.Rename("/a", filepath.Join("/a", "b", "a")); !errors.Is(err, syscall.EINVAL) {
+		t.Errorf("Rename() into its own subtree error = %v, want EINVAL", err)
+	}
+	if data, err := m.ReadFile(filepath.Join("/a", "b", "c.txt")); err != nil || string(data) != "c" {
+		t.Errorf("ReadFile() after failed Rename() = %q, %v", data, err)
+	}
+	if _, err := m.Stat(filepath.Join("/a", "b", "a")); !errors.Is(err, fs.ErrNotExist) {
+		t.Errorf("Stat() of the rejected destination error = %v, want ErrNotExist", err)
+	}
+}
+
+func TestResolverMemFS(t *testing.T) {
+	m := NewMemFS(map[string]string{
+		filepath.Join("/opt", "etc", "xdg", "app", "app.conf"): "opt",
+		filepath.Join("/etc", "xdg", "app", "app.conf"):        "etc",
+	})
+	r := memResolver(m)
+
+	got, err := r.FindConfig(filepath.Join("app", "app.conf"))
+	if want := filepath.Join("/etc", "xdg", "app", "app.conf"); err != nil || got != want {
+		t.Errorf("FindConfig() = %v, %v, want %v", got, err, want)
+	}
+
+	path, err := r.ConfigFile(filepath.Join("app", "app.conf"))
+	if err != nil {
+		t.Fatal(err)
+	}
+	fi, err := m.Stat(filepath.Dir(path))
+	if err != nil {
+		t.Fatal(err)
+	}
+	if perm := fi.Mode().Perm(); perm != 0700 {
+		t.Errorf("ConfigFile() directory mode = %#o, want 0700", perm)
+	}
+	if err := m.WriteFile(path, []byte("user"), 0600); err != nil {
+		t.Fatal(err)
+	}
+
+	want := []string{
+		filepath.Join("/home", "gopher", ".config", "app", "app.conf"),
+		filepath.Join("/etc", "xdg", "app", "app.conf"),
+		filepath.Join("/opt", "etc", "xdg", "app", "app.conf"),
+	}
+	if got := r.ConfigPaths(filepath.Join("app", "app.conf")); !reflect.DeepEqual(got, want) {
+		t.Errorf("ConfigPaths() = %v, want %v", got, want)
+	}
+}
+
+func TestWatchConfig(t *testing.T) {
+	m := NewMemFS(map[string]string{
+		filepath.Join("/etc", "xdg", "app", "app.conf"): "etc",
+	})
+	r := memResolver(m)
+
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+	ch := r.WatchConfig(ctx, filepath.Join("app", "app.conf"), time.Millisecond)
+
+	next := func() Event {
+		select {
+		case ev := <-ch:
+			return ev
+		case <-time.After(5 * time.Second):
+			t.Fatal("timeout")
+		}
+		return Event{}
+	}
+
+	if ev := next(); ev.Path != filepath.Join("/etc", "xdg", "app", "app.conf") {
+		t.Errorf("initial Event = %v", ev)
+	}
+
+	path, err := r.ConfigFile(filepath.Join("app", "app.conf"))
+	if err != nil {
+		t.Fatal(err)
+	}
+	if err := m.WriteFile(path, []byte("user"), 0600); err != nil {
+		t.Fatal(err)
+	}
+	if ev := next(); ev.Path != path {
+		t.Errorf("shadowed Event = %v, want %v", ev, path)
+	}
+
+	if err := m.Chtimes(path, time.Now().Add(time.Hour)); err != nil {
+		t.Fatal(err)
+	}
+	if ev := next(); ev.Path != path {
+		t.Errorf("modified Event = %v, want %v", ev, path)
+	}
+
+	cancel()
+	for range ch {
+	}
+
+	ctx, cancel = context.WithCancel(context.Background())
+	defer cancel()
+	if ev := <-r.WatchConfig(ctx, filepath.Join("app", "app.conf"), 0); ev.Path != path {
+		t.Errorf("initial Event with zero interval = %v, want %v", ev, path)
+	}
+}
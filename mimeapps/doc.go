@@ -0,0 +1,21 @@
+// Copyright 2026 The go-xdgbasedir Authors. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+// Package mimeapps implements the freedesktop.org Association between MIME types and applications specification.
+//
+//	https://specifications.freedesktop.org/mime-apps-spec/latest/
+//
+// The default applications and the associations of a MIME type, or of a URI scheme as x-scheme-handler/<scheme>,
+// are looked up in the mimeapps.list files, and in the $desktop-mimeapps.list files of the desktop environments in
+// XDG_CURRENT_DESKTOP which take priority over them, of the following directories in precedence order:
+//
+//	$XDG_CONFIG_HOME
+//	$XDG_CONFIG_DIRS
+//	$XDG_DATA_HOME/applications
+//	$XDG_DATA_DIRS/applications
+//
+// The applications directories also provide the associations declared by the MimeType key of the installed desktop
+// entries, read from the mimeinfo.cache file written by update-desktop-database, or from the desktop files
+// themselves if there is no cache.
+package mimeapps // import "github.com/zchee/go-xdgbasedir/mimeapps"
@@ -0,0 +1,253 @@
+// Copyright 2026 The go-xdgbasedir Authors. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package mimeapps
+
+import (
+	"errors"
+	"fmt"
+	"path/filepath"
+	"strings"
+
+	"github.com/zchee/go-xdgbasedir"
+	"github.com/zchee/go-xdgbasedir/desktopentry"
+	"github.com/zchee/go-xdgbasedir/keyfile"
+)
+
+const (
+	// FileName is the file name of the mimeapps.list files.
+	FileName = "mimeapps.list"
+	// CacheFileName is the file name of the cache of the MimeType keys of desktop entries in an applications
+	// directory.
+	CacheFileName = "mimeinfo.cache"
+)
+
+// List of the groups of the mimeapps.list and mimeinfo.cache files.
+const (
+	DefaultApplications = "Default Applications"
+	AddedAssociations   = "Added Associations"
+	RemovedAssociations = "Removed Associations"
+	CacheGroup          = "MIME Cache"
+)
+
+// ErrNotFound is returned if no application is associated with the MIME type.
+var ErrNotFound = errors.New("mimeapps: no application found")
+
+// SchemeHandler returns the pseudo MIME type of the handlers of the URI scheme, such as
+// "x-scheme-handler/https".
+func SchemeHandler(scheme string) string {
+	return "x-scheme-handler/" + strings.ToLower(scheme)
+}
+
+// Files returns the paths of the mimeapps.list files resolved by r in precedence order, where the
+// $desktop-mimeapps.list files of the desktop environments in XDG_CURRENT_DESKTOP precede the mimeapps.list of
+// each directory. If r is nil, xdgbasedir.Default() is used.
+func Files(r *xdgbasedir.Resolver) []string {
+	if r == nil {
+		r = xdgbasedir.Default()
+	}
+	desktops := desktopentry.CurrentDesktops(r)
+	var files []string
+	for _, dir := range dirs(r) {
+		files = append(files, listFiles(dir.path, desktops)...)
+	}
+	return files
+}
+
+// listFiles returns the paths of the mimeapps.list files in dir for the desktops in precedence order.
+func listFiles(dir string, desktops []string) []string {
+	files := make([]string, 0, len(desktops)+1)
+	for _, desktop := range desktops {
+		files = append(files, filepath.Join(dir, strings.ToLower(desktop)+"-"+FileName))
+	}
+	return append(files, filepath.Join(dir, FileName))
+}
+
+// dir is a directory of mimeapps.list files.
+type dir struct {
+	path string
+	apps bool // applications directory providing the associations of desktop entries
+}
+
+// dirs returns the directories of mimeapps.list files resolved by r in precedence order.
+func dirs(r *xdgbasedir.Resolver) []dir {
+	var ds []dir
+	for _, path := range append([]string{r.ConfigHome()}, filepath.SplitList(r.ConfigDirs())...) {
+		if path != "" {
+			ds = append(ds, dir{path: path})
+		}
+	}
+	for _, path := range desktopentry.Dirs(r) {
+		ds = append(ds, dir{path: path, apps: true})
+	}
+	return ds
+}
+
+// get returns the desktop-file IDs of the MIME type in the group of the mimeapps.list file f.
+func get(f *keyfile.File, group, mimeType string) []string {
+	ids, _ := f.StringList(group, mimeType)
+	return ids
+}
+
+// source is a directory with its parsed mimeapps.list files and the MIME types of its desktop entries.
+type source struct {
+	lists []*keyfile.File
+	cache map[string][]string // desktop-file IDs keyed by MIME type, nil for the config directories
+}
+
+// database is a snapshot of the mimeapps.list files and the installed desktop entries resolved by a Resolver.
+type database struct {
+	sources   []*source
+	installed map[string]bool
+}
+
+// load loads the database resolved by r.
+func load(r *xdgbasedir.Resolver) *database {
+	db := &database{installed: make(map[string]bool)}
+	apps := desktopentry.Applications(r)
+	for _, e := range apps {
+		db.installed[e.ID] = true
+	}
+
+	desktops := desktopentry.CurrentDesktops(r)
+	for _, d := range dirs(r) {
+		src := new(source)
+		for _, path := range listFiles(d.path, desktops) {
+			if f := readKeyFile(r, path); f != nil {
+				src.lists = append(src.lists, f)
+			}
+		}
+		if d.apps {
+			src.cache = readCache(r, d.path, apps)
+		}
+		db.sources = append(db.sources, src)
+	}
+	return db
+}
+
+// readKeyFile reads the key file path, and returns nil if it is missing or malformed.
+func readKeyFile(r *xdgbasedir.Resolver, path string) *keyfile.File {
+	data, err := r.FS().ReadFile(path)
+	if err != nil {
+		return nil
+	}
+	f, err := keyfile.ParseBytes(data)
+	if err != nil {
+		return nil
+	}
+	return f
+}
+
+// readCache returns the desktop-file IDs keyed by MIME type of the applications directory dir from its
+// mimeinfo.cache, or from the MimeType keys of the effective entries installed in dir if there is no cache.
+func readCache(r *xdgbasedir.Resolver, dir string, apps []*desktopentry.Entry) map[string][]string {
+	cache := make(map[string][]string)
+	if f := readKeyFile(r, filepath.Join(dir, CacheFileName)); f != nil {
+		keys, _ := f.Keys(CacheGroup)
+		for _, mimeType := range keys {
+			cache[mimeType], _ = f.StringList(CacheGroup, mimeType)
+		}
+		return cache
+	}
+	for _, e := range apps {
+		if id, err := desktopentry.FileID(dir, e.File); err != nil || id != e.ID {
+			continue
+		}
+		for _, mimeType := range e.MimeType {
+			cache[mimeType] = append(cache[mimeType], e.ID)
+		}
+	}
+	return cache
+}
+
+// associations returns the installed desktop-file IDs associated with the MIME type in precedence order. The Added
+// Associations of each file and the MimeType keys of each applications directory are added unless they are
+// removed by the Removed Associations of the same or a higher precedence file.
+func (db *database) associations(mimeType string) []string {
+	var ids []string
+	seen := make(map[string]bool)
+	removed := make(map[string]bool)
+	add := func(list []string) {
+		for _, id := range list {
+			if !seen[id] && !removed[id] && db.installed[id] {
+				seen[id] = true
+				ids = append(ids, id)
+			}
+		}
+	}
+	for _, src := range db.sources {
+		for _, l := range src.lists {
+			add(get(l, AddedAssociations, mimeType))
+			for _, id := range get(l, RemovedAssociations, mimeType) {
+				removed[id] = true
+			}
+		}
+		add(src.cache[mimeType])
+	}
+	return ids
+}
+
+// defaultApp returns the first installed desktop-file ID in the Default Applications of the files in precedence
+// order, or the most preferred association if there is none.
+func (db *database) defaultApp(mimeType string, assocs []string) (string, bool) {
+	for _, src := range db.sources {
+		for _, l := range src.lists {
+			for _, id := range get(l, DefaultApplications, mimeType) {
+				if db.installed[id] {
+					return id, true
+				}
+			}
+		}
+	}
+	if len(assocs) > 0 {
+		return assocs[0], true
+	}
+	return "", false
+}
+
+// Default returns the desktop-file ID of the default application of the MIME type resolved by r, or ErrNotFound.
+// If r is nil, xdgbasedir.Default() is used.
+func Default(r *xdgbasedir.Resolver, mimeType string) (string, error) {
+	if r == nil {
+		r = xdgbasedir.Default()
+	}
+	db := load(r)
+	id, ok := db.defaultApp(mimeType, db.associations(mimeType))
+	if !ok {
+		return "", fmt.Errorf("mimeapps: %s: %w", mimeType, ErrNotFound)
+	}
+	return id, nil
+}
+
+// Associations returns the desktop-file IDs of the installed applications associated with the MIME type resolved
+// by r in precedence order, without the default application given priority. If r is nil, xdgbasedir.Default() is
+// used.
+func Associations(r *xdgbasedir.Resolver, mimeType string) []string {
+	if r == nil {
+		r = xdgbasedir.Default()
+	}
+	return load(r).associations(mimeType)
+}
+
+// Applications returns the desktop-file IDs of the candidate applications for the MIME type resolved by r, the
+// default application first followed by the other associations in precedence order. If r is nil,
+// xdgbasedir.Default() is used.
+func Applications(r *xdgbasedir.Resolver, mimeType string) []string {
+	if r == nil {
+		r = xdgbasedir.Default()
+	}
+	db := load(r)
+	assocs := db.associations(mimeType)
+	id, ok := db.defaultApp(mimeType, assocs)
+	if !ok {
+		return nil
+	}
+	ids := []string{id}
+	for _, a := range assocs {
+		if a != id {
+			ids = append(ids, a)
+		}
+	}
+	return ids
+}
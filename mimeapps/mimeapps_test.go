@@ -0,0 +1,164 @@
+// Copyright 2026 The go-xdgbasedir Authors. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package mimeapps_test
+
+import (
+	"errors"
+	"reflect"
+	"testing"
+
+	"github.com/zchee/go-xdgbasedir"
+	"github.com/zchee/go-xdgbasedir/mimeapps"
+	"github.com/zchee/go-xdgbasedir/xdgtest"
+)
+
+func app(name, mimeTypes string) string {
+	return "[Desktop Entry]\nType=Application\nName=" + name + "\nExec=" + name + " %U\nMimeType=" + mimeTypes + "\n"
+}
+
+func TestLookup(t *testing.T) {
+	t.Parallel()
+
+	tr := xdgtest.New(t, xdgtest.WithFiles(map[string]string{
+		"home/.config/mimeapps.list":       "[Default Applications]\ntext/markdown=missing.desktop;\n\n[Added Associations]\ntext/markdown=viewer.desktop;\n\n[Removed Associations]\ntext/markdown=notes.desktop;\n",
+		"home/.config/gnome-mimeapps.list": "[Default Applications]\nx-scheme-handler/https=browser.desktop;\n",
+		"etc/xdg/mimeapps.list":            "[Default Applications]\ntext/markdown=editor.desktop;\nx-scheme-handler/https=other.desktop;\n",
+
+		"home/.local/share/applications/editor.desktop":   app("editor", "text/plain;text/markdown;"),
+		"home/.local/share/applications/browser.desktop":  app("browser", "x-scheme-handler/https;"),
+		"usr/share/applications/mimeinfo.cache":           "[MIME Cache]\ntext/markdown=notes.desktop;ide.desktop;\nx-scheme-handler/https=other.desktop;\n",
+		"usr/share/applications/notes.desktop":            app("notes", "text/markdown;"),
+		"usr/share/applications/ide.desktop":              app("ide", "text/markdown;"),
+		"usr/share/applications/viewer.desktop":           app("viewer", "image/png;"),
+		"usr/share/applications/other.desktop":            app("other", "x-scheme-handler/https;"),
+		"usr/share/applications/uncached.desktop":         app("uncached", "text/markdown;"),
+		"usr/local/share/applications/hidden.desktop":     "[Desktop Entry]\nType=Application\nName=hidden\nHidden=true\nMimeType=text/markdown;\n",
+		"usr/local/share/applications/local-only.desktop": app("local-only", "text/markdown;"),
+		"usr/local/share/applications/mimeapps.list":      "[Added Associations]\ntext/markdown=hidden.desktop;\n",
+	}))
+	r := tr.Resolver(xdgbasedir.WithEnv(append(tr.Env(), "XDG_CURRENT_DESKTOP=ubuntu:GNOME")))
+
+	tests := []struct {
+		mimeType string
+		def      string
+		apps     []string
+	}{
+		{
+			mimeType: "text/markdown",
+			def:      "editor.desktop",
+			apps:     []string{"editor.desktop", "viewer.desktop", "local-only.desktop", "ide.desktop"},
+		},
+		{
+			mimeType: "text/plain",
+			def:      "editor.desktop",
+			apps:     []string{"editor.desktop"},
+		},
+		{
+			mimeType: mimeapps.SchemeHandler("HTTPS"),
+			def:      "browser.desktop",
+			apps:     []string{"browser.desktop", "other.desktop"},
+		},
+	}
+	for _, tt := range tests {
+		if got, err := mimeapps.Default(r, tt.mimeType); err != nil || got != tt.def {
+			t.Errorf("Default(%s) = %q, %v, want %q", tt.mimeType, got, err, tt.def)
+		}
+		if got := mimeapps.Applications(r, tt.mimeType); !reflect.DeepEqual(got, tt.apps) {
+			t.Errorf("Applications(%s) = %q, want %q", tt.mimeType, got, tt.apps)
+		}
+	}
+
+	if _, err := mimeapps.Default(r, "image/gif"); !errors.Is(err, mimeapps.ErrNotFound) {
+		t.Errorf("Default(image/gif) error = %v, want ErrNotFound", err)
+	}
+	if got := mimeapps.Applications(r, "image/gif"); got != nil {
+		t.Errorf("Applications(image/gif) = %q, want nil", got)
+	}
+
+	want := []string{
+		tr.Path("home/.config/ubuntu-mimeapps.list"),
+		tr.Path("home/.config/gnome-mimeapps.list"),
+		tr.Path("home/.config/mimeapps.list"),
+	}
+	if got := mimeapps.Files(r)[:3]; !reflect.DeepEqual(got, want) {
+		t.Errorf("Files()[:3] = %q, want %q", got, want)
+	}
+}
+
+func TestWrite(t *testing.T) {
+	t.Parallel()
+
+	const user = `# managed by hand
+[Default Applications]
+text/markdown=notes.desktop;ide.desktop;
+
+[X-Custom]
+Key=value
+`
+	tr := xdgtest.New(t, xdgtest.WithFiles(map[string]string{
+		"home/.config/mimeapps.list":             user,
+		"etc/xdg/mimeapps.list":                  "[Default Applications]\nimage/png=viewer.desktop;\n",
+		"usr/share/applications/editor.desktop":  app("editor", "text/markdown;"),
+		"usr/share/applications/notes.desktop":   app("notes", "text/markdown;"),
+		"usr/share/applications/ide.desktop":     app("ide", "text/markdown;"),
+		"usr/share/applications/viewer.desktop":  app("viewer", "image/png;"),
+		"usr/share/applications/browser.desktop": app("browser", "x-scheme-handler/https;"),
+	}))
+	r := tr.Resolver(xdgbasedir.WithEnv(append(tr.Env(), "XDG_CURRENT_DESKTOP=KDE")))
+
+	if err := mimeapps.SetDefault(r, "text/markdown", "ide.desktop"); err != nil {
+		t.Fatal(err)
+	}
+	if err := mimeapps.RemoveAssociation(r, "text/markdown", "notes.desktop"); err != nil {
+		t.Fatal(err)
+	}
+	if err := mimeapps.AddAssociation(r, "image/png", "editor.desktop"); err != nil {
+		t.Fatal(err)
+	}
+	if err := mimeapps.SetDefault(r, mimeapps.SchemeHandler("https"), "browser.desktop", mimeapps.WithCurrentDesktop()); err != nil {
+		t.Fatal(err)
+	}
+	tr.AssertFileContent("home/.config/mimeapps.list", `# managed by hand
+[Default Applications]
+text/markdown=ide.desktop;
+
+[X-Custom]
+Key=value
+
+[Added Associations]
+text/markdown=ide.desktop;
+image/png=editor.desktop;
+
+[Removed Associations]
+text/markdown=notes.desktop;
+`)
+	tr.AssertFileContent("home/.config/kde-mimeapps.list", "[Default Applications]\nx-scheme-handler/https=browser.desktop;\n\n[Added Associations]\nx-scheme-handler/https=browser.desktop;\n")
+	tr.AssertFileContent("etc/xdg/mimeapps.list", "[Default Applications]\nimage/png=viewer.desktop;\n")
+
+	if got, want := mimeapps.Applications(r, "text/markdown"), []string{"ide.desktop", "editor.desktop"}; !reflect.DeepEqual(got, want) {
+		t.Errorf("Applications(text/markdown) = %q, want %q", got, want)
+	}
+	if got, want := mimeapps.Applications(r, "image/png"), []string{"viewer.desktop", "editor.desktop"}; !reflect.DeepEqual(got, want) {
+		t.Errorf("Applications(image/png) = %q, want %q", got, want)
+	}
+
+	if err := mimeapps.AddAssociation(r, "text/markdown", "notes.desktop"); err != nil {
+		t.Fatal(err)
+	}
+	if got, want := mimeapps.Associations(r, "text/markdown"), []string{"ide.desktop", "notes.desktop", "editor.desktop"}; !reflect.DeepEqual(got, want) {
+		t.Errorf("Associations(text/markdown) = %q, want %q", got, want)
+	}
+
+	for _, tt := range []struct{ mimeType, id string }{{"markdown", "ide.desktop"}, {"text/markdown", "ide"}, {"text/markdown", "a;b.desktop"}} {
+		if err := mimeapps.SetDefault(r, tt.mimeType, tt.id); err == nil {
+			t.Errorf("SetDefault(%q, %q) succeeded", tt.mimeType, tt.id)
+		}
+	}
+	for _, mimeType := range []string{"text/a\nb", "text/a\rb", "text/a\tb", "text/a\x7fb"} {
+		if err := mimeapps.AddAssociation(r, mimeType, "notes.desktop"); err == nil {
+			t.Errorf("AddAssociation(%q) succeeded", mimeType)
+		}
+	}
+}
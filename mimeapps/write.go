@@ -0,0 +1,194 @@
+// Copyright 2026 The go-xdgbasedir Authors. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package mimeapps
+
+import (
+	"errors"
+	"fmt"
+	"io/fs"
+	"path/filepath"
+	"strings"
+
+	"github.com/zchee/go-xdgbasedir"
+	"github.com/zchee/go-xdgbasedir/desktopentry"
+	"github.com/zchee/go-xdgbasedir/keyfile"
+)
+
+// filePerm is the permission of the written mimeapps.list.
+const filePerm = 0644
+
+// dirPerm is the permission of the created XDG_CONFIG_HOME directory.
+const dirPerm = 0700
+
+// options configures the writes of the user mimeapps.list.
+type options struct {
+	desktop        string
+	currentDesktop bool
+}
+
+// Option configures SetDefault, AddAssociation and RemoveAssociation.
+type Option func(*options)
+
+// WithDesktop makes the write target $desktop-mimeapps.list of the desktop environment, such as "GNOME", instead
+// of mimeapps.list, so that the change only applies to that desktop.
+func WithDesktop(desktop string) Option {
+	return func(o *options) {
+		o.desktop = desktop
+	}
+}
+
+// WithCurrentDesktop makes the write target $desktop-mimeapps.list of the first desktop environment in
+// XDG_CURRENT_DESKTOP. mimeapps.list is written if XDG_CURRENT_DESKTOP is unset.
+func WithCurrentDesktop() Option {
+	return func(o *options) {
+		o.currentDesktop = true
+	}
+}
+
+// UserFile returns the path of the user mimeapps.list under r's ConfigHome written by SetDefault, AddAssociation
+// and RemoveAssociation with opts. If r is nil, xdgbasedir.Default() is used.
+func UserFile(r *xdgbasedir.Resolver, opts ...Option) string {
+	if r == nil {
+		r = xdgbasedir.Default()
+	}
+	var o options
+	for _, opt := range opts {
+		opt(&o)
+	}
+	desktop := o.desktop
+	if o.currentDesktop {
+		if desktops := desktopentry.CurrentDesktops(r); len(desktops) > 0 {
+			desktop = desktops[0]
+		}
+	}
+	name := FileName
+	if desktop != "" {
+		name = strings.ToLower(desktop) + "-" + FileName
+	}
+	return filepath.Join(r.ConfigHome(), name)
+}
+
+// SetDefault makes the application of the desktop-file ID the default of the MIME type, or of a URI scheme with
+// SchemeHandler, in the user mimeapps.list resolved by r. The application is also moved to the front of the Added
+// Associations and is no longer removed. If r is nil, xdgbasedir.Default() is used.
+func SetDefault(r *xdgbasedir.Resolver, mimeType, id string, opts ...Option) error {
+	return update(r, mimeType, id, opts, func(f *keyfile.File) {
+		prepend(f, DefaultApplications, mimeType, id)
+		prepend(f, AddedAssociations, mimeType, id)
+		remove(f, RemovedAssociations, mimeType, id)
+	})
+}
+
+// AddAssociation associates the application of the desktop-file ID with the MIME type in the user mimeapps.list
+// resolved by r. If r is nil, xdgbasedir.Default() is used.
+func AddAssociation(r *xdgbasedir.Resolver, mimeType, id string, opts ...Option) error {
+	return update(r, mimeType, id, opts, func(f *keyfile.File) {
+		if ids := get(f, AddedAssociations, mimeType); !contains(ids, id) {
+			f.SetStringList(AddedAssociations, mimeType, append(ids, id))
+		}
+		remove(f, RemovedAssociations, mimeType, id)
+	})
+}
+
+// RemoveAssociation dissociates the application of the desktop-file ID from the MIME type in the user
+// mimeapps.list resolved by r, which also hides the associations of the lower precedence files and the MimeType
+// key of the desktop entry. If r is nil, xdgbasedir.Default() is used.
+func RemoveAssociation(r *xdgbasedir.Resolver, mimeType, id string, opts ...Option) error {
+	return update(r, mimeType, id, opts, func(f *keyfile.File) {
+		remove(f, DefaultApplications, mimeType, id)
+		remove(f, AddedAssociations, mimeType, id)
+		if ids := get(f, RemovedAssociations, mimeType); !contains(ids, id) {
+			f.SetStringList(RemovedAssociations, mimeType, append(ids, id))
+		}
+	})
+}
+
+// update applies fn to the user mimeapps.list resolved by r and writes it atomically if it is changed, keeping
+// the other groups, keys and comments as is.
+func update(r *xdgbasedir.Resolver, mimeType, id string, opts []Option, fn func(f *keyfile.File)) error {
+	if r == nil {
+		r = xdgbasedir.Default()
+	}
+	if !validMIMEType(mimeType) {
+		return fmt.Errorf("mimeapps: invalid MIME type %q", mimeType)
+	}
+	if !strings.HasSuffix(id, desktopentry.Ext) || strings.ContainsAny(id, "/;") {
+		return fmt.Errorf("mimeapps: invalid desktop-file ID %q", id)
+	}
+
+	path := UserFile(r, opts...)
+	data, err := r.FS().ReadFile(path)
+	if err != nil && !errors.Is(err, fs.ErrNotExist) {
+		return err
+	}
+	f, err := keyfile.ParseBytes(data)
+	if err != nil {
+		return fmt.Errorf("%s: %w", path, err)
+	}
+	fn(f)
+	out := f.Bytes()
+	if string(out) == string(data) {
+		return nil
+	}
+
+	if err := r.FS().MkdirAll(filepath.Dir(path), dirPerm); err != nil {
+		return err
+	}
+	return xdgbasedir.WriteFileAtomic(r.FS(), path, out, filePerm)
+}
+
+// prepend moves id to the front of the list of the MIME type in the group of f.
+func prepend(f *keyfile.File, group, mimeType, id string) {
+	ids := []string{id}
+	for _, e := range get(f, group, mimeType) {
+		if e != id {
+			ids = append(ids, e)
+		}
+	}
+	f.SetStringList(group, mimeType, ids)
+}
+
+// remove removes id from the list of the MIME type in the group of f, deleting the key if the list gets empty.
+func remove(f *keyfile.File, group, mimeType, id string) {
+	old := get(f, group, mimeType)
+	if !contains(old, id) {
+		return
+	}
+	var ids []string
+	for _, e := range old {
+		if e != id {
+			ids = append(ids, e)
+		}
+	}
+	if len(ids) == 0 {
+		f.DeleteKey(group, mimeType)
+		return
+	}
+	f.SetStringList(group, mimeType, ids)
+}
+
+// validMIMEType reports whether s is a media type such as "text/markdown" or "x-scheme-handler/https", which is
+// also a valid key of mimeapps.list.
+func validMIMEType(s string) bool {
+	typ, sub, ok := strings.Cut(s, "/")
+	if !ok || typ == "" || sub == "" || strings.ContainsAny(s, " =;[]") {
+		return false
+	}
+	for i := 0; i < len(s); i++ {
+		if c := s[i]; c < 0x20 || c == 0x7f {
+			return false
+		}
+	}
+	return true
+}
+
+func contains(list []string, s string) bool {
+	for _, e := range list {
+		if e == s {
+			return true
+		}
+	}
+	return false
+}
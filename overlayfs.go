@@ -0,0 +1,260 @@
+// Copyright 2026 The go-xdgbasedir Authors. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package xdgbasedir
+
+import (
+	"bytes"
+	"errors"
+	"io/fs"
+	"path"
+	"path/filepath"
+	"sort"
+)
+
+// OverlayFS is a merged io/fs view of layered directories, such as ConfigHome shadowing each entry of ConfigDirs.
+//
+// A file in a higher precedence layer shadows the same named file in the lower layers, and directories
+// are merged. OverlayFS implements fs.ReadDirFS, fs.ReadFileFS, fs.StatFS and fs.GlobFS, so it can be passed
+// to template loaders, embed-compatible code and http.FS.
+type OverlayFS struct {
+	layers []fs.FS // in precedence order
+}
+
+// ConfigFS returns the merged view of the app directory of ConfigHome and each ConfigDirs.
+func ConfigFS(app string) *OverlayFS {
+	return std.ConfigFS(app)
+}
+
+// DataFS returns the merged view of the app directory of DataHome and each DataDirs.
+func DataFS(app string) *OverlayFS {
+	return std.DataFS(app)
+}
+
+// ConfigFS returns the merged view of the app directory of r's ConfigHome and each ConfigDirs.
+func (r *Resolver) ConfigFS(app string) *OverlayFS {
+	return r.overlay(app, r.ConfigHome(), r.ConfigDirs())
+}
+
+// DataFS returns the merged view of the app directory of r's DataHome and each DataDirs.
+func (r *Resolver) DataFS(app string) *OverlayFS {
+	return r.overlay(app, r.DataHome(), r.DataDirs())
+}
+
+// overlay returns the OverlayFS of the app directory of home and dirs in r's FileSystem.
+func (r *Resolver) overlay(app, home, dirs string) *OverlayFS {
+	o := new(OverlayFS)
+	for _, dir := range append([]string{home}, splitList(dirs)...) {
+		o.layers = append(o.layers, &hostFS{fsys: r.FS(), dir: filepath.Join(dir, app)})
+	}
+	return o
+}
+
+// NewOverlayFS returns the merged view of layers, in precedence order.
+func NewOverlayFS(layers ...fs.FS) *OverlayFS {
+	return &OverlayFS{layers: layers}
+}
+
+// WithDefaults returns a copy of o with defaults, such as an embed.FS, added as the lowest precedence layer.
+func (o *OverlayFS) WithDefaults(defaults fs.FS) *OverlayFS {
+	layers := append(append([]fs.FS(nil), o.layers...), defaults)
+	return &OverlayFS{layers: layers}
+}
+
+// Open implements fs.FS.
+func (o *OverlayFS) Open(name string) (fs.File, error) {
+	if !fs.ValidPath(name) {
+		return nil, &fs.PathError{Op: "open", Path: name, Err: fs.ErrInvalid}
+	}
+	fi, err := o.Stat(name)
+	if err != nil {
+		return nil, err
+	}
+	if fi.IsDir() {
+		entries, err := o.ReadDir(name)
+		if err != nil {
+			return nil, err
+		}
+		return &memFile{info: fi, r: bytes.NewReader(nil), entries: entries}, nil
+	}
+	for _, layer := range o.layers {
+		f, err := layer.Open(name)
+		if err == nil {
+			return f, nil
+		}
+		if !errors.Is(err, fs.ErrNotExist) {
+			return nil, err
+		}
+		if shadowed(layer, name) {
+			break
+		}
+	}
+	return nil, &fs.PathError{Op: "open", Path: name, Err: fs.ErrNotExist}
+}
+
+// Stat implements fs.StatFS. It returns the fs.FileInfo of name in the highest precedence layer.
+// Like ReadDir, a file in a higher precedence layer shadows the directories of the lower layers, including the
+// parent directories of name.
+func (o *OverlayFS) Stat(name string) (fs.FileInfo, error) {
+	if !fs.ValidPath(name) {
+		return nil, &fs.PathError{Op: "stat", Path: name, Err: fs.ErrInvalid}
+	}
+	for _, layer := range o.layers {
+		fi, err := fs.Stat(layer, name)
+		if err == nil {
+			return fi, nil
+		}
+		if !errors.Is(err, fs.ErrNotExist) {
+			return nil, err
+		}
+		if shadowed(layer, name) {
+			break
+		}
+	}
+	return nil, &fs.PathError{Op: "stat", Path: name, Err: fs.ErrNotExist}
+}
+
+// ReadFile implements fs.ReadFileFS.
+func (o *OverlayFS) ReadFile(name string) ([]byte, error) {
+	if !fs.ValidPath(name) {
+		return nil, &fs.PathError{Op: "read", Path: name, Err: fs.ErrInvalid}
+	}
+	for _, layer := range o.layers {
+		data, err := fs.ReadFile(layer, name)
+		if err == nil {
+			return data, nil
+		}
+		if !errors.Is(err, fs.ErrNotExist) {
+			return nil, err
+		}
+		if shadowed(layer, name) {
+			break
+		}
+	}
+	return nil, &fs.PathError{Op: "read", Path: name, Err: fs.ErrNotExist}
+}
+
+// ReadDir implements fs.ReadDirFS. It merges the entries of the name directory of all layers, where
+// an entry of a higher precedence layer shadows the same named entry of the lower layers.
+// A file in a higher precedence layer shadows the directories of the lower layers.
+func (o *OverlayFS) ReadDir(name string) ([]fs.DirEntry, error) {
+	if !fs.ValidPath(name) {
+		return nil, &fs.PathError{Op: "readdir", Path: name, Err: fs.ErrInvalid}
+	}
+
+	found := false
+	seen := make(map[string]bool)
+	var entries []fs.DirEntry
+	for _, layer := range o.layers {
+		fi, err := fs.Stat(layer, name)
+		if err != nil {
+			if shadowed(layer, name) {
+				break
+			}
+			continue
+		}
+		if !fi.IsDir() {
+			// a file shadows the directories of the lower layers
+			break
+		}
+		list, err := fs.ReadDir(layer, name)
+		if err != nil {
+			return nil, err
+		}
+		found = true
+		for _, e := range list {
+			if !seen[e.Name()] {
+				seen[e.Name()] = true
+				entries = append(entries, e)
+			}
+		}
+	}
+	if !found {
+		if _, err := o.Stat(name); err == nil {
+			return nil, &fs.PathError{Op: "readdir", Path: name, Err: errors.New("not a directory")}
+		}
+		return nil, &fs.PathError{Op: "readdir", Path: name, Err: fs.ErrNotExist}
+	}
+	sort.Slice(entries, func(i, j int) bool { return entries[i].Name() < entries[j].Name() })
+	return entries, nil
+}
+
+// shadowed reports whether a parent directory of name is a file in layer, which shadows the directories of the
+// lower layers.
+func shadowed(layer fs.FS, name string) bool {
+	for dir := path.Dir(name); dir != "."; dir = path.Dir(dir) {
+		if fi, err := fs.Stat(layer, dir); err == nil && !fi.IsDir() {
+			return true
+		}
+	}
+	return false
+}
+
+// Glob implements fs.GlobFS over the merged tree.
+func (o *OverlayFS) Glob(pattern string) ([]string, error) {
+	// hide the Glob method, otherwise fs.Glob calls it recursively
+	return fs.Glob(readDirFS{o}, pattern)
+}
+
+// readDirFS hides the Glob method of OverlayFS.
+type readDirFS struct {
+	o *OverlayFS
+}
+
+func (f readDirFS) Open(name string) (fs.File, error) {
+	return f.o.Open(name)
+}
+
+func (f readDirFS) ReadDir(name string) ([]fs.DirEntry, error) {
+	return f.o.ReadDir(name)
+}
+
+// hostFS is the io/fs view of the dir host directory of a FileSystem.
+type hostFS struct {
+	fsys FileSystem
+	dir  string
+}
+
+func (h *hostFS) path(op, name string) (string, error) {
+	if !fs.ValidPath(name) {
+		return "", &fs.PathError{Op: op, Path: name, Err: fs.ErrInvalid}
+	}
+	return filepath.Join(h.dir, filepath.FromSlash(name)), nil
+}
+
+// Open implements fs.FS.
+func (h *hostFS) Open(name string) (fs.File, error) {
+	path, err := h.path("open", name)
+	if err != nil {
+		return nil, err
+	}
+	return h.fsys.Open(path)
+}
+
+// Stat implements fs.StatFS.
+func (h *hostFS) Stat(name string) (fs.FileInfo, error) {
+	path, err := h.path("stat", name)
+	if err != nil {
+		return nil, err
+	}
+	return h.fsys.Stat(path)
+}
+
+// ReadDir implements fs.ReadDirFS.
+func (h *hostFS) ReadDir(name string) ([]fs.DirEntry, error) {
+	path, err := h.path("readdir", name)
+	if err != nil {
+		return nil, err
+	}
+	return h.fsys.ReadDir(path)
+}
+
+// ReadFile implements fs.ReadFileFS.
+func (h *hostFS) ReadFile(name string) ([]byte, error) {
+	path, err := h.path("read", name)
+	if err != nil {
+		return nil, err
+	}
+	return h.fsys.ReadFile(path)
+}
@@ -0,0 +1,68 @@
+// Copyright 2026 The go-xdgbasedir Authors. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package xdgbasedir
+
+import (
+	"errors"
+	"io/fs"
+	"path/filepath"
+	"reflect"
+	"testing"
+	"testing/fstest"
+)
+
+func TestConfigFS(t *testing.T) {
+	m := NewMemFS(map[string]string{
+		filepath.Join("/home", "gopher", ".config", "app", "app.conf"):         "user",
+		filepath.Join("/home", "gopher", ".config", "app", "conf.d", "a.conf"): "user a",
+		filepath.Join("/etc", "xdg", "app", "app.conf"):                        "etc",
+		filepath.Join("/etc", "xdg", "app", "conf.d", "b.conf"):                "etc b",
+		filepath.Join("/opt", "etc", "xdg", "app", "conf.d", "a.conf"):         "opt a",
+		filepath.Join("/opt", "etc", "xdg", "app", "theme"):                    "opt theme",
+	})
+	defaults := fstest.MapFS{
+		"app.conf":        {Data: []byte("default")},
+		"default.conf":    {Data: []byte("default")},
+		"theme/dark.conf": {Data: []byte("shadowed by the theme file")},
+	}
+	fsys := memResolver(m).ConfigFS("app").WithDefaults(defaults)
+
+	if err := fstest.TestFS(fsys, "app.conf", "conf.d/a.conf", "conf.d/b.conf", "default.conf", "theme"); err != nil {
+		t.Fatal(err)
+	}
+
+	files := map[string]string{
+		"app.conf":      "user",
+		"conf.d/a.conf": "user a",
+		"conf.d/b.conf": "etc b",
+		"default.conf":  "default",
+	}
+	for name, want := range files {
+		if data, err := fs.ReadFile(fsys, name); err != nil || string(data) != want {
+			t.Errorf("ReadFile(%q) = %q, %v, want %q", name, data, err, want)
+		}
+	}
+
+	got, err := fs.Glob(fsys, "conf.d/*.conf")
+	if err != nil {
+		t.Fatal(err)
+	}
+	if want := []string{"conf.d/a.conf", "conf.d/b.conf"}; !reflect.DeepEqual(got, want) {
+		t.Errorf("Glob() = %v, want %v", got, want)
+	}
+
+	if _, err := fs.ReadDir(fsys, "theme"); err == nil {
+		t.Error("ReadDir(theme) succeeded, want the file to shadow the default directory")
+	}
+	if _, err := fs.Stat(fsys, "theme/dark.conf"); !errors.Is(err, fs.ErrNotExist) {
+		t.Errorf("Stat(theme/dark.conf) error = %v, want ErrNotExist under the shadowing file", err)
+	}
+	if _, err := fs.ReadFile(fsys, "theme/dark.conf"); !errors.Is(err, fs.ErrNotExist) {
+		t.Errorf("ReadFile(theme/dark.conf) error = %v, want ErrNotExist under the shadowing file", err)
+	}
+	if _, err := fsys.Open("theme/dark.conf"); !errors.Is(err, fs.ErrNotExist) {
+		t.Errorf("Open(theme/dark.conf) error = %v, want ErrNotExist under the shadowing file", err)
+	}
+}
@@ -0,0 +1,113 @@
+// Copyright 2026 The go-xdgbasedir Authors. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package xdgbasedir
+
+import (
+	"os"
+	"path/filepath"
+)
+
+// Portable mode layout under the root directory.
+//
+// In portable mode, every base directory is mapped under a single root directory with this fixed layout,
+// and the XDG environment variables are ignored.
+const (
+	PortableData    = "data"
+	PortableConfig  = "config"
+	PortableState   = "state"
+	PortableCache   = "cache"
+	PortableRuntime = "runtime"
+)
+
+// portable holds the portable mode configuration of a Resolver.
+type portable struct {
+	root   string // explicit root set by WithPortableRoot, and the activated root after New
+	env    string
+	marker string
+}
+
+// WithPortableRoot makes the Resolver map every base directory under root.
+func WithPortableRoot(root string) Option {
+	return func(r *Resolver) {
+		r.portable.root = root
+	}
+}
+
+// WithPortableEnv makes the Resolver map every base directory under the value of the key environment variable,
+// such as "MYAPP_HOME", if it is set and not empty.
+func WithPortableEnv(key string) Option {
+	return func(r *Resolver) {
+		r.portable.env = key
+	}
+}
+
+// WithPortableMarker makes the Resolver map every base directory under the directory of the executable,
+// if a file named name exists next to the executable.
+func WithPortableMarker(name string) Option {
+	return func(r *Resolver) {
+		r.portable.marker = name
+	}
+}
+
+// activate determines the portable root of r. WithPortableRoot has priority over WithPortableEnv,
+// which has priority over WithPortableMarker.
+func (p *portable) activate(r *Resolver) {
+	root := p.root
+	if root == "" && p.env != "" {
+		root = r.expandUser(r.getenv(p.env))
+	}
+	if root == "" && p.marker != "" {
+		root = markerDir(p.marker)
+	}
+	if root == "" {
+		return
+	}
+
+	if abs, err := filepath.Abs(root); err == nil {
+		root = abs
+	}
+	p.root = root
+}
+
+// markerDir returns the directory of the executable if the name file exists next to it.
+func markerDir(name string) string {
+	exe, err := os.Executable()
+	if err != nil {
+		return ""
+	}
+	if resolved, err := filepath.EvalSymlinks(exe); err == nil {
+		exe = resolved
+	}
+	dir := filepath.Dir(exe)
+	if _, err := os.Stat(filepath.Join(dir, name)); err != nil {
+		return ""
+	}
+	return dir
+}
+
+// Portable reports whether r is in portable mode, and returns the portable root directory.
+func (r *Resolver) Portable() (root string, ok bool) {
+	return r.portable.root, r.portable.root != ""
+}
+
+// portableDir returns the key XDG base directory under the portable root.
+func (r *Resolver) portableDir(key string) string {
+	var dir string
+	switch key {
+	case EnvDataHome, EnvDataDirs:
+		dir = PortableData
+	case EnvConfigHome, EnvConfigDirs:
+		dir = PortableConfig
+	case EnvStateHome:
+		dir = PortableState
+	case EnvCacheHome:
+		dir = PortableCache
+	case EnvRuntimeDir:
+		dir = PortableRuntime
+	default:
+		return ""
+	}
+	return filepath.Join(r.portable.root, dir)
+}
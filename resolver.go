@@ -0,0 +1,261 @@
+// Copyright 2026 The go-xdgbasedir Authors. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package xdgbasedir
+
+import (
+	"os"
+	"path/filepath"
+	"runtime"
+	"strings"
+
+	"github.com/zchee/go-xdgbasedir/home"
+)
+
+// Resolver resolves the XDG base directories from an environment.
+//
+// The package level functions use the Resolver returned by Default, which resolves from the process environment.
+type Resolver struct {
+	env      map[string]string // nil means the process environment
+	layout   *layout           // nil means the platform defaults
+	guard    func(key string)
+	fsys     FileSystem // nil means the OS filesystem
+	app      string     // application name of the App*Location standard locations
+	portable portable
+	sysroot  sysroot
+}
+
+// Option configures a Resolver.
+type Option func(*Resolver)
+
+// WithEnv makes the Resolver resolve from env instead of the process environment.
+// env is a list of "key=value" strings in the form returned by os.Environ.
+// The default base directories are based on the home directory of env, and the ones under the home directory are
+// empty if env does not set it.
+func WithEnv(env []string) Option {
+	return func(r *Resolver) {
+		r.env = make(map[string]string, len(env))
+		for _, kv := range env {
+			if i := strings.IndexByte(kv, '='); i > 0 {
+				r.env[kv[:i]] = kv[i+1:]
+			}
+		}
+	}
+}
+
+// New returns a new Resolver configured by opts.
+func New(opts ...Option) *Resolver {
+	r := new(Resolver)
+	for _, opt := range opts {
+		opt(r)
+	}
+	r.sysroot.activate(r)
+	r.portable.activate(r)
+	return r
+}
+
+// std is the Resolver used by the package level functions.
+var std = New()
+
+// Default returns the Resolver used by the package level functions.
+func Default() *Resolver {
+	return std
+}
+
+// SetDefault sets the Resolver used by the package level functions, so that the call sites of
+// the package level functions resolve through r without changes.
+//
+// SetDefault is not safe for concurrent use with the package level functions. It should be called
+// from an init function or at the beginning of main.
+func SetDefault(r *Resolver) {
+	std = r
+}
+
+// DataHome return the XDG_DATA_HOME based directory path resolved by r.
+func (r *Resolver) DataHome() string {
+	return r.resolve(EnvDataHome)
+}
+
+// ConfigHome return the XDG_CONFIG_HOME based directory path resolved by r.
+func (r *Resolver) ConfigHome() string {
+	return r.resolve(EnvConfigHome)
+}
+
+// StateHome return the XDG_STATE_HOME based directory path resolved by r.
+func (r *Resolver) StateHome() string {
+	return r.resolve(EnvStateHome)
+}
+
+// DataDirs return the XDG_DATA_DIRS based directory path resolved by r.
+func (r *Resolver) DataDirs() string {
+	return r.resolve(EnvDataDirs)
+}
+
+// ConfigDirs return the XDG_CONFIG_DIRS based directory path resolved by r.
+func (r *Resolver) ConfigDirs() string {
+	return r.resolve(EnvConfigDirs)
+}
+
+// CacheHome return the XDG_CACHE_HOME based directory path resolved by r.
+func (r *Resolver) CacheHome() string {
+	return r.resolve(EnvCacheHome)
+}
+
+// RuntimeDir return the XDG_RUNTIME_DIR based directory path resolved by r.
+func (r *Resolver) RuntimeDir() string {
+	return r.resolve(EnvRuntimeDir)
+}
+
+// Home returns the user home directory which r resolves the XDG base directories from, prefixed
+// with the root in sysroot mode.
+func (r *Resolver) Home() string {
+	var dir string
+	if r.layout != nil {
+		dir = r.layout.home
+	} else {
+		dir = r.getenv(homeEnv())
+		if dir == "" && r.env == nil {
+			dir = home.Dir()
+		}
+	}
+	if r.sysroot.root != "" && dir != "" {
+		dir = filepath.Join(r.sysroot.root, dir)
+	}
+	return dir
+}
+
+// resolve returns the key XDG base directory resolved by r.
+func (r *Resolver) resolve(key string) string {
+	if r.portable.root != "" {
+		return r.portableDir(key)
+	}
+	dir := r.getenv(key)
+	if dir != "" {
+		dir = r.expandUser(dir)
+	} else {
+		dir = r.defaultDir(key)
+	}
+	if r.sysroot.root != "" {
+		dir = r.sysroot.prefix(dir)
+	}
+	return dir
+}
+
+// profile returns the platform directory layout of r.
+func (r *Resolver) profile() Profile {
+	if r.layout != nil {
+		return r.layout.profile
+	}
+	return platformProfile()
+}
+
+// defaultDir returns the default of the key XDG base directory.
+func (r *Resolver) defaultDir(key string) string {
+	if r.layout != nil {
+		return r.layout.dir(key)
+	}
+	// the defaults of an explicit environment are based on its $HOME, so that they agree with Home. Without $HOME,
+	// the defaults under the home directory are empty rather than those of the process.
+	if r.env != nil {
+		l := layout{profile: platformProfile(), home: r.env[homeEnv()], uid: os.Getuid()}
+		dir := l.dir(key)
+		if l.home == "" && !filepath.IsAbs(dir) {
+			return ""
+		}
+		return dir
+	}
+	// the platform defaults are based on the process $HOME
+	r.fallback("HOME")
+	return defaultDir(key)
+}
+
+// defaultDir returns the platform default of the key XDG base directory.
+func defaultDir(key string) string {
+	switch key {
+	case EnvDataHome:
+		return dataHome()
+	case EnvConfigHome:
+		return configHome()
+	case EnvStateHome:
+		return stateHome()
+	case EnvDataDirs:
+		return dataDirs()
+	case EnvConfigDirs:
+		return configDirs()
+	case EnvCacheHome:
+		return cacheHome()
+	case EnvRuntimeDir:
+		return runtimeDir()
+	}
+	return ""
+}
+
+// Getenv retrieves the value of the environment variable named by the key from r's environment,
+// which the XDG base directories are resolved from. Packages which read other variables, such as
+// the locale, use it so that they honor WithEnv, WithHermetic and WithSysroot.
+func (r *Resolver) Getenv(key string) string {
+	return r.getenv(key)
+}
+
+// getenv retrieves the value of the environment variable named by the key from r's environment.
+func (r *Resolver) getenv(key string) string {
+	if r.env == nil {
+		r.fallback(key)
+		return os.Getenv(key)
+	}
+	return r.env[key]
+}
+
+// environ returns a copy of r's environment in the form "key=value".
+func (r *Resolver) environ() []string {
+	if r.env == nil {
+		r.fallback("*")
+		return os.Environ()
+	}
+	env := make([]string, 0, len(r.env))
+	for k, v := range r.env {
+		env = append(env, k+"="+v)
+	}
+	return env
+}
+
+// homeEnv returns the name of the environment variable of the user home directory.
+func homeEnv() string {
+	switch runtime.GOOS {
+	case "windows":
+		return "USERPROFILE"
+	case "plan9":
+		return "home"
+	}
+	return "HOME"
+}
+
+// expandUser expands shell's user home directory tilde expansion from s.
+func (r *Resolver) expandUser(s string) string {
+	if len(s) < 2 || s[0] != '~' || !os.IsPathSeparator(s[1]) {
+		return s
+	}
+
+	var home string
+	if r.layout != nil {
+		home = r.layout.home
+	} else {
+		home = r.getenv(homeEnv())
+	}
+	if home == "" {
+		return s
+	}
+
+	if runtime.GOOS == "windows" {
+		s = filepath.ToSlash(filepath.Join(home, s[2:]))
+	} else {
+		s = filepath.Join(home, s[2:])
+	}
+	return os.Expand(s, func(env string) string {
+		if env == "HOME" {
+			return home
+		}
+		return r.getenv(env)
+	})
+}
@@ -0,0 +1,380 @@
+// Copyright 2026 The go-xdgbasedir Authors. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package xdgbasedir
+
+import (
+	"errors"
+	"os"
+	"path/filepath"
+	"reflect"
+	"testing"
+)
+
+func TestResolver(t *testing.T) {
+	r := New(WithEnv([]string{
+		"HOME=/home/gopher",
+		EnvDataHome + "=/data",
+		EnvConfigHome + "=~/config",
+	}))
+
+	tests := []struct {
+		name string
+		fn   func() string
+		want string
+	}{
+		{name: "DataHome", fn: r.DataHome, want: "/data"},
+		{name: "ConfigHome", fn: r.ConfigHome, want: filepath.Join("/home", "gopher", "config")},
+		{name: "CacheHome", fn: r.CacheHome, want: gopherDir(EnvCacheHome)},
+		{name: "DataDirs", fn: r.DataDirs, want: gopherDir(EnvDataDirs)},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := tt.fn(); got != tt.want {
+				t.Errorf("%s() = %v, want %v", tt.name, got, tt.want)
+			}
+		})
+	}
+}
+
+func TestResolverWithoutHome(t *testing.T) {
+	t.Setenv("HOME", "/home/host")
+	r := New(WithEnv([]string{EnvDataHome + "=/data"}))
+
+	if got := r.Home(); got != "" {
+		t.Errorf("Home() = %v, want empty", got)
+	}
+	tests := []struct {
+		name string
+		fn   func() string
+		want string
+	}{
+		{name: "DataHome", fn: r.DataHome, want: "/data"},
+		{name: "ConfigHome", fn: r.ConfigHome, want: ""},
+		{name: "CacheHome", fn: r.CacheHome, want: ""},
+		{name: "StateHome", fn: r.StateHome, want: ""},
+	}
+	if platformProfile() == ProfileUnix {
+		tests = append(tests, struct {
+			name string
+			fn   func() string
+			want string
+		}{name: "ConfigDirs", fn: r.ConfigDirs, want: filepath.Join("/etc", "xdg")})
+	}
+	for _, tt := range tests {
+		if got := tt.fn(); got != tt.want {
+			t.Errorf("%s() = %v, want %v", tt.name, got, tt.want)
+		}
+	}
+}
+
+// gopherDir returns the platform default of the key base directory for the /home/gopher home directory.
+func gopherDir(key string) string {
+	l := layout{profile: platformProfile(), home: "/home/gopher", uid: os.Getuid()}
+	return l.dir(key)
+}
+
+func TestPortable(t *testing.T) {
+	root := filepath.Join(t.TempDir(), "portable")
+	env := []string{
+		EnvDataHome + "=/data",
+		"MYAPP_HOME=" + root,
+	}
+
+	tests := []struct {
+		name string
+		r    func(t *testing.T) (*Resolver, string)
+	}{
+		{
+			name: "not portable",
+			r: func(t *testing.T) (*Resolver, string) {
+				return New(WithEnv(env), WithPortableEnv("OTHERAPP_HOME")), ""
+			},
+		},
+		{
+			name: "root option",
+			r: func(t *testing.T) (*Resolver, string) {
+				return New(WithEnv(env), WithPortableRoot(root)), root
+			},
+		},
+		{
+			name: "env",
+			r: func(t *testing.T) (*Resolver, string) {
+				return New(WithPortableEnv("MYAPP_HOME"), WithEnv(env)), root
+			},
+		},
+		{
+			name: "marker",
+			r: func(t *testing.T) (*Resolver, string) {
+				exe, err := os.Executable()
+				if err != nil {
+					t.Fatal(err)
+				}
+				if resolved, err := filepath.EvalSymlinks(exe); err == nil {
+					exe = resolved
+				}
+				marker := filepath.Join(filepath.Dir(exe), "portable.txt")
+				if err := os.WriteFile(marker, nil, 0644); err != nil {
+					t.Skip(err)
+				}
+				t.Cleanup(func() { os.Remove(marker) })
+				return New(WithEnv(env), WithPortableMarker("portable.txt")), filepath.Dir(exe)
+			},
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			r, want := tt.r(t)
+
+			got, ok := r.Portable()
+			if got != want || ok != (want != "") {
+				t.Fatalf("Portable() = %q, %v, want %q", got, ok, want)
+			}
+			if !ok {
+				if got := r.DataHome(); got != "/data" {
+					t.Errorf("DataHome() = %v, want %v", got, "/data")
+				}
+				return
+			}
+
+			dirs := map[string]func() string{
+				PortableData:    r.DataHome,
+				PortableConfig:  r.ConfigHome,
+				PortableState:   r.StateHome,
+				PortableCache:   r.CacheHome,
+				PortableRuntime: r.RuntimeDir,
+			}
+			for dir, fn := range dirs {
+				if got := fn(); got != filepath.Join(want, dir) {
+					t.Errorf("got %v, want %v", got, filepath.Join(want, dir))
+				}
+			}
+			if got := r.DataDirs(); got != filepath.Join(want, PortableData) {
+				t.Errorf("DataDirs() = %v, want %v", got, filepath.Join(want, PortableData))
+			}
+		})
+	}
+}
+
+func TestSetDefault(t *testing.T) {
+	root := t.TempDir()
+	defer SetDefault(Default())
+	SetDefault(New(WithPortableRoot(root)))
+
+	if got, want := ConfigHome(), filepath.Join(root, PortableConfig); got != want {
+		t.Errorf("ConfigHome() = %v, want %v", got, want)
+	}
+}
+
+func TestSysroot(t *testing.T) {
+	root := t.TempDir()
+	files := map[string]string{
+		filepath.Join("etc", "passwd"):                              "root:x:0:0:root:/root:/bin/sh\n# comment\ngopher:x:1000:1000::/home/gopher:/bin/sh\n",
+		filepath.Join("etc", "xdg", "app", "app.conf"):              "system",
+		filepath.Join("home", "gopher", ".config", "app", "a.conf"): "user",
+		filepath.Join("usr", "share", "app", "data"):                "data",
+	}
+	for name, data := range files {
+		path := filepath.Join(root, name)
+		if err := os.MkdirAll(filepath.Dir(path), 0755); err != nil {
+			t.Fatal(err)
+		}
+		if err := os.WriteFile(path, []byte(data), 0644); err != nil {
+			t.Fatal(err)
+		}
+	}
+	// absolute symlink must be resolved inside root
+	if err := os.Symlink("/usr/share/app", filepath.Join(root, "home", "gopher", ".config", "app", "link")); err != nil {
+		t.Skip(err)
+	}
+
+	t.Setenv(EnvConfigHome, filepath.Join("/host", "config"))
+
+	t.Run("user", func(t *testing.T) {
+		r := New(WithSysroot(root), WithSysrootUser("gopher"))
+		if got, ok := r.Sysroot(); !ok || got != root {
+			t.Fatalf("Sysroot() = %q, %v, want %q", got, ok, root)
+		}
+
+		tests := []struct {
+			name string
+			fn   func() string
+			want string
+		}{
+			{name: "ConfigHome", fn: r.ConfigHome, want: filepath.Join(root, "home", "gopher", ".config")},
+			{name: "DataHome", fn: r.DataHome, want: filepath.Join(root, "home", "gopher", ".local", "share")},
+			{name: "ConfigDirs", fn: r.ConfigDirs, want: filepath.Join(root, "etc", "xdg")},
+			{name: "DataDirs", fn: r.DataDirs, want: list(filepath.Join(root, "usr", "local", "share"), filepath.Join(root, "usr", "share"))},
+			{name: "RuntimeDir", fn: r.RuntimeDir, want: filepath.Join(root, "run", "user", "1000")},
+		}
+		for _, tt := range tests {
+			if got := tt.fn(); got != tt.want {
+				t.Errorf("%s() = %v, want %v", tt.name, got, tt.want)
+			}
+		}
+
+		if got, err := r.FindConfig(filepath.Join("app", "app.conf")); err != nil || got != filepath.Join(root, "etc", "xdg", "app", "app.conf") {
+			t.Errorf("FindConfig() = %v, %v", got, err)
+		}
+		if got, err := r.FindData(filepath.Join("app", "missing")); !errors.Is(err, os.ErrNotExist) {
+			t.Errorf("FindData() = %v, %v, want ErrNotExist", got, err)
+		}
+		if !r.Exists(filepath.Join(r.ConfigHome(), "app", "link", "data")) {
+			t.Error("Exists() = false, want symlink resolved inside sysroot")
+		}
+		link := filepath.Join(r.ConfigHome(), "app", "link")
+		if data, err := r.ReadFile(filepath.Join(link, "data")); err != nil || string(data) != "data" {
+			t.Errorf("ReadFile() = %q, %v, want symlink resolved inside sysroot", data, err)
+		}
+		if entries, err := r.ReadDir(link); err != nil || len(entries) != 1 {
+			t.Errorf("ReadDir() = %v, %v, want symlink resolved inside sysroot", entries, err)
+		}
+		if got, err := r.EvalSymlinks(link); err != nil || got != filepath.Join(root, "usr", "share", "app") {
+			t.Errorf("EvalSymlinks() = %v, %v", got, err)
+		}
+	})
+
+	t.Run("default root user", func(t *testing.T) {
+		r := New(WithSysroot(root))
+		if got, want := r.ConfigHome(), filepath.Join(root, "root", ".config"); got != want {
+			t.Errorf("ConfigHome() = %v, want %v", got, want)
+		}
+	})
+
+	t.Run("unknown user", func(t *testing.T) {
+		r := New(WithSysroot(root), WithSysrootUser("nobody"))
+		if got, want := r.ConfigHome(), filepath.Join(root, "home", "nobody", ".config"); got != want {
+			t.Errorf("ConfigHome() = %v, want %v", got, want)
+		}
+		if got := r.RuntimeDir(); got != "" {
+			t.Errorf("RuntimeDir() = %v, want empty for the unknown uid", got)
+		}
+	})
+
+	t.Run("unknown uid", func(t *testing.T) {
+		r := New(WithSysroot(root), WithSysrootUser("1001"))
+		if got, want := r.RuntimeDir(), filepath.Join(root, "run", "user", "1001"); got != want {
+			t.Errorf("RuntimeDir() = %v, want %v", got, want)
+		}
+	})
+
+	t.Run("host env", func(t *testing.T) {
+		r := New(WithSysroot(root), WithHostEnv())
+		if got, want := r.ConfigHome(), filepath.Join(root, "host", "config"); got != want {
+			t.Errorf("ConfigHome() = %v, want %v", got, want)
+		}
+	})
+}
+
+func TestConfigPaths(t *testing.T) {
+	root := t.TempDir()
+	home := filepath.Join(root, "home")
+	dirs := []string{filepath.Join(root, "etc1"), filepath.Join(root, "etc2")}
+	for _, dir := range append([]string{home}, dirs...) {
+		if dir == dirs[0] {
+			continue
+		}
+		if err := os.MkdirAll(filepath.Join(dir, "app"), 0755); err != nil {
+			t.Fatal(err)
+		}
+		if err := os.WriteFile(filepath.Join(dir, "app", "app.conf"), nil, 0644); err != nil {
+			t.Fatal(err)
+		}
+	}
+
+	r := New(WithEnv([]string{
+		EnvConfigHome + "=" + home,
+		EnvConfigDirs + "=" + list(dirs...),
+	}))
+	got := r.ConfigPaths(filepath.Join("app", "app.conf"))
+	want := []string{filepath.Join(home, "app", "app.conf"), filepath.Join(dirs[1], "app", "app.conf")}
+	if !reflect.DeepEqual(got, want) {
+		t.Errorf("ConfigPaths() = %v, want %v", got, want)
+	}
+}
+
+func TestHermetic(t *testing.T) {
+	t.Setenv(EnvConfigHome, filepath.Join("/host", "config"))
+
+	home := filepath.Join("/home", "gopher")
+	tests := []struct {
+		name    string
+		profile Profile
+		env     []string
+		want    map[string]string
+	}{
+		{
+			name:    "unix",
+			profile: ProfileUnix,
+			want: map[string]string{
+				EnvConfigHome: filepath.Join(home, ".config"),
+				EnvDataDirs:   list(filepath.Join("/usr", "local", "share"), filepath.Join("/usr", "share")),
+				EnvRuntimeDir: filepath.Join("/run", "user", "1000"),
+			},
+		},
+		{
+			name:    "darwin",
+			profile: ProfileDarwin,
+			want: map[string]string{
+				EnvConfigHome: filepath.Join(home, "Library", "Preferences"),
+				EnvCacheHome:  filepath.Join(home, "Library", "Caches"),
+				EnvStateHome:  filepath.Join(home, "Library", "Application Support"),
+			},
+		},
+		{
+			name:    "windows",
+			profile: ProfileWindows,
+			want: map[string]string{
+				EnvConfigHome: filepath.Join(home, "AppData", "Roaming"),
+				EnvCacheHome:  filepath.Join(home, "AppData", "Local", "cache"),
+			},
+		},
+		{
+			name:    "explicit env",
+			profile: ProfileUnix,
+			env:     []string{EnvConfigHome + "=~/cfg"},
+			want: map[string]string{
+				EnvConfigHome: filepath.Join(home, "cfg"),
+				EnvDataHome:   filepath.Join(home, ".local", "share"),
+			},
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			r := New(
+				WithHermetic(Hermetic{Home: home, UID: 1000, Profile: tt.profile, Env: tt.env}),
+				WithEnvGuard(func(key string) { t.Errorf("read process env %s", key) }),
+			)
+			for key, want := range tt.want {
+				if got := r.resolve(key); got != want {
+					t.Errorf("%s = %v, want %v", key, got, want)
+				}
+			}
+			if got := r.Home(); got != home {
+				t.Errorf("Home() = %v, want %v", got, home)
+			}
+			r.Environ().Pin().Environ()
+		})
+	}
+}
+
+func TestEnvGuard(t *testing.T) {
+	var keys []string
+	r := New(WithEnvGuard(func(key string) { keys = append(keys, key) }))
+	r.ConfigHome()
+	if len(keys) == 0 || keys[0] != EnvConfigHome {
+		t.Errorf("guard called with %v, want %v first", keys, EnvConfigHome)
+	}
+
+	defer func() {
+		err, ok := recover().(*ProcessEnvError)
+		if !ok {
+			t.Fatalf("recover() = %v, want *ProcessEnvError", err)
+		}
+		if err.Key != "*" {
+			t.Errorf("Key = %q, want %q", err.Key, "*")
+		}
+	}()
+	New(WithEnvGuard(PanicOnProcessEnv)).Environ().Environ()
+}
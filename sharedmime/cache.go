@@ -0,0 +1,400 @@
+// Copyright 2026 The go-xdgbasedir Authors. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package sharedmime
+
+import (
+	"bytes"
+	"encoding/binary"
+	"errors"
+	"fmt"
+	"path"
+	"path/filepath"
+	"sort"
+	"strings"
+
+	"github.com/zchee/go-xdgbasedir"
+)
+
+// List of the mime.cache versions supported by ParseCache.
+const (
+	CacheMajorVersion = 1
+	CacheMinorVersion = 2
+)
+
+// cacheHeaderSize is the size of the mime.cache header, the version and the offsets of the lists.
+const cacheHeaderSize = 40
+
+// List of the flags in the weight field of the glob entries of mime.cache.
+const (
+	cacheWeightMask    = 0xff
+	cacheCaseSensitive = 0x100
+)
+
+// ErrStaleCache is returned by LoadCache if a package file is newer than the mime.cache.
+var ErrStaleCache = errors.New("sharedmime: mime.cache is stale")
+
+// Cache is a mime.cache file written by update-mime-database, a binary form of the text files of a mime
+// directory. All the numbers are big-endian, and the lookups read the file data in place without decoding it.
+type Cache struct {
+	data []byte
+
+	aliasList        uint32
+	parentList       uint32
+	literalList      uint32
+	suffixTree       uint32
+	globList         uint32
+	magicList        uint32
+	namespaceList    uint32
+	iconsList        uint32
+	genericIconsList uint32
+}
+
+// ParseCache parses the mime.cache file data. The data is retained and must not be modified.
+func ParseCache(data []byte) (*Cache, error) {
+	if len(data) < cacheHeaderSize {
+		return nil, errors.New("sharedmime: mime.cache is truncated")
+	}
+	major, minor := binary.BigEndian.Uint16(data), binary.BigEndian.Uint16(data[2:])
+	if major != CacheMajorVersion || minor < CacheMinorVersion {
+		return nil, fmt.Errorf("sharedmime: unsupported mime.cache version %d.%d", major, minor)
+	}
+	c := &Cache{data: data}
+	for i, off := range []*uint32{
+		&c.aliasList, &c.parentList, &c.literalList, &c.suffixTree, &c.globList,
+		&c.magicList, &c.namespaceList, &c.iconsList, &c.genericIconsList,
+	} {
+		*off = binary.BigEndian.Uint32(data[4+4*i:])
+		if int(*off) > len(data)-4 {
+			return nil, fmt.Errorf("sharedmime: mime.cache list offset %d is out of range", *off)
+		}
+	}
+	return c, nil
+}
+
+// LoadCache loads the mime.cache of the mime directory dir. It returns ErrStaleCache if any package file in the
+// packages subdirectory, or the directory itself, is newer than the cache.
+func LoadCache(fsys xdgbasedir.FileSystem, dir string) (*Cache, error) {
+	name := filepath.Join(dir, CacheFile)
+	fi, err := fsys.Stat(name)
+	if err != nil {
+		return nil, err
+	}
+	if stale(fsys, dir, fi.ModTime().UnixNano()) {
+		return nil, fmt.Errorf("%s: %w", name, ErrStaleCache)
+	}
+	data, err := fsys.ReadFile(name)
+	if err != nil {
+		return nil, err
+	}
+	c, err := ParseCache(data)
+	if err != nil {
+		return nil, fmt.Errorf("%s: %w", name, err)
+	}
+	return c, nil
+}
+
+// stale reports whether the packages directory of the mime directory dir or any package file in it is modified
+// after mtime.
+func stale(fsys xdgbasedir.FileSystem, dir string, mtime int64) bool {
+	pkgs := filepath.Join(dir, PackagesDir)
+	fi, err := fsys.Stat(pkgs)
+	if err != nil {
+		return false
+	}
+	if fi.ModTime().UnixNano() > mtime {
+		return true
+	}
+	entries, err := fsys.ReadDir(pkgs)
+	if err != nil {
+		return false
+	}
+	for _, de := range entries {
+		if !strings.HasSuffix(de.Name(), ".xml") {
+			continue
+		}
+		if fi, err := fsys.Stat(filepath.Join(pkgs, de.Name())); err == nil && fi.ModTime().UnixNano() > mtime {
+			return true
+		}
+	}
+	return false
+}
+
+// u32 returns the number at the offset, or 0 if it is out of range.
+func (c *Cache) u32(off uint32) uint32 {
+	if uint64(off)+4 > uint64(len(c.data)) {
+		return 0
+	}
+	return binary.BigEndian.Uint32(c.data[off:])
+}
+
+// str returns the nul-terminated string at the offset, or an empty string if it is out of range.
+func (c *Cache) str(off uint32) string {
+	if int(off) >= len(c.data) {
+		return ""
+	}
+	b := c.data[off:]
+	if i := bytes.IndexByte(b, 0); i >= 0 {
+		return string(b[:i])
+	}
+	return ""
+}
+
+// count returns n, the number of the entries of size bytes at the offset start, clamped to the entries which fit
+// in the cache, so that a corrupted count cannot force a large allocation or a long loop.
+func (c *Cache) count(n uint32, start, size uint64) uint32 {
+	if start >= uint64(len(c.data)) {
+		return 0
+	}
+	if max := (uint64(len(c.data)) - start) / size; uint64(n) > max {
+		return uint32(max)
+	}
+	return n
+}
+
+// lookupPair searches the sorted list of pairs of string offsets at the offset list for key, and returns the
+// offset of its value.
+func (c *Cache) lookupPair(list uint32, key string) (uint32, bool) {
+	n := int(c.count(c.u32(list), uint64(list)+4, 8))
+	entry := func(i int) uint32 { return list + 4 + 8*uint32(i) }
+	i := sort.Search(n, func(i int) bool { return c.str(c.u32(entry(i))) >= key })
+	if i < n && c.str(c.u32(entry(i))) == key {
+		return c.u32(entry(i) + 4), true
+	}
+	return 0, false
+}
+
+func (c *Cache) alias(mimeType string) (string, bool) {
+	off, ok := c.lookupPair(c.aliasList, mimeType)
+	if !ok {
+		return "", false
+	}
+	return c.str(off), true
+}
+
+func (c *Cache) parents(mimeType string) ([]string, bool) {
+	off, ok := c.lookupPair(c.parentList, mimeType)
+	if !ok {
+		return nil, false
+	}
+	n := c.count(c.u32(off), uint64(off)+4, 4)
+	parents := make([]string, 0, n)
+	for i := uint32(0); i < n; i++ {
+		parents = append(parents, c.str(c.u32(off+4+4*i)))
+	}
+	return parents, true
+}
+
+func (c *Cache) icon(mimeType string) (string, bool) {
+	off, ok := c.lookupPair(c.iconsList, mimeType)
+	if !ok {
+		return "", false
+	}
+	return c.str(off), true
+}
+
+func (c *Cache) genericIcon(mimeType string) (string, bool) {
+	off, ok := c.lookupPair(c.genericIconsList, mimeType)
+	if !ok {
+		return "", false
+	}
+	return c.str(off), true
+}
+
+// Alias returns the canonical MIME type of the alias.
+func (c *Cache) Alias(alias string) (string, bool) {
+	return c.alias(strings.ToLower(alias))
+}
+
+// Parents returns the parents of the MIME type, and whether it is listed in the parent list.
+func (c *Cache) Parents(mimeType string) ([]string, bool) {
+	return c.parents(mimeType)
+}
+
+// globEntries calls fn for each entry of the literal or glob list at the offset list.
+func (c *Cache) globEntries(list uint32, fn func(pattern, mimeType string, weight uint32)) {
+	n := c.count(c.u32(list), uint64(list)+4, 12)
+	for i := uint32(0); i < n; i++ {
+		e := list + 4 + 12*i
+		fn(c.str(c.u32(e)), c.str(c.u32(e+4)), c.u32(e+8))
+	}
+}
+
+func (c *Cache) matchGlobs(name string, fold bool, fn func(mimeType string, weight, length int)) {
+	match := func(pattern, mimeType string, weight uint32, glob bool) {
+		if pattern == noGlobsPattern {
+			return
+		}
+		n := name
+		if fold {
+			if weight&cacheCaseSensitive != 0 {
+				return
+			}
+			pattern, n = strings.ToLower(pattern), strings.ToLower(name)
+		}
+		ok := pattern == n
+		if glob {
+			ok, _ = path.Match(pattern, n)
+		}
+		if !ok {
+			return
+		}
+		fn(mimeType, int(weight&cacheWeightMask), len(pattern))
+	}
+	c.globEntries(c.literalList, func(pattern, mimeType string, weight uint32) {
+		match(pattern, mimeType, weight, false)
+	})
+
+	n := name
+	if fold {
+		n = strings.ToLower(name)
+	}
+	runes := []rune(n)
+	c.matchSuffix(runes, len(runes)-1, c.u32(c.suffixTree), c.u32(c.suffixTree+4), fold, fn)
+
+	c.globEntries(c.globList, func(pattern, mimeType string, weight uint32) {
+		match(pattern, mimeType, weight, true)
+	})
+}
+
+// matchSuffix walks the reverse suffix tree nodes, n nodes at the offset off, with the character of name at i
+// and the preceding ones, and calls fn for each leaf reached, which means the suffix name[i:] is a "*" pattern.
+func (c *Cache) matchSuffix(name []rune, i int, n, off uint32, fold bool, fn func(mimeType string, weight, length int)) {
+	if i < 0 {
+		return
+	}
+	// the nodes are sorted by character, and the leaves of character 0 come first
+	node := func(j int) uint32 { return off + 12*uint32(j) }
+	ch := uint32(name[i])
+	n = c.count(n, uint64(off), 12)
+	j := sort.Search(int(n), func(j int) bool { return c.u32(node(j)) >= ch })
+	if j >= int(n) || c.u32(node(j)) != ch {
+		return
+	}
+	childOff := c.u32(node(j) + 8)
+	childN := c.count(c.u32(node(j)+4), uint64(childOff), 12)
+	for k := uint32(0); k < childN; k++ {
+		leaf := childOff + 12*k
+		if c.u32(leaf) != 0 {
+			break
+		}
+		weight := c.u32(leaf + 8)
+		if fold && weight&cacheCaseSensitive != 0 {
+			continue
+		}
+		fn(c.str(c.u32(leaf+4)), int(weight&cacheWeightMask), len(name)-i+1)
+	}
+	c.matchSuffix(name, i-1, childN, childOff, fold, fn)
+}
+
+func (c *Cache) noGlobs(mimeType string) bool {
+	found := false
+	c.globEntries(c.literalList, func(pattern, t string, _ uint32) {
+		found = found || pattern == noGlobsPattern && t == mimeType
+	})
+	return found
+}
+
+// MatchName returns the MIME types of the globs matching the base name of the file name, like
+// Database.MatchName.
+func (c *Cache) MatchName(name string) []string {
+	return (&Database{sources: []source{c}}).MatchName(name)
+}
+
+// magicMatches calls fn for each magic match, with its priority, MIME type and matchlets, in priority order.
+func (c *Cache) magicMatches(fn func(priority int, mimeType string, n, off uint32) bool) {
+	off := c.u32(c.magicList + 8)
+	n := c.count(c.u32(c.magicList), uint64(off), 16)
+	for i := uint32(0); i < n; i++ {
+		m := off + 16*i
+		if !fn(int(c.u32(m)), c.str(c.u32(m+4)), c.u32(m+8), c.u32(m+12)) {
+			return
+		}
+	}
+}
+
+// maxMatchletDepth is the maximum nesting depth of the matchlets, which bounds the recursion of a corrupted cache
+// whose matchlet is a child of itself.
+const maxMatchletDepth = 64
+
+// matchlets reports whether data matches any of the n matchlets at the offset, nested at depth.
+func (c *Cache) matchlets(data []byte, n, off uint32, depth int) bool {
+	if depth > maxMatchletDepth {
+		return false
+	}
+	n = c.count(n, uint64(off), 32)
+	for i := uint32(0); i < n; i++ {
+		m := off + 32*i
+		start, rangeLen := int(c.u32(m)), int(c.u32(m+4))
+		valueLen, value, mask := c.u32(m+12), c.u32(m+16), c.u32(m+20)
+		if uint64(value)+uint64(valueLen) > uint64(len(c.data)) || (mask != 0 && uint64(mask)+uint64(valueLen) > uint64(len(c.data))) {
+			continue
+		}
+		r := magicRule{value: c.data[value : value+valueLen]}
+		if mask != 0 {
+			r.mask = c.data[mask : mask+valueLen]
+		}
+		if wordSize := int(c.u32(m + 8)); littleEndian && wordSize > 1 {
+			// the cache is read in place, so the big-endian words are swapped on copies as the magic file does
+			r.value = append([]byte(nil), r.value...)
+			r.mask = append([]byte(nil), r.mask...)
+			swap(r.value, wordSize)
+			swap(r.mask, wordSize)
+		}
+		for pos := start; pos < start+rangeLen; pos++ {
+			if pos+int(valueLen) > len(data) {
+				break
+			}
+			if !r.matchAt(data[pos:]) {
+				continue
+			}
+			if childN := c.u32(m + 24); childN == 0 || c.matchlets(data, childN, c.u32(m+28), depth+1) {
+				return true
+			}
+		}
+	}
+	return false
+}
+
+// isNoMagic reports whether the match of n matchlets at the offset discards the magic of its MIME type in the
+// lower precedence directories.
+func (c *Cache) isNoMagic(n, off uint32) bool {
+	value, valueLen := c.u32(off+16), c.u32(off+12)
+	return n == 1 && valueLen == uint32(len(noMagicValue)) && c.str(value) == noMagicValue
+}
+
+func (c *Cache) matchMagic(data []byte, skip func(mimeType string) bool) (string, int) {
+	var (
+		mimeType string
+		priority int
+	)
+	c.magicMatches(func(prio int, t string, n, off uint32) bool {
+		if !skip(t) && !c.isNoMagic(n, off) && c.matchlets(data, n, off, 0) {
+			mimeType, priority = t, prio
+			return false
+		}
+		return true
+	})
+	return mimeType, priority
+}
+
+func (c *Cache) noMagic(mimeType string) bool {
+	found := false
+	c.magicMatches(func(_ int, t string, n, off uint32) bool {
+		found = t == mimeType && c.isNoMagic(n, off)
+		return !found
+	})
+	return found
+}
+
+func (c *Cache) magicSize() int {
+	return int(c.u32(c.magicList + 4))
+}
+
+// MatchContent returns the MIME type of the highest priority magic match which data matches, or an empty string
+// if none matches.
+func (c *Cache) MatchContent(data []byte) string {
+	mimeType, _ := c.matchMagic(data, func(string) bool { return false })
+	return mimeType
+}
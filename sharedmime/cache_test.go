@@ -0,0 +1,379 @@
+// Copyright 2026 The go-xdgbasedir Authors. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package sharedmime_test
+
+import (
+	"encoding/binary"
+	"errors"
+	"os"
+	"reflect"
+	"sort"
+	"testing"
+	"time"
+
+	"github.com/zchee/go-xdgbasedir/sharedmime"
+	"github.com/zchee/go-xdgbasedir/xdgtest"
+)
+
+// cacheBuilder builds a mime.cache for the tests.
+type cacheBuilder struct {
+	buf []byte
+}
+
+func (b *cacheBuilder) put32(at int, v uint32) { binary.BigEndian.PutUint32(b.buf[at:], v) }
+
+// reserve appends n zero bytes and returns their offset.
+func (b *cacheBuilder) reserve(n int) int {
+	off := len(b.buf)
+	b.buf = append(b.buf, make([]byte, n)...)
+	return off
+}
+
+// str appends the nul-terminated string padded to 4 bytes and returns its offset.
+func (b *cacheBuilder) str(s string) uint32 {
+	off := len(b.buf)
+	b.buf = append(b.buf, s...)
+	b.buf = append(b.buf, 0)
+	for len(b.buf)%4 != 0 {
+		b.buf = append(b.buf, 0)
+	}
+	return uint32(off)
+}
+
+func (b *cacheBuilder) pairs(pairs [][2]string) uint32 {
+	off := b.reserve(4 + 8*len(pairs))
+	b.put32(off, uint32(len(pairs)))
+	for i, p := range pairs {
+		b.put32(off+4+8*i, b.str(p[0]))
+		b.put32(off+8+8*i, b.str(p[1]))
+	}
+	return uint32(off)
+}
+
+type cacheGlob struct {
+	pattern, mimeType string
+	weight            uint32
+}
+
+func (b *cacheBuilder) globs(globs []cacheGlob) uint32 {
+	off := b.reserve(4 + 12*len(globs))
+	b.put32(off, uint32(len(globs)))
+	for i, g := range globs {
+		b.put32(off+4+12*i, b.str(g.pattern))
+		b.put32(off+8+12*i, b.str(g.mimeType))
+		b.put32(off+12+12*i, g.weight)
+	}
+	return uint32(off)
+}
+
+type suffixNode struct {
+	leaves   []cacheGlob
+	children map[rune]*suffixNode
+}
+
+// nodes writes the nodes of the children of n and returns their count and offset.
+func (b *cacheBuilder) nodes(n *suffixNode) (uint32, uint32) {
+	var chars []rune
+	for c := range n.children {
+		chars = append(chars, c)
+	}
+	sort.Slice(chars, func(i, j int) bool { return chars[i] < chars[j] })
+	count := len(n.leaves) + len(chars)
+	off := b.reserve(12 * count)
+	for i, leaf := range n.leaves {
+		b.put32(off+12*i+4, b.str(leaf.mimeType))
+		b.put32(off+12*i+8, leaf.weight)
+	}
+	for i, c := range chars {
+		at := off + 12*(len(n.leaves)+i)
+		childN, childOff := b.nodes(n.children[c])
+		b.put32(at, uint32(c))
+		b.put32(at+4, childN)
+		b.put32(at+8, childOff)
+	}
+	return uint32(count), uint32(off)
+}
+
+func (b *cacheBuilder) suffixTree(globs []cacheGlob) uint32 {
+	root := &suffixNode{children: map[rune]*suffixNode{}}
+	for _, g := range globs {
+		n := root
+		runes := []rune(g.pattern[1:])
+		for i := len(runes) - 1; i >= 0; i-- {
+			child, ok := n.children[runes[i]]
+			if !ok {
+				child = &suffixNode{children: map[rune]*suffixNode{}}
+				n.children[runes[i]] = child
+			}
+			n = child
+		}
+		n.leaves = append(n.leaves, g)
+	}
+	off := b.reserve(8)
+	n, first := b.nodes(root)
+	b.put32(off, n)
+	b.put32(off+4, first)
+	return uint32(off)
+}
+
+type matchlet struct {
+	start, rangeLen uint32
+	value, mask     string
+	children        []matchlet
+}
+
+func (b *cacheBuilder) matchlets(ms []matchlet) (uint32, uint32) {
+	off := b.reserve(32 * len(ms))
+	for i, m := range ms {
+		at := off + 32*i
+		b.put32(at, m.start)
+		b.put32(at+4, m.rangeLen)
+		b.put32(at+8, 1)
+		b.put32(at+12, uint32(len(m.value)))
+		b.put32(at+16, b.str(m.value))
+		if m.mask != "" {
+			b.put32(at+20, b.str(m.mask))
+		}
+		n, first := b.matchlets(m.children)
+		b.put32(at+24, n)
+		b.put32(at+28, first)
+	}
+	return uint32(len(ms)), uint32(off)
+}
+
+type magicMatch struct {
+	priority  uint32
+	mimeType  string
+	matchlets []matchlet
+}
+
+func (b *cacheBuilder) magic(maxExtent uint32, matches []magicMatch) uint32 {
+	off := b.reserve(12)
+	first := b.reserve(16 * len(matches))
+	b.put32(off, uint32(len(matches)))
+	b.put32(off+4, maxExtent)
+	b.put32(off+8, uint32(first))
+	for i, m := range matches {
+		at := first + 16*i
+		b.put32(at, m.priority)
+		b.put32(at+4, b.str(m.mimeType))
+		n, mOff := b.matchlets(m.matchlets)
+		b.put32(at+8, n)
+		b.put32(at+12, mOff)
+	}
+	return uint32(off)
+}
+
+func testCache() []byte {
+	b := &cacheBuilder{}
+	b.reserve(40)
+	binary.BigEndian.PutUint16(b.buf, sharedmime.CacheMajorVersion)
+	binary.BigEndian.PutUint16(b.buf[2:], sharedmime.CacheMinorVersion)
+
+	aliases := b.pairs([][2]string{{"application/x-gzip", "application/gzip"}, {"text/x-markdown", "text/markdown"}})
+
+	parentsOff := b.reserve(4 + 8)
+	b.put32(parentsOff, 1)
+	b.put32(parentsOff+4, b.str("text/markdown"))
+	list := b.reserve(8)
+	b.put32(list, 1)
+	b.put32(list+4, b.str("text/plain"))
+	b.put32(parentsOff+8, uint32(list))
+
+	literals := b.globs([]cacheGlob{
+		{"README", "text/x-readme", 50},
+		{"__NOGLOBS__", "application/x-deleted", 50},
+	})
+	suffixes := b.suffixTree([]cacheGlob{
+		{"*.C", "text/x-c++src", 50 | 0x100},
+		{"*.c", "text/x-csrc", 50},
+		{"*.gz", "application/gzip", 50},
+		{"*.md", "text/markdown", 50},
+		{"*.tar.gz", "application/x-compressed-tar", 55},
+		{"*.ñ", "text/x-enye", 50},
+	})
+	globs := b.globs([]cacheGlob{{"file-?.dat", "application/x-dat", 50}})
+	magic := b.magic(256, []magicMatch{
+		{80, "application/x-ambiguous-b", []matchlet{{start: 0, rangeLen: 1, value: "AMB", children: []matchlet{{start: 8, rangeLen: 4, value: "B"}}}}},
+		{60, "application/x-masked", []matchlet{{start: 0, rangeLen: 1, value: "\xf0\x00", mask: "\xf0\x0f"}}},
+		{50, "image/png", []matchlet{{start: 0, rangeLen: 1, value: "\x89PNG"}}},
+		{50, "application/x-deleted", []matchlet{{start: 0, rangeLen: 1, value: "__NOMAGIC__"}}},
+	})
+	namespaces := b.reserve(4)
+	icons := b.pairs([][2]string{{"text/markdown", "text-markdown-custom"}})
+	genericIcons := b.pairs([][2]string{{"application/gzip", "package-x-generic"}})
+
+	for i, off := range []uint32{aliases, uint32(parentsOff), literals, suffixes, globs, magic, uint32(namespaces), icons, genericIcons} {
+		b.put32(4+4*i, off)
+	}
+	return b.buf
+}
+
+func TestParseCache(t *testing.T) {
+	t.Parallel()
+
+	c, err := sharedmime.ParseCache(testCache())
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	if got, ok := c.Alias("Application/X-Gzip"); !ok || got != "application/gzip" {
+		t.Errorf("Alias() = %q, %v", got, ok)
+	}
+	if _, ok := c.Alias("application/gzip"); ok {
+		t.Error("Alias(canonical) found")
+	}
+	if got, ok := c.Parents("text/markdown"); !ok || !reflect.DeepEqual(got, []string{"text/plain"}) {
+		t.Errorf("Parents() = %q, %v", got, ok)
+	}
+
+	tests := []struct {
+		name string
+		want []string
+	}{
+		{name: "README", want: []string{"text/x-readme"}},
+		{name: "readme", want: []string{"text/x-readme"}},
+		{name: "notes.md", want: []string{"text/markdown"}},
+		{name: "NOTES.MD", want: []string{"text/markdown"}},
+		{name: "main.C", want: []string{"text/x-c++src"}},
+		{name: "main.c", want: []string{"text/x-csrc"}},
+		{name: "src.tar.gz", want: []string{"application/x-compressed-tar"}},
+		{name: "file.gz", want: []string{"application/gzip"}},
+		{name: "mañana.ñ", want: []string{"text/x-enye"}},
+		{name: "file-1.dat", want: []string{"application/x-dat"}},
+		{name: "gz", want: nil},
+		{name: "unknown", want: nil},
+	}
+	for _, tt := range tests {
+		if got := c.MatchName(tt.name); !reflect.DeepEqual(got, tt.want) {
+			t.Errorf("MatchName(%q) = %q, want %q", tt.name, got, tt.want)
+		}
+	}
+
+	for data, want := range map[string]string{
+		"\x89PNG\r\n": "image/png",
+		"AMB.......B": "application/x-ambiguous-b",
+		"AMB":         "",
+		"\xf7\x30":    "application/x-masked",
+		"__NOMAGIC__": "",
+	} {
+		if got := c.MatchContent([]byte(data)); got != want {
+			t.Errorf("MatchContent(%q) = %q, want %q", data, got, want)
+		}
+	}
+
+	outOfRange := append([]byte{0, 1, 0, 2, 0, 0, 0x10, 0}, make([]byte, 32)...)
+	for _, data := range [][]byte{nil, make([]byte, 40), outOfRange} {
+		if _, err := sharedmime.ParseCache(data); err == nil {
+			t.Errorf("ParseCache(%q) succeeded", data)
+		}
+	}
+}
+
+func TestCorruptedCache(t *testing.T) {
+	t.Parallel()
+
+	b := &cacheBuilder{}
+	b.reserve(40)
+	binary.BigEndian.PutUint16(b.buf, sharedmime.CacheMajorVersion)
+	binary.BigEndian.PutUint16(b.buf[2:], sharedmime.CacheMinorVersion)
+
+	// the parent list of text/markdown claims 0xffffffff parents
+	parentsOff := b.reserve(4 + 8)
+	b.put32(parentsOff, 1)
+	b.put32(parentsOff+4, b.str("text/markdown"))
+	list := b.reserve(8)
+	b.put32(list, 0xffffffff)
+	b.put32(list+4, b.str("text/plain"))
+	b.put32(parentsOff+8, uint32(list))
+
+	// the matchlet of image/png is its own child
+	magic := b.magic(256, []magicMatch{{50, "image/png", []matchlet{{start: 0, rangeLen: 1, value: "\x89PNG"}}}})
+	first := binary.BigEndian.Uint32(b.buf[magic+8:])
+	m := int(binary.BigEndian.Uint32(b.buf[first+12:]))
+	b.put32(m+24, 1)
+	b.put32(m+28, uint32(m))
+	// the magic list claims 0xffffffff matches
+	b.put32(int(magic), 0xffffffff)
+
+	empty := uint32(b.reserve(4))
+	for i, off := range []uint32{empty, uint32(parentsOff), empty, empty, empty, magic, empty, empty, empty} {
+		b.put32(4+4*i, off)
+	}
+
+	c, err := sharedmime.ParseCache(b.buf)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if got, ok := c.Parents("text/markdown"); !ok || len(got) > len(b.buf)/4 || got[0] != "text/plain" {
+		t.Errorf("Parents() = %d parents, %v, want them clamped to the cache size", len(got), ok)
+	}
+	if got := c.MatchContent([]byte("\x89PNG")); got != "" {
+		t.Errorf("MatchContent() = %q, want no match of the endless matchlet", got)
+	}
+}
+
+func TestLoadCache(t *testing.T) {
+	t.Parallel()
+
+	tr := xdgtest.New(t, xdgtest.WithFiles(map[string]string{
+		"usr/local/share/mime/mime.cache":          string(testCache()),
+		"usr/local/share/mime/globs2":              "50:text/x-from-text:*.md\n",
+		"usr/local/share/mime/packages/custom.xml": "",
+		"usr/share/mime/globs2":                    "50:application/x-deleted:*.del\n50:text/x-lower:*.low\n",
+		"usr/share/mime/generic-icons":             "text/markdown text-x-lower\n",
+		"home/.local/share/mime/subclasses":        "application/x-compressed-tar application/x-tar\n",
+	}))
+	old := time.Now().Add(-time.Hour)
+	if err := os.Chtimes(tr.Path("usr/local/share/mime/packages/custom.xml"), old, old); err != nil {
+		t.Fatal(err)
+	}
+	if err := os.Chtimes(tr.Path("usr/local/share/mime/packages"), old, old); err != nil {
+		t.Fatal(err)
+	}
+	r := tr.Resolver()
+
+	db, err := sharedmime.Load(r)
+	if err != nil {
+		t.Fatal(err)
+	}
+	tests := []struct {
+		name string
+		want string
+	}{
+		{name: "notes.md", want: "text/markdown"},
+		{name: "file.del", want: ""},
+		{name: "file.low", want: "text/x-lower"},
+	}
+	for _, tt := range tests {
+		if got := db.TypeByName(tt.name); got != tt.want {
+			t.Errorf("TypeByName(%q) = %q, want %q", tt.name, got, tt.want)
+		}
+	}
+	if !db.IsA("application/x-compressed-tar", "application/x-tar") || db.IsA("application/x-compressed-tar", "application/gzip") {
+		t.Error("IsA() does not prefer the subclasses of the higher precedence directory")
+	}
+	if got := db.GenericIcon("text/x-markdown"); got != "text-x-lower" {
+		t.Errorf("GenericIcon() = %q, want the one of the lower directory", got)
+	}
+	if got := db.MagicSize(); got != 256 {
+		t.Errorf("MagicSize() = %d, want 256", got)
+	}
+
+	// a package newer than the cache makes it stale
+	newer := time.Now().Add(time.Hour)
+	if err := os.Chtimes(tr.Path("usr/local/share/mime/packages/custom.xml"), newer, newer); err != nil {
+		t.Fatal(err)
+	}
+	if _, err := sharedmime.LoadCache(r.FS(), tr.Path("usr/local/share/mime")); !errors.Is(err, sharedmime.ErrStaleCache) {
+		t.Fatalf("LoadCache() error = %v, want ErrStaleCache", err)
+	}
+	if db, err = sharedmime.Load(r); err != nil {
+		t.Fatal(err)
+	}
+	if got := db.TypeByName("notes.md"); got != "text/x-from-text" {
+		t.Errorf("TypeByName() with a stale cache = %q, want the text files", got)
+	}
+}
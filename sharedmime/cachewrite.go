@@ -0,0 +1,300 @@
+// Copyright 2026 The go-xdgbasedir Authors. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package sharedmime
+
+import (
+	"encoding/binary"
+	"sort"
+	"strings"
+)
+
+// cacheWriter writes a mime.cache.
+type cacheWriter struct {
+	buf     []byte
+	strings map[string]uint32
+}
+
+// reserve appends n zero bytes and returns their offset.
+func (w *cacheWriter) reserve(n int) uint32 {
+	off := len(w.buf)
+	w.buf = append(w.buf, make([]byte, n)...)
+	return uint32(off)
+}
+
+func (w *cacheWriter) put32(off, v uint32) {
+	binary.BigEndian.PutUint32(w.buf[off:], v)
+}
+
+// data appends b followed by a nul byte, padded to 4 bytes, and returns its offset.
+func (w *cacheWriter) data(b []byte) uint32 {
+	off := uint32(len(w.buf))
+	w.buf = append(w.buf, b...)
+	w.buf = append(w.buf, 0)
+	for len(w.buf)%4 != 0 {
+		w.buf = append(w.buf, 0)
+	}
+	return off
+}
+
+// str returns the offset of the string written by writeStrings.
+func (w *cacheWriter) str(s string) uint32 {
+	return w.strings[s]
+}
+
+// writeStrings writes the strings in sorted order, each once.
+func (w *cacheWriter) writeStrings(strs []string) {
+	sort.Strings(strs)
+	for _, s := range strs {
+		if _, ok := w.strings[s]; !ok {
+			w.strings[s] = w.data([]byte(s))
+		}
+	}
+}
+
+// writePairs writes a list of the pairs of strings sorted by the first one.
+func (w *cacheWriter) writePairs(pairs [][2]string) uint32 {
+	off := w.reserve(4 + 8*len(pairs))
+	w.put32(off, uint32(len(pairs)))
+	for i, p := range pairs {
+		w.put32(off+4+8*uint32(i), w.str(p[0]))
+		w.put32(off+8+8*uint32(i), w.str(p[1]))
+	}
+	return off
+}
+
+// cacheGlob is a glob in the cache form, lowercased unless it is case-sensitive.
+type cacheGlob struct {
+	pattern  string
+	mimeType string
+	weight   uint32
+}
+
+func newCacheGlob(g glob, pattern string) cacheGlob {
+	weight := uint32(g.weight)
+	switch {
+	case g.caseSensitive:
+		weight |= cacheCaseSensitive
+	case pattern != noGlobsPattern:
+		pattern = strings.ToLower(pattern)
+	}
+	return cacheGlob{pattern: pattern, mimeType: g.mimeType, weight: weight}
+}
+
+// writeGlobs writes a literal or glob list.
+func (w *cacheWriter) writeGlobs(globs []cacheGlob) uint32 {
+	off := w.reserve(4 + 12*len(globs))
+	w.put32(off, uint32(len(globs)))
+	for i, g := range globs {
+		e := off + 4 + 12*uint32(i)
+		w.put32(e, w.str(g.pattern))
+		w.put32(e+4, w.str(g.mimeType))
+		w.put32(e+8, g.weight)
+	}
+	return off
+}
+
+// suffixNode is a node of the reverse suffix tree.
+type suffixNode struct {
+	leaves   []cacheGlob
+	children map[rune]*suffixNode
+}
+
+// writeNodes writes the leaves and the child nodes of n, and returns their count and offset.
+func (w *cacheWriter) writeNodes(n *suffixNode) (uint32, uint32) {
+	chars := make([]rune, 0, len(n.children))
+	for c := range n.children {
+		chars = append(chars, c)
+	}
+	sort.Slice(chars, func(i, j int) bool { return chars[i] < chars[j] })
+	sort.Slice(n.leaves, func(i, j int) bool {
+		if n.leaves[i].mimeType != n.leaves[j].mimeType {
+			return n.leaves[i].mimeType < n.leaves[j].mimeType
+		}
+		return n.leaves[i].weight < n.leaves[j].weight
+	})
+
+	count := len(n.leaves) + len(chars)
+	off := w.reserve(12 * count)
+	for i, leaf := range n.leaves {
+		e := off + 12*uint32(i)
+		w.put32(e+4, w.str(leaf.mimeType))
+		w.put32(e+8, leaf.weight)
+	}
+	for i, c := range chars {
+		e := off + 12*uint32(len(n.leaves)+i)
+		childN, childOff := w.writeNodes(n.children[c])
+		w.put32(e, uint32(c))
+		w.put32(e+4, childN)
+		w.put32(e+8, childOff)
+	}
+	return uint32(count), off
+}
+
+// writeSuffixTree writes the reverse suffix tree of the "*" globs.
+func (w *cacheWriter) writeSuffixTree(globs []cacheGlob) uint32 {
+	root := &suffixNode{children: make(map[rune]*suffixNode)}
+	for _, g := range globs {
+		n := root
+		runes := []rune(g.pattern[1:])
+		for i := len(runes) - 1; i >= 0; i-- {
+			child, ok := n.children[runes[i]]
+			if !ok {
+				child = &suffixNode{children: make(map[rune]*suffixNode)}
+				n.children[runes[i]] = child
+			}
+			n = child
+		}
+		n.leaves = append(n.leaves, g)
+	}
+	off := w.reserve(8)
+	n, first := w.writeNodes(root)
+	w.put32(off, n)
+	w.put32(off+4, first)
+	return off
+}
+
+// writeMatchlets writes the magic rules as matchlets, and returns their count and offset.
+func (w *cacheWriter) writeMatchlets(rules []*magicRule) (uint32, uint32) {
+	off := w.reserve(32 * len(rules))
+	for i, r := range rules {
+		m := off + 32*uint32(i)
+		w.put32(m, uint32(r.offset))
+		w.put32(m+4, uint32(r.rangeLen))
+		w.put32(m+8, uint32(r.wordSize))
+		w.put32(m+12, uint32(len(r.value)))
+		w.put32(m+16, w.data(r.value))
+		if r.mask != nil {
+			w.put32(m+20, w.data(r.mask))
+		}
+		n, first := w.writeMatchlets(r.children)
+		w.put32(m+24, n)
+		w.put32(m+28, first)
+	}
+	return uint32(len(rules)), off
+}
+
+// writeMagic writes the magic list of the sections in priority order.
+func (w *cacheWriter) writeMagic(sections []*magicSection) uint32 {
+	maxExtent := 0
+	for _, s := range sections {
+		if n := extent(s.rules); n > maxExtent {
+			maxExtent = n
+		}
+	}
+	off := w.reserve(12)
+	first := w.reserve(16 * len(sections))
+	w.put32(off, uint32(len(sections)))
+	w.put32(off+4, uint32(maxExtent))
+	w.put32(off+8, first)
+	for i, s := range sections {
+		m := first + 16*uint32(i)
+		w.put32(m, uint32(s.priority))
+		w.put32(m+4, w.str(s.mimeType))
+		n, rules := w.writeMatchlets(s.rules)
+		w.put32(m+8, n)
+		w.put32(m+12, rules)
+	}
+	return off
+}
+
+// isLiteral reports whether the glob pattern has no wildcard.
+func isLiteral(pattern string) bool {
+	return !strings.ContainsAny(pattern, "*?[")
+}
+
+// cache returns the mime.cache of the compiled types with the sorted globs and magic sections.
+func (c *compiler) cache(globs []glob, magic []*magicSection) []byte {
+	var literals, suffixes, others []cacheGlob
+	for _, g := range globs {
+		switch {
+		case isLiteral(g.pattern):
+			literals = append(literals, newCacheGlob(g, g.pattern))
+		case g.pattern[0] == '*' && len(g.pattern) > 1 && isLiteral(g.pattern[1:]):
+			suffixes = append(suffixes, newCacheGlob(g, g.pattern))
+		default:
+			others = append(others, newCacheGlob(g, g.pattern))
+		}
+	}
+	sort.SliceStable(literals, func(i, j int) bool { return literals[i].pattern < literals[j].pattern })
+
+	var parents [][2]string // MIME type and the offset of its parents, filled later
+	var namespaces [][3]string
+	var strs []string
+	for _, t := range c.sortedTypes() {
+		strs = append(strs, t.name)
+		strs = append(strs, t.parents...)
+		strs = append(strs, t.aliases...)
+		if t.icon != "" {
+			strs = append(strs, t.icon)
+		}
+		if t.genericIcon != "" {
+			strs = append(strs, t.genericIcon)
+		}
+		if len(t.parents) > 0 {
+			parents = append(parents, [2]string{t.name})
+		}
+		for _, ns := range t.namespaces {
+			namespaces = append(namespaces, [3]string{ns[0], ns[1], t.name})
+			strs = append(strs, ns[0], ns[1])
+		}
+	}
+	for _, list := range [][]cacheGlob{literals, suffixes, others} {
+		for _, g := range list {
+			strs = append(strs, g.pattern)
+		}
+	}
+	sort.Slice(namespaces, func(i, j int) bool {
+		if namespaces[i][0] != namespaces[j][0] {
+			return namespaces[i][0] < namespaces[j][0]
+		}
+		return namespaces[i][1] < namespaces[j][1]
+	})
+
+	w := &cacheWriter{strings: make(map[string]uint32)}
+	w.reserve(cacheHeaderSize)
+	binary.BigEndian.PutUint16(w.buf, CacheMajorVersion)
+	binary.BigEndian.PutUint16(w.buf[2:], CacheMinorVersion)
+	w.writeStrings(strs)
+
+	aliasList := w.writePairs(c.aliases())
+
+	parentList := w.reserve(4 + 8*len(parents))
+	w.put32(parentList, uint32(len(parents)))
+	for i, p := range parents {
+		list := c.types[p[0]].parents
+		off := w.reserve(4 + 4*len(list))
+		w.put32(off, uint32(len(list)))
+		for j, parent := range list {
+			w.put32(off+4+4*uint32(j), w.str(parent))
+		}
+		w.put32(parentList+4+8*uint32(i), w.str(p[0]))
+		w.put32(parentList+8+8*uint32(i), off)
+	}
+
+	literalList := w.writeGlobs(literals)
+	suffixTree := w.writeSuffixTree(suffixes)
+	globList := w.writeGlobs(others)
+	magicList := w.writeMagic(magic)
+
+	namespaceList := w.reserve(4 + 12*len(namespaces))
+	w.put32(namespaceList, uint32(len(namespaces)))
+	for i, ns := range namespaces {
+		e := namespaceList + 4 + 12*uint32(i)
+		w.put32(e, w.str(ns[0]))
+		w.put32(e+4, w.str(ns[1]))
+		w.put32(e+8, w.str(ns[2]))
+	}
+
+	iconsList := w.writePairs(c.icons(false))
+	genericIconsList := w.writePairs(c.icons(true))
+
+	for i, off := range []uint32{
+		aliasList, parentList, literalList, suffixTree, globList,
+		magicList, namespaceList, iconsList, genericIconsList,
+	} {
+		w.put32(4+4*uint32(i), off)
+	}
+	return w.buf
+}
@@ -0,0 +1,24 @@
+// Copyright 2026 The go-xdgbasedir Authors. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+// Package sharedmime implements the freedesktop.org Shared MIME-info Database specification.
+//
+//	https://specifications.freedesktop.org/shared-mime-info-spec/latest/
+//
+// The database is loaded from the files generated by update-mime-database in the mime directory of DataHome and
+// each DataDirs, merged in precedence order:
+//
+//	globs2          weighted glob patterns of file names, or globs if there is no globs2
+//	magic           content sniffing rules
+//	aliases         aliases of the canonical MIME types
+//	subclasses      parents of the MIME types
+//	icons           icon names
+//	generic-icons   generic icon names
+//
+// The binary mime.cache of a directory is read in place of its text files unless it is missing or stale, that is,
+// older than a package file in the packages subdirectory.
+//
+// A Database detects the MIME type of a file by its name, by its content or by both the same way the desktop
+// does, and answers whether a MIME type is a subclass of another with IsA.
+package sharedmime // import "github.com/zchee/go-xdgbasedir/sharedmime"
@@ -0,0 +1,112 @@
+// Copyright 2026 The go-xdgbasedir Authors. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package sharedmime
+
+import (
+	"bufio"
+	"bytes"
+	"path"
+	"strconv"
+	"strings"
+)
+
+// DefaultWeight is the weight of the globs without one, such as the ones in the globs file.
+const DefaultWeight = 50
+
+// noGlobsPattern is the pattern which discards the globs of the MIME type in the lower precedence directories.
+const noGlobsPattern = "__NOGLOBS__"
+
+// glob is a glob pattern of file names.
+type glob struct {
+	weight        int
+	mimeType      string
+	pattern       string
+	caseSensitive bool
+}
+
+// parseGlobs parses the globs2 file data, or the globs file if v2 is false, into src. Malformed lines are
+// skipped.
+func (src *textSource) parseGlobs(data []byte, v2 bool) {
+	sc := bufio.NewScanner(bytes.NewReader(data))
+	for sc.Scan() {
+		line := sc.Text()
+		if line == "" || line[0] == '#' {
+			continue
+		}
+		g := glob{weight: DefaultWeight}
+		if v2 {
+			fields := strings.Split(line, ":")
+			if len(fields) < 3 {
+				continue
+			}
+			w, err := strconv.Atoi(fields[0])
+			if err != nil {
+				continue
+			}
+			g.weight = w
+			g.mimeType, g.pattern = fields[1], fields[2]
+			if len(fields) > 3 {
+				for _, flag := range strings.Split(fields[3], ",") {
+					g.caseSensitive = g.caseSensitive || flag == "cs"
+				}
+			}
+		} else {
+			typ, pattern, ok := strings.Cut(line, ":")
+			if !ok {
+				continue
+			}
+			g.mimeType, g.pattern = typ, pattern
+		}
+		switch {
+		case g.mimeType == "" || g.pattern == "":
+		case g.pattern == noGlobsPattern:
+			src.deletedGlobs[g.mimeType] = true
+		default:
+			src.globs = append(src.globs, g)
+		}
+	}
+}
+
+// match reports whether the file name matches g. If fold is true, g matches case-insensitively unless it is
+// case-sensitive.
+func (g *glob) match(name string, fold bool) bool {
+	pattern := g.pattern
+	if fold {
+		if g.caseSensitive {
+			return false
+		}
+		pattern, name = strings.ToLower(pattern), strings.ToLower(name)
+	}
+	ok, err := path.Match(pattern, name)
+	return err == nil && ok
+}
+
+// globMatch collects the best glob matches of a file name.
+type globMatch struct {
+	types  []string
+	weight int
+	length int
+}
+
+// add adds the match of the MIME type by a glob of the weight and the pattern length. A higher weight wins, and
+// a longer pattern wins for the same weight.
+func (m *globMatch) add(mimeType string, weight, length int) {
+	switch {
+	case m.types == nil, weight > m.weight, weight == m.weight && length > m.length:
+		m.types = []string{mimeType}
+		m.weight, m.length = weight, length
+	case weight == m.weight && length == m.length && !contains(m.types, mimeType):
+		m.types = append(m.types, mimeType)
+	}
+}
+
+func contains(list []string, s string) bool {
+	for _, e := range list {
+		if e == s {
+			return true
+		}
+	}
+	return false
+}
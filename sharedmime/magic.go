@@ -0,0 +1,291 @@
+// Copyright 2026 The go-xdgbasedir Authors. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package sharedmime
+
+import (
+	"bytes"
+	"encoding/binary"
+	"errors"
+	"fmt"
+	"strconv"
+	"strings"
+)
+
+// magicHeader is the header of the magic file.
+const magicHeader = "MIME-Magic\x00\n"
+
+// noMagicValue is the value which discards the magic of the MIME type in the lower precedence directories.
+const noMagicValue = "__NOMAGIC__"
+
+// minMagicSize is the minimum number of bytes read for the detection, which is also checked to tell text from
+// binary data.
+const minMagicSize = 128
+
+// magicSection is a [priority:mime-type] section of the magic file.
+type magicSection struct {
+	priority int
+	mimeType string
+	rules    []*magicRule
+}
+
+// magicRule is a rule of a magic section, which matches if its value is found at the offset and any of its
+// children, if any, matches too.
+type magicRule struct {
+	offset   int
+	rangeLen int
+	wordSize int
+	value    []byte
+	mask     []byte
+	children []*magicRule
+}
+
+// littleEndian reports whether the host is little-endian, where the values and masks of a word size greater than
+// one are byte-swapped.
+var littleEndian = func() bool {
+	var b [2]byte
+	binary.NativeEndian.PutUint16(b[:], 1)
+	return b[0] == 1
+}()
+
+// parseMagic parses the magic file data into src.
+func (src *textSource) parseMagic(data []byte) error {
+	sections, err := parseMagic(data)
+	if err != nil {
+		return err
+	}
+	for _, s := range sections {
+		if len(s.rules) == 1 && string(s.rules[0].value) == noMagicValue {
+			src.deletedMagic[s.mimeType] = true
+			continue
+		}
+		src.magic = append(src.magic, s)
+	}
+	src.sortMagic()
+	return nil
+}
+
+// parseMagic parses the magic file data.
+func parseMagic(data []byte) ([]*magicSection, error) {
+	if !bytes.HasPrefix(data, []byte(magicHeader)) {
+		return nil, errors.New("sharedmime: not a magic file")
+	}
+	p := &magicParser{data: data, pos: len(magicHeader)}
+	var sections []*magicSection
+	for p.pos < len(p.data) {
+		if p.data[p.pos] == '[' {
+			s, err := p.section()
+			if err != nil {
+				return nil, err
+			}
+			sections = append(sections, s)
+			continue
+		}
+		if len(sections) == 0 {
+			return nil, p.errorf("rule before any section")
+		}
+		indent, rule, err := p.rule()
+		if err != nil {
+			return nil, err
+		}
+		s := sections[len(sections)-1]
+		if err := s.add(indent, rule); err != nil {
+			return nil, p.errorf("%v", err)
+		}
+	}
+	return sections, nil
+}
+
+// add adds the rule at the indent level to the last rule of the parent level.
+func (s *magicSection) add(indent int, rule *magicRule) error {
+	rules := &s.rules
+	for i := 0; i < indent; i++ {
+		if len(*rules) == 0 {
+			return fmt.Errorf("rule indented by %d has no parent", indent)
+		}
+		rules = &(*rules)[len(*rules)-1].children
+	}
+	*rules = append(*rules, rule)
+	return nil
+}
+
+// magicParser is a parser of the magic file.
+type magicParser struct {
+	data []byte
+	pos  int
+}
+
+func (p *magicParser) errorf(format string, args ...interface{}) error {
+	return fmt.Errorf("sharedmime: magic offset %d: %s", p.pos, fmt.Sprintf(format, args...))
+}
+
+// section parses a "[priority:mime-type]\n" line.
+func (p *magicParser) section() (*magicSection, error) {
+	end := bytes.IndexByte(p.data[p.pos:], '\n')
+	if end < 0 {
+		return nil, p.errorf("unterminated section")
+	}
+	line := string(p.data[p.pos : p.pos+end])
+	if len(line) < 2 || line[len(line)-1] != ']' {
+		return nil, p.errorf("malformed section %q", line)
+	}
+	prio, mimeType, ok := strings.Cut(line[1:len(line)-1], ":")
+	priority, err := strconv.Atoi(prio)
+	if !ok || err != nil || mimeType == "" {
+		return nil, p.errorf("malformed section %q", line)
+	}
+	p.pos += end + 1
+	return &magicSection{priority: priority, mimeType: mimeType}, nil
+}
+
+// rule parses a "[ indent ] '>' start-offset '=' value [ '&' mask ] [ '~' word-size ] [ '+' range-length ]\n"
+// line.
+func (p *magicParser) rule() (int, *magicRule, error) {
+	indent := 0
+	if p.data[p.pos] != '>' {
+		n, err := p.number('>')
+		if err != nil {
+			return 0, nil, err
+		}
+		indent = n
+	}
+	p.pos++ // '>'
+	offset, err := p.number('=')
+	if err != nil {
+		return 0, nil, err
+	}
+	p.pos++ // '='
+	if p.pos+2 > len(p.data) {
+		return 0, nil, p.errorf("truncated value length")
+	}
+	n := int(binary.BigEndian.Uint16(p.data[p.pos:]))
+	p.pos += 2
+	value, err := p.bytes(n)
+	if err != nil {
+		return 0, nil, err
+	}
+	rule := &magicRule{offset: offset, rangeLen: 1, wordSize: 1, value: value}
+
+	for p.pos < len(p.data) && p.data[p.pos] != '\n' {
+		c := p.data[p.pos]
+		p.pos++
+		switch c {
+		case '&':
+			if rule.mask, err = p.bytes(n); err != nil {
+				return 0, nil, err
+			}
+		case '~':
+			if rule.wordSize, err = p.number(0); err != nil {
+				return 0, nil, err
+			}
+		case '+':
+			if rule.rangeLen, err = p.number(0); err != nil {
+				return 0, nil, err
+			}
+		default:
+			// unknown extension, ignored up to the end of line as the specification requires
+			end := bytes.IndexByte(p.data[p.pos:], '\n')
+			if end < 0 {
+				return 0, nil, p.errorf("unterminated rule")
+			}
+			p.pos += end
+		}
+	}
+	if p.pos >= len(p.data) {
+		return 0, nil, p.errorf("unterminated rule")
+	}
+	p.pos++ // '\n'
+	if rule.rangeLen < 1 {
+		rule.rangeLen = 1
+	}
+	if littleEndian && rule.wordSize > 1 {
+		swap(rule.value, rule.wordSize)
+		swap(rule.mask, rule.wordSize)
+	}
+	return indent, rule, nil
+}
+
+// number parses a decimal number terminated by delim, or by any non-digit if delim is 0. The delimiter is not
+// consumed.
+func (p *magicParser) number(delim byte) (int, error) {
+	start := p.pos
+	for p.pos < len(p.data) && '0' <= p.data[p.pos] && p.data[p.pos] <= '9' {
+		p.pos++
+	}
+	if p.pos == start || (delim != 0 && (p.pos >= len(p.data) || p.data[p.pos] != delim)) {
+		return 0, p.errorf("malformed number")
+	}
+	return strconv.Atoi(string(p.data[start:p.pos]))
+}
+
+// bytes returns the next n bytes.
+func (p *magicParser) bytes(n int) ([]byte, error) {
+	if p.pos+n > len(p.data) {
+		return nil, p.errorf("truncated value")
+	}
+	b := p.data[p.pos : p.pos+n : p.pos+n]
+	p.pos += n
+	return b, nil
+}
+
+// swap reverses the order of the bytes in each word of b in place.
+func swap(b []byte, wordSize int) {
+	if len(b)%wordSize != 0 {
+		return
+	}
+	for i := 0; i < len(b); i += wordSize {
+		w := b[i : i+wordSize]
+		for l, r := 0, len(w)-1; l < r; l, r = l+1, r-1 {
+			w[l], w[r] = w[r], w[l]
+		}
+	}
+}
+
+// match reports whether data matches r.
+func (r *magicRule) match(data []byte) bool {
+	for start := r.offset; start < r.offset+r.rangeLen; start++ {
+		if start+len(r.value) > len(data) {
+			break
+		}
+		if !r.matchAt(data[start:]) {
+			continue
+		}
+		if len(r.children) == 0 {
+			return true
+		}
+		for _, c := range r.children {
+			if c.match(data) {
+				return true
+			}
+		}
+	}
+	return false
+}
+
+// matchAt reports whether data starts with the value of r under its mask.
+func (r *magicRule) matchAt(data []byte) bool {
+	if r.mask == nil {
+		return bytes.HasPrefix(data, r.value)
+	}
+	for i, v := range r.value {
+		if data[i]&r.mask[i] != v&r.mask[i] {
+			return false
+		}
+	}
+	return true
+}
+
+// extent returns the number of bytes of the content needed to match the rules.
+func extent(rules []*magicRule) int {
+	n := 0
+	for _, r := range rules {
+		if end := r.offset + r.rangeLen - 1 + len(r.value); end > n {
+			n = end
+		}
+		if end := extent(r.children); end > n {
+			n = end
+		}
+	}
+	return n
+}
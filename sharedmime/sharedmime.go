@@ -0,0 +1,315 @@
+// Copyright 2026 The go-xdgbasedir Authors. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package sharedmime
+
+import (
+	"io"
+	"path/filepath"
+	"strings"
+
+	"github.com/zchee/go-xdgbasedir"
+)
+
+// List of the well-known MIME types used by the detection.
+const (
+	TextPlain   = "text/plain"
+	OctetStream = "application/octet-stream"
+	Directory   = "inode/directory"
+)
+
+// List of the files of a mime directory.
+const (
+	Globs2File       = "globs2"
+	GlobsFile        = "globs"
+	MagicFile        = "magic"
+	AliasesFile      = "aliases"
+	SubclassesFile   = "subclasses"
+	IconsFile        = "icons"
+	GenericIconsFile = "generic-icons"
+	CacheFile        = "mime.cache"
+	PackagesDir      = "packages"
+)
+
+// source is the data of a mime directory, read from either its mime.cache or its text files.
+type source interface {
+	alias(mimeType string) (string, bool)
+	parents(mimeType string) ([]string, bool)
+	icon(mimeType string) (string, bool)
+	genericIcon(mimeType string) (string, bool)
+	// matchGlobs calls fn for each glob matching the file name, with the exact case or case-insensitively if
+	// fold is true, and the length of its pattern.
+	matchGlobs(name string, fold bool, fn func(mimeType string, weight, length int))
+	noGlobs(mimeType string) bool
+	// matchMagic returns the MIME type and the priority of the first magic section in priority order which data
+	// matches, skipping the MIME types skip reports.
+	matchMagic(data []byte, skip func(mimeType string) bool) (string, int)
+	noMagic(mimeType string) bool
+	magicSize() int
+}
+
+// Database is a Shared MIME-info database merged from the mime directories.
+type Database struct {
+	fsys    xdgbasedir.FileSystem
+	sources []source // in precedence order
+}
+
+// Dirs returns the mime directories of r's DataHome and each DataDirs in precedence order.
+// If r is nil, xdgbasedir.Default() is used.
+func Dirs(r *xdgbasedir.Resolver) []string {
+	if r == nil {
+		r = xdgbasedir.Default()
+	}
+	var dirs []string
+	for _, dir := range append([]string{r.DataHome()}, filepath.SplitList(r.DataDirs())...) {
+		if dir != "" {
+			dirs = append(dirs, filepath.Join(dir, "mime"))
+		}
+	}
+	return dirs
+}
+
+// Load loads the database from the mime directories resolved by r. The mime.cache of a directory is used unless
+// it is missing, corrupted or stale, in which case the text files are read instead. Missing files are ignored.
+// If r is nil, xdgbasedir.Default() is used.
+func Load(r *xdgbasedir.Resolver) (*Database, error) {
+	if r == nil {
+		r = xdgbasedir.Default()
+	}
+	db := &Database{fsys: r.FS()}
+	for _, dir := range Dirs(r) {
+		if c, err := LoadCache(r.FS(), dir); err == nil {
+			db.sources = append(db.sources, c)
+			continue
+		}
+		src, err := loadText(r.FS(), dir)
+		if err != nil {
+			return nil, err
+		}
+		if src != nil {
+			db.sources = append(db.sources, src)
+		}
+	}
+	return db, nil
+}
+
+// Canonical returns the canonical MIME type of the alias, or mimeType itself if it is not an alias.
+func (db *Database) Canonical(mimeType string) string {
+	lower := strings.ToLower(mimeType)
+	for _, src := range db.sources {
+		if c, ok := src.alias(lower); ok {
+			return c
+		}
+	}
+	return mimeType
+}
+
+// Parents returns the direct parents of the MIME type listed in the subclasses files, with the implicit parents
+// text/plain of the text/* types and application/octet-stream of the other streamable types.
+func (db *Database) Parents(mimeType string) []string {
+	mimeType = db.Canonical(mimeType)
+	for _, src := range db.sources {
+		if parents, ok := src.parents(mimeType); ok {
+			return parents
+		}
+	}
+	switch {
+	case mimeType == TextPlain, mimeType == OctetStream, strings.HasPrefix(mimeType, "inode/"),
+		strings.HasPrefix(mimeType, "x-scheme-handler/"):
+		return nil
+	case strings.HasPrefix(mimeType, "text/"):
+		return []string{TextPlain}
+	}
+	return []string{OctetStream}
+}
+
+// IsA reports whether the MIME type is parent or a subclass of parent, both of which may be aliases.
+func (db *Database) IsA(mimeType, parent string) bool {
+	return db.isA(db.Canonical(mimeType), db.Canonical(parent), make(map[string]bool))
+}
+
+func (db *Database) isA(mimeType, parent string, seen map[string]bool) bool {
+	if mimeType == parent {
+		return true
+	}
+	if seen[mimeType] {
+		return false
+	}
+	seen[mimeType] = true
+	for _, p := range db.Parents(mimeType) {
+		if db.isA(db.Canonical(p), parent, seen) {
+			return true
+		}
+	}
+	return false
+}
+
+// Icon returns the icon name of the MIME type from the icons files, or the MIME type with "/" replaced by "-",
+// such as "text-markdown".
+func (db *Database) Icon(mimeType string) string {
+	mimeType = db.Canonical(mimeType)
+	for _, src := range db.sources {
+		if icon, ok := src.icon(mimeType); ok {
+			return icon
+		}
+	}
+	return strings.ReplaceAll(mimeType, "/", "-")
+}
+
+// GenericIcon returns the generic icon name of the MIME type from the generic-icons files, or the media type
+// followed by "-x-generic", such as "text-x-generic".
+func (db *Database) GenericIcon(mimeType string) string {
+	mimeType = db.Canonical(mimeType)
+	for _, src := range db.sources {
+		if icon, ok := src.genericIcon(mimeType); ok {
+			return icon
+		}
+	}
+	media, _, _ := strings.Cut(mimeType, "/")
+	return media + "-x-generic"
+}
+
+// MatchName returns the MIME types of the globs matching the base name of the file name. The globs matching with
+// the exact case are tried first, and the case-insensitive ones only if none matches. The globs of the highest
+// weight win, and the longest patterns win among them, so that "*.tar.gz" wins over "*.gz". More than one MIME
+// type is returned if the match is ambiguous, and none if no glob matches. The globs of a MIME type with
+// __NOGLOBS__ in a directory are ignored in the lower precedence directories.
+func (db *Database) MatchName(name string) []string {
+	name = filepath.Base(name)
+	if types := db.matchName(name, false); types != nil {
+		return types
+	}
+	return db.matchName(name, true)
+}
+
+func (db *Database) matchName(name string, fold bool) []string {
+	var m globMatch
+	for i, src := range db.sources {
+		src.matchGlobs(name, fold, func(mimeType string, weight, length int) {
+			for _, higher := range db.sources[:i] {
+				if higher.noGlobs(mimeType) {
+					return
+				}
+			}
+			m.add(mimeType, weight, length)
+		})
+	}
+	return m.types
+}
+
+// MatchContent returns the MIME type of the highest priority magic section which data matches, or an empty
+// string if none matches. The magic of a MIME type with __NOMAGIC__ in a directory is ignored in the lower
+// precedence directories.
+func (db *Database) MatchContent(data []byte) string {
+	var (
+		best     string
+		priority int
+	)
+	for i, src := range db.sources {
+		mimeType, prio := src.matchMagic(data, func(mimeType string) bool {
+			for _, higher := range db.sources[:i] {
+				if higher.noMagic(mimeType) {
+					return true
+				}
+			}
+			return false
+		})
+		if mimeType != "" && (best == "" || prio > priority) {
+			best, priority = mimeType, prio
+		}
+	}
+	return best
+}
+
+// MagicSize returns the number of bytes of the file content needed to match all the magic rules.
+func (db *Database) MagicSize() int {
+	n := minMagicSize
+	for _, src := range db.sources {
+		if m := src.magicSize(); m > n {
+			n = m
+		}
+	}
+	return n
+}
+
+// TypeByName returns the MIME type of the file name detected by the globs, or an empty string if no glob matches
+// or the match is ambiguous. Use MatchName for all the candidates.
+func (db *Database) TypeByName(name string) string {
+	if types := db.MatchName(name); len(types) == 1 {
+		return types[0]
+	}
+	return ""
+}
+
+// TypeByContent returns the MIME type of data detected by the magic rules. If no rule matches, it returns
+// text/plain if data looks like text, and application/octet-stream otherwise.
+func (db *Database) TypeByContent(data []byte) string {
+	if mimeType := db.MatchContent(data); mimeType != "" {
+		return mimeType
+	}
+	return fallback(data)
+}
+
+// Type returns the MIME type of the file name with the content data, whose prefix of MagicSize bytes is enough.
+// As recommended by the specification, an unambiguous glob match wins, and the magic rules are used when no glob
+// or several globs match. A glob candidate which is the magic match or a subclass of it is preferred, so that a
+// text file named foo.doc is not detected as the container format of the magic match.
+func (db *Database) Type(name string, data []byte) string {
+	globs := db.MatchName(name)
+	if len(globs) == 1 {
+		return globs[0]
+	}
+	if mimeType := db.MatchContent(data); mimeType != "" {
+		for _, g := range globs {
+			if db.IsA(g, mimeType) {
+				return g
+			}
+		}
+		return mimeType
+	}
+	if len(globs) > 0 {
+		return globs[0]
+	}
+	return fallback(data)
+}
+
+// TypeByFile returns the MIME type of the file path, reading its first MagicSize bytes when the name is not
+// enough. It returns inode/directory for a directory.
+func (db *Database) TypeByFile(path string) (string, error) {
+	fi, err := db.fsys.Stat(path)
+	if err != nil {
+		return "", err
+	}
+	if fi.IsDir() {
+		return Directory, nil
+	}
+	name := filepath.Base(path)
+	if globs := db.MatchName(name); len(globs) == 1 {
+		return globs[0], nil
+	}
+	f, err := db.fsys.Open(path)
+	if err != nil {
+		return "", err
+	}
+	defer f.Close()
+	data, err := io.ReadAll(io.LimitReader(f, int64(db.MagicSize())))
+	if err != nil {
+		return "", err
+	}
+	return db.Type(name, data), nil
+}
+
+// fallback returns text/plain if data looks like text, and application/octet-stream otherwise.
+func fallback(data []byte) string {
+	const n = 128 // the number of bytes checked, same as xdgmime
+	if len(data) > n {
+		data = data[:n]
+	}
+	for _, c := range data {
+		if c < 0x20 && c != '\t' && c != '\n' && c != '\r' && c != '\f' && c != 0x1b {
+			return OctetStream
+		}
+	}
+	return TextPlain
+}
@@ -0,0 +1,230 @@
+// Copyright 2026 The go-xdgbasedir Authors. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package sharedmime_test
+
+import (
+	"encoding/binary"
+	"reflect"
+	"testing"
+
+	"github.com/zchee/go-xdgbasedir/sharedmime"
+	"github.com/zchee/go-xdgbasedir/xdgtest"
+)
+
+// rule returns a magic rule line.
+func rule(indent string, offset string, value string, extra string) string {
+	var n [2]byte
+	binary.BigEndian.PutUint16(n[:], uint16(len(value)))
+	return indent + ">" + offset + "=" + string(n[:]) + value + extra + "\n"
+}
+
+func testDatabase(t *testing.T) (*xdgtest.Tree, *sharedmime.Database) {
+	t.Helper()
+
+	tr := xdgtest.New(t, xdgtest.WithFiles(map[string]string{
+		"usr/share/mime/globs2": `# comment
+50:text/markdown:*.md
+50:text/x-csrc:*.c
+50:text/x-c++src:*.C:cs
+50:application/gzip:*.gz
+55:application/x-compressed-tar:*.tar.gz
+50:text/x-readme:README
+50:application/x-ambiguous-a:*.amb
+50:application/x-ambiguous-b:*.amb
+50:image/png:*.png
+50:application/x-deleted:*.del
+`,
+		"usr/share/mime/magic": "MIME-Magic\x00\n" +
+			"[50:image/png]\n" + rule("", "0", "\x89PNG", "") +
+			"[80:application/x-ambiguous-b]\n" + rule("", "0", "AMB", "") + rule("1", "8", "B", "+4") +
+			"[60:application/x-masked]\n" + rule("", "0", "\xf0\x00", "&\xf0\x0f") +
+			"[40:application/x-word]\n" + rule("", "0", "\x12\x34", "~2") +
+			"[50:application/x-deleted]\n" + rule("", "0", "DEL", "") +
+			"[30:application/x-ambiguous-parent]\n" + rule("", "0", "PAR", ""),
+		"usr/share/mime/aliases":       "application/x-gzip application/gzip\ntext/x-markdown text/markdown\n",
+		"usr/share/mime/subclasses":    "text/markdown text/plain\napplication/x-compressed-tar application/gzip\ntext/x-c++src text/x-csrc\napplication/x-ambiguous-a application/x-ambiguous-parent\n",
+		"usr/share/mime/icons":         "text/markdown text-markdown-custom\n",
+		"usr/share/mime/generic-icons": "application/gzip package-x-generic\n",
+
+		"home/.local/share/mime/globs2":  "50:application/x-deleted:__NOGLOBS__\n60:application/x-local:*.del\n",
+		"home/.local/share/mime/magic":   "MIME-Magic\x00\n[50:application/x-deleted]\n" + rule("", "0", "__NOMAGIC__", ""),
+		"home/.local/share/mime/aliases": "text/x-markdown text/x-local-markdown\n",
+
+		"home/notes.md":   "# notes\n",
+		"home/image":      "\x89PNG\r\n\x1a\n",
+		"home/blob":       "\x00\x01\x02",
+		"home/x.amb":      "AMB....xB.",
+		"home/archive.gz": "",
+	}))
+	db, err := sharedmime.Load(tr.Resolver())
+	if err != nil {
+		t.Fatal(err)
+	}
+	return tr, db
+}
+
+func TestMatchName(t *testing.T) {
+	t.Parallel()
+
+	_, db := testDatabase(t)
+	tests := []struct {
+		name string
+		want []string
+	}{
+		{name: "notes.md", want: []string{"text/markdown"}},
+		{name: "/home/gopher/NOTES.MD", want: []string{"text/markdown"}},
+		{name: "main.C", want: []string{"text/x-c++src"}},
+		{name: "main.c", want: []string{"text/x-csrc"}},
+		{name: "src.tar.gz", want: []string{"application/x-compressed-tar"}},
+		{name: "file.gz", want: []string{"application/gzip"}},
+		{name: "README", want: []string{"text/x-readme"}},
+		{name: "x.amb", want: []string{"application/x-ambiguous-a", "application/x-ambiguous-b"}},
+		{name: "x.del", want: []string{"application/x-local"}},
+		{name: "unknown", want: nil},
+	}
+	for _, tt := range tests {
+		if got := db.MatchName(tt.name); !reflect.DeepEqual(got, tt.want) {
+			t.Errorf("MatchName(%q) = %q, want %q", tt.name, got, tt.want)
+		}
+	}
+	if got := db.TypeByName("x.amb"); got != "" {
+		t.Errorf("TypeByName(ambiguous) = %q, want empty", got)
+	}
+}
+
+func TestTypeByContent(t *testing.T) {
+	t.Parallel()
+
+	_, db := testDatabase(t)
+	word := "\x12\x34"
+	if littleEndian() {
+		word = "\x34\x12"
+	}
+	tests := []struct {
+		data string
+		want string
+	}{
+		{data: "\x89PNG\r\n", want: "image/png"},
+		{data: "AMB.....B", want: "application/x-ambiguous-b"},
+		{data: "AMB......B", want: "application/x-ambiguous-b"},
+		{data: "AMB", want: sharedmime.TextPlain},
+		{data: "\xf7\x30", want: "application/x-masked"},
+		{data: "\xf7\x01", want: sharedmime.OctetStream},
+		{data: word, want: "application/x-word"},
+		{data: "DEL\x00", want: sharedmime.OctetStream},
+		{data: "plain text\n", want: sharedmime.TextPlain},
+		{data: "", want: sharedmime.TextPlain},
+	}
+	for _, tt := range tests {
+		if got := db.TypeByContent([]byte(tt.data)); got != tt.want {
+			t.Errorf("TypeByContent(%q) = %q, want %q", tt.data, got, tt.want)
+		}
+	}
+}
+
+func littleEndian() bool {
+	var b [2]byte
+	binary.NativeEndian.PutUint16(b[:], 1)
+	return b[0] == 1
+}
+
+func TestTypeByFile(t *testing.T) {
+	t.Parallel()
+
+	tr, db := testDatabase(t)
+	tests := []struct {
+		path string
+		want string
+	}{
+		{path: "home/notes.md", want: "text/markdown"},
+		{path: "home/image", want: "image/png"},
+		{path: "home/blob", want: sharedmime.OctetStream},
+		{path: "home/x.amb", want: "application/x-ambiguous-b"},
+		{path: "home/archive.gz", want: "application/gzip"},
+		{path: "home", want: sharedmime.Directory},
+	}
+	for _, tt := range tests {
+		if got, err := db.TypeByFile(tr.Path(tt.path)); err != nil || got != tt.want {
+			t.Errorf("TypeByFile(%s) = %q, %v, want %q", tt.path, got, err, tt.want)
+		}
+	}
+	if _, err := db.TypeByFile(tr.Path("home/missing")); err == nil {
+		t.Error("TypeByFile(missing) succeeded")
+	}
+	if got := db.Type("x.amb", []byte("unknown")); got != "application/x-ambiguous-a" {
+		t.Errorf("Type(ambiguous, no magic) = %q", got)
+	}
+
+	ambiguous := []struct {
+		data string
+		want string
+	}{
+		{data: "AMB....xB.", want: "application/x-ambiguous-b"},
+		{data: "PAR", want: "application/x-ambiguous-a"},
+		{data: "\x89PNG\r\n", want: "image/png"},
+	}
+	for _, tt := range ambiguous {
+		if got := db.Type("x.amb", []byte(tt.data)); got != tt.want {
+			t.Errorf("Type(ambiguous, %q) = %q, want %q", tt.data, got, tt.want)
+		}
+	}
+}
+
+func TestIsA(t *testing.T) {
+	t.Parallel()
+
+	_, db := testDatabase(t)
+	tests := []struct {
+		mimeType, parent string
+		want             bool
+	}{
+		{"text/markdown", "text/plain", true},
+		{"text/x-markdown", "text/plain", true},
+		{"text/x-markdown", "text/markdown", false},
+		{"text/x-local-markdown", "text/x-local-markdown", true},
+		{"application/x-compressed-tar", "application/x-gzip", true},
+		{"application/x-compressed-tar", sharedmime.OctetStream, true},
+		{"text/x-c++src", "text/plain", true},
+		{"text/x-csrc", "text/plain", true},
+		{"image/png", sharedmime.OctetStream, true},
+		{"image/png", "text/plain", false},
+		{sharedmime.Directory, sharedmime.OctetStream, false},
+	}
+	for _, tt := range tests {
+		if got := db.IsA(tt.mimeType, tt.parent); got != tt.want {
+			t.Errorf("IsA(%s, %s) = %v, want %v", tt.mimeType, tt.parent, got, tt.want)
+		}
+	}
+
+	if got := db.Canonical("application/x-gzip"); got != "application/gzip" {
+		t.Errorf("Canonical(application/x-gzip) = %q", got)
+	}
+	if got := db.Canonical("text/x-markdown"); got != "text/x-local-markdown" {
+		t.Errorf("Canonical(text/x-markdown) = %q, want the alias of the higher precedence directory", got)
+	}
+}
+
+func TestIcon(t *testing.T) {
+	t.Parallel()
+
+	_, db := testDatabase(t)
+	tests := []struct {
+		mimeType    string
+		icon        string
+		genericIcon string
+	}{
+		{"text/markdown", "text-markdown-custom", "text-x-generic"},
+		{"application/x-gzip", "application-gzip", "package-x-generic"},
+		{"image/png", "image-png", "image-x-generic"},
+	}
+	for _, tt := range tests {
+		if got := db.Icon(tt.mimeType); got != tt.icon {
+			t.Errorf("Icon(%s) = %q, want %q", tt.mimeType, got, tt.icon)
+		}
+		if got := db.GenericIcon(tt.mimeType); got != tt.genericIcon {
+			t.Errorf("GenericIcon(%s) = %q, want %q", tt.mimeType, got, tt.genericIcon)
+		}
+	}
+}
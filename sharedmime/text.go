@@ -0,0 +1,181 @@
+// Copyright 2026 The go-xdgbasedir Authors. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package sharedmime
+
+import (
+	"bufio"
+	"bytes"
+	"errors"
+	"fmt"
+	"io/fs"
+	"path/filepath"
+	"sort"
+	"strings"
+
+	"github.com/zchee/go-xdgbasedir"
+)
+
+// textSource is the data of a mime directory read from its text files.
+type textSource struct {
+	globs        []glob
+	deletedGlobs map[string]bool
+	magic        []*magicSection // sorted by priority
+	deletedMagic map[string]bool
+	aliases      map[string]string
+	parentsOf    map[string][]string
+	icons        map[string]string
+	genericIcons map[string]string
+}
+
+// loadText reads the text files of the mime directory dir, and returns nil if there are none.
+func loadText(fsys xdgbasedir.FileSystem, dir string) (*textSource, error) {
+	src := &textSource{
+		deletedGlobs: make(map[string]bool),
+		deletedMagic: make(map[string]bool),
+		aliases:      make(map[string]string),
+		parentsOf:    make(map[string][]string),
+		icons:        make(map[string]string),
+		genericIcons: make(map[string]string),
+	}
+	found := false
+	read := func(name string) ([]byte, error) {
+		data, err := fsys.ReadFile(filepath.Join(dir, name))
+		found = found || err == nil
+		return data, err
+	}
+
+	globs, err := read(Globs2File)
+	if err == nil {
+		src.parseGlobs(globs, true)
+	} else if errors.Is(err, fs.ErrNotExist) {
+		if globs, err = read(GlobsFile); err == nil {
+			src.parseGlobs(globs, false)
+		}
+	}
+	if err != nil && !errors.Is(err, fs.ErrNotExist) {
+		return nil, err
+	}
+
+	magic, err := read(MagicFile)
+	if err == nil {
+		if err = src.parseMagic(magic); err != nil {
+			return nil, fmt.Errorf("%s: %w", filepath.Join(dir, MagicFile), err)
+		}
+	}
+	if err != nil && !errors.Is(err, fs.ErrNotExist) {
+		return nil, err
+	}
+
+	for _, f := range []struct {
+		name string
+		fn   func(key, value string)
+	}{
+		{AliasesFile, func(alias, mimeType string) { setDefault(src.aliases, alias, mimeType) }},
+		{SubclassesFile, func(mimeType, parent string) { src.parentsOf[mimeType] = append(src.parentsOf[mimeType], parent) }},
+		{IconsFile, func(mimeType, icon string) { setDefault(src.icons, mimeType, icon) }},
+		{GenericIconsFile, func(mimeType, icon string) { setDefault(src.genericIcons, mimeType, icon) }},
+	} {
+		data, err := read(f.name)
+		if errors.Is(err, fs.ErrNotExist) {
+			continue
+		}
+		if err != nil {
+			return nil, err
+		}
+		parsePairs(data, f.fn)
+	}
+	if !found {
+		return nil, nil
+	}
+	return src, nil
+}
+
+// parsePairs calls fn for each line of two fields separated by a space in data, such as the aliases file.
+func parsePairs(data []byte, fn func(key, value string)) {
+	sc := bufio.NewScanner(bytes.NewReader(data))
+	for sc.Scan() {
+		line := sc.Text()
+		if line == "" || line[0] == '#' {
+			continue
+		}
+		if key, value, ok := strings.Cut(line, " "); ok && key != "" && value != "" {
+			fn(key, value)
+		}
+	}
+}
+
+// setDefault sets m[key] to value unless it is set already.
+func setDefault(m map[string]string, key, value string) {
+	if _, ok := m[key]; !ok {
+		m[key] = value
+	}
+}
+
+func (src *textSource) alias(mimeType string) (string, bool) {
+	c, ok := src.aliases[mimeType]
+	return c, ok
+}
+
+func (src *textSource) parents(mimeType string) ([]string, bool) {
+	p, ok := src.parentsOf[mimeType]
+	return p, ok
+}
+
+func (src *textSource) icon(mimeType string) (string, bool) {
+	icon, ok := src.icons[mimeType]
+	return icon, ok
+}
+
+func (src *textSource) genericIcon(mimeType string) (string, bool) {
+	icon, ok := src.genericIcons[mimeType]
+	return icon, ok
+}
+
+func (src *textSource) matchGlobs(name string, fold bool, fn func(mimeType string, weight, length int)) {
+	for i := range src.globs {
+		if g := &src.globs[i]; g.match(name, fold) {
+			fn(g.mimeType, g.weight, len(g.pattern))
+		}
+	}
+}
+
+func (src *textSource) noGlobs(mimeType string) bool {
+	return src.deletedGlobs[mimeType]
+}
+
+func (src *textSource) matchMagic(data []byte, skip func(mimeType string) bool) (string, int) {
+	for _, s := range src.magic {
+		if skip(s.mimeType) {
+			continue
+		}
+		for _, r := range s.rules {
+			if r.match(data) {
+				return s.mimeType, s.priority
+			}
+		}
+	}
+	return "", 0
+}
+
+func (src *textSource) noMagic(mimeType string) bool {
+	return src.deletedMagic[mimeType]
+}
+
+func (src *textSource) magicSize() int {
+	n := 0
+	for _, s := range src.magic {
+		if m := extent(s.rules); m > n {
+			n = m
+		}
+	}
+	return n
+}
+
+// sortMagic sorts the magic sections by priority, keeping the file order for the same priority.
+func (src *textSource) sortMagic() {
+	sort.SliceStable(src.magic, func(i, j int) bool {
+		return src.magic[i].priority > src.magic[j].priority
+	})
+}
@@ -0,0 +1,612 @@
+// Copyright 2026 The go-xdgbasedir Authors. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package sharedmime
+
+import (
+	"bytes"
+	"encoding/binary"
+	"encoding/xml"
+	"errors"
+	"fmt"
+	"io/fs"
+	"math"
+	"path/filepath"
+	"sort"
+	"strconv"
+	"strings"
+
+	"github.com/zchee/go-xdgbasedir"
+)
+
+// List of the other files generated by Compile.
+const (
+	TypesFile = "types"
+	// OverrideFile is the package file processed last, so that it overrides the other packages.
+	OverrideFile = "Override.xml"
+)
+
+// generatedHeader is the header of the generated globs files.
+const generatedHeader = "# This file was automatically generated by the\n# update-mime-database command. DO NOT EDIT!\n"
+
+// filePerm is the permission of the generated files.
+const filePerm = 0644
+
+// dirPerm is the permission of the created mime directory.
+const dirPerm = 0755
+
+// xmlMimeInfo is the root element of a package file.
+type xmlMimeInfo struct {
+	Types []xmlMimeType `xml:"mime-type"`
+}
+
+// xmlMimeType is a mime-type element of a package file.
+type xmlMimeType struct {
+	Type           string      `xml:"type,attr"`
+	Globs          []xmlGlob   `xml:"glob"`
+	GlobDeleteAll  *struct{}   `xml:"glob-deleteall"`
+	Magic          []xmlMagic  `xml:"magic"`
+	MagicDeleteAll *struct{}   `xml:"magic-deleteall"`
+	SubClassOf     []xmlRef    `xml:"sub-class-of"`
+	Aliases        []xmlRef    `xml:"alias"`
+	Icon           *xmlName    `xml:"icon"`
+	GenericIcon    *xmlName    `xml:"generic-icon"`
+	RootXML        []xmlRootNS `xml:"root-XML"`
+}
+
+type xmlGlob struct {
+	Pattern       string `xml:"pattern,attr"`
+	Weight        string `xml:"weight,attr"`
+	CaseSensitive string `xml:"case-sensitive,attr"`
+}
+
+type xmlMagic struct {
+	Priority string     `xml:"priority,attr"`
+	Matches  []xmlMatch `xml:"match"`
+}
+
+type xmlMatch struct {
+	Type    string     `xml:"type,attr"`
+	Offset  string     `xml:"offset,attr"`
+	Value   string     `xml:"value,attr"`
+	Mask    string     `xml:"mask,attr"`
+	Matches []xmlMatch `xml:"match"`
+}
+
+type xmlRef struct {
+	Type string `xml:"type,attr"`
+}
+
+type xmlName struct {
+	Name string `xml:"name,attr"`
+}
+
+type xmlRootNS struct {
+	NamespaceURI string `xml:"namespaceURI,attr"`
+	LocalName    string `xml:"localName,attr"`
+}
+
+// compiledType is a MIME type merged from the package files.
+type compiledType struct {
+	name        string
+	globs       []glob
+	deleteGlobs bool
+	magic       []*magicSection
+	deleteMagic bool
+	parents     []string
+	aliases     []string
+	icon        string
+	genericIcon string
+	namespaces  [][2]string // namespace URI and local name
+}
+
+// compiler merges the package files of a mime directory.
+type compiler struct {
+	types map[string]*compiledType
+}
+
+// Compile compiles the freedesktop.org shared-mime-info package files in the packages subdirectory of the mime
+// directory dir, and returns the contents of the generated files keyed by file name: globs, globs2, magic,
+// aliases, subclasses, types, icons, generic-icons and mime.cache. The package files are processed in file name
+// order with Override.xml last, and the output is deterministic.
+func Compile(fsys xdgbasedir.FileSystem, dir string) (map[string][]byte, error) {
+	pkgs := filepath.Join(dir, PackagesDir)
+	entries, err := fsys.ReadDir(pkgs)
+	if err != nil && !errors.Is(err, fs.ErrNotExist) {
+		return nil, err
+	}
+	var names []string
+	for _, de := range entries {
+		if name := de.Name(); strings.HasSuffix(name, ".xml") && name != OverrideFile {
+			names = append(names, name)
+		}
+	}
+	sort.Strings(names)
+	if _, err := fsys.Stat(filepath.Join(pkgs, OverrideFile)); err == nil {
+		names = append(names, OverrideFile)
+	}
+
+	c := &compiler{types: make(map[string]*compiledType)}
+	for _, name := range names {
+		path := filepath.Join(pkgs, name)
+		data, err := fsys.ReadFile(path)
+		if err != nil {
+			return nil, err
+		}
+		if err := c.add(data); err != nil {
+			return nil, fmt.Errorf("%s: %w", path, err)
+		}
+	}
+	return c.files(), nil
+}
+
+// Update compiles the package files of the mime directory dir with Compile and writes the generated files
+// atomically. If dir is empty, the mime directory of r's DataHome is used. If r is nil, xdgbasedir.Default() is
+// used.
+func Update(r *xdgbasedir.Resolver, dir string) error {
+	if r == nil {
+		r = xdgbasedir.Default()
+	}
+	if dir == "" {
+		dir = filepath.Join(r.DataHome(), "mime")
+	}
+	files, err := Compile(r.FS(), dir)
+	if err != nil {
+		return err
+	}
+	if err := r.FS().MkdirAll(dir, dirPerm); err != nil {
+		return err
+	}
+	names := make([]string, 0, len(files))
+	for name := range files {
+		names = append(names, name)
+	}
+	sort.Strings(names)
+	// write mime.cache last, so that it is not older than the text files
+	for _, name := range append(remove(names, CacheFile), CacheFile) {
+		if err := xdgbasedir.WriteFileAtomic(r.FS(), filepath.Join(dir, name), files[name], filePerm); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+func remove(list []string, s string) []string {
+	var out []string
+	for _, e := range list {
+		if e != s {
+			out = append(out, e)
+		}
+	}
+	return out
+}
+
+// add merges the package file data.
+func (c *compiler) add(data []byte) error {
+	var info xmlMimeInfo
+	if err := xml.Unmarshal(data, &info); err != nil {
+		return err
+	}
+	for _, x := range info.Types {
+		if !validType(x.Type) {
+			return fmt.Errorf("invalid MIME type %q", x.Type)
+		}
+		t := c.types[x.Type]
+		if t == nil {
+			t = &compiledType{name: x.Type}
+			c.types[x.Type] = t
+		}
+
+		if x.GlobDeleteAll != nil {
+			t.globs = nil
+			t.deleteGlobs = true
+		}
+		for _, g := range x.Globs {
+			if g.Pattern == "" {
+				return fmt.Errorf("%s: glob without a pattern", x.Type)
+			}
+			if strings.ContainsAny(g.Pattern, ":\n") {
+				// ':' and '\n' separate the fields and the lines of globs and globs2
+				return fmt.Errorf("%s: invalid glob pattern %q", x.Type, g.Pattern)
+			}
+			weight := DefaultWeight
+			if g.Weight != "" {
+				w, err := strconv.Atoi(g.Weight)
+				if err != nil || w < 0 || w > 100 {
+					return fmt.Errorf("%s: invalid glob weight %q", x.Type, g.Weight)
+				}
+				weight = w
+			}
+			t.globs = append(t.globs, glob{weight: weight, mimeType: x.Type, pattern: g.Pattern, caseSensitive: g.CaseSensitive == "true"})
+		}
+
+		if x.MagicDeleteAll != nil {
+			t.magic = nil
+			t.deleteMagic = true
+		}
+		for _, m := range x.Magic {
+			priority := DefaultWeight
+			if m.Priority != "" {
+				p, err := strconv.Atoi(m.Priority)
+				if err != nil || p < 0 || p > 100 {
+					return fmt.Errorf("%s: invalid magic priority %q", x.Type, m.Priority)
+				}
+				priority = p
+			}
+			rules, err := compileMatches(m.Matches)
+			if err != nil {
+				return fmt.Errorf("%s: %w", x.Type, err)
+			}
+			t.magic = append(t.magic, &magicSection{priority: priority, mimeType: x.Type, rules: rules})
+		}
+
+		for _, p := range x.SubClassOf {
+			if !contains(t.parents, p.Type) {
+				t.parents = append(t.parents, p.Type)
+			}
+		}
+		for _, a := range x.Aliases {
+			if !contains(t.aliases, a.Type) {
+				t.aliases = append(t.aliases, a.Type)
+			}
+		}
+		if x.Icon != nil {
+			t.icon = x.Icon.Name
+		}
+		if x.GenericIcon != nil {
+			t.genericIcon = x.GenericIcon.Name
+		}
+		for _, ns := range x.RootXML {
+			t.namespaces = append(t.namespaces, [2]string{ns.NamespaceURI, ns.LocalName})
+		}
+	}
+	return nil
+}
+
+// validType reports whether s is a media type such as "text/markdown".
+func validType(s string) bool {
+	media, sub, ok := strings.Cut(s, "/")
+	return ok && media != "" && sub != "" && !strings.Contains(sub, "/") && !strings.ContainsAny(s, " \t\n:")
+}
+
+// compileMatches compiles the match elements into magic rules.
+func compileMatches(matches []xmlMatch) ([]*magicRule, error) {
+	var rules []*magicRule
+	for _, m := range matches {
+		r := &magicRule{rangeLen: 1, wordSize: 1}
+		start, end, isRange := strings.Cut(m.Offset, ":")
+		off, err := strconv.Atoi(start)
+		if err != nil || off < 0 {
+			return nil, fmt.Errorf("invalid match offset %q", m.Offset)
+		}
+		r.offset = off
+		if isRange {
+			last, err := strconv.Atoi(end)
+			if err != nil || last < off {
+				return nil, fmt.Errorf("invalid match offset %q", m.Offset)
+			}
+			r.rangeLen = last - off + 1
+		}
+
+		if m.Type == "string" {
+			if r.value, err = unescapeMatch(m.Value); err != nil {
+				return nil, err
+			}
+			if m.Mask != "" {
+				if r.mask, err = parseHexMask(m.Mask, len(r.value)); err != nil {
+					return nil, err
+				}
+			}
+		} else {
+			size, order, wordSize, ok := matchWord(m.Type)
+			if !ok {
+				return nil, fmt.Errorf("unsupported match type %q", m.Type)
+			}
+			r.wordSize = wordSize
+			if r.value, err = encodeNumber(m.Value, size, order); err != nil {
+				return nil, err
+			}
+			if m.Mask != "" {
+				if r.mask, err = encodeNumber(m.Mask, size, order); err != nil {
+					return nil, err
+				}
+			}
+		}
+		if len(r.value) == 0 {
+			return nil, fmt.Errorf("empty match value")
+		}
+		if len(r.value) > math.MaxUint16 {
+			// the magic file stores the value length as a 16 bit integer
+			return nil, fmt.Errorf("match value of %d bytes is too long", len(r.value))
+		}
+		if r.children, err = compileMatches(m.Matches); err != nil {
+			return nil, err
+		}
+		rules = append(rules, r)
+	}
+	return rules, nil
+}
+
+// matchWord returns the size, the byte order and the word size of the numeric match type. The host types are
+// stored big-endian with their word size, so that the readers swap them on little-endian hosts.
+func matchWord(typ string) (int, binary.ByteOrder, int, bool) {
+	switch typ {
+	case "byte":
+		return 1, binary.BigEndian, 1, true
+	case "big16":
+		return 2, binary.BigEndian, 1, true
+	case "big32":
+		return 4, binary.BigEndian, 1, true
+	case "little16":
+		return 2, binary.LittleEndian, 1, true
+	case "little32":
+		return 4, binary.LittleEndian, 1, true
+	case "host16":
+		return 2, binary.BigEndian, 2, true
+	case "host32":
+		return 4, binary.BigEndian, 4, true
+	}
+	return 0, nil, 0, false
+}
+
+// encodeNumber encodes the decimal, hexadecimal or octal number s in size bytes of the byte order.
+func encodeNumber(s string, size int, order binary.ByteOrder) ([]byte, error) {
+	v, err := strconv.ParseUint(s, 0, size*8)
+	if err != nil {
+		return nil, fmt.Errorf("invalid match value %q", s)
+	}
+	b := make([]byte, size)
+	switch size {
+	case 1:
+		b[0] = byte(v)
+	case 2:
+		order.PutUint16(b, uint16(v))
+	case 4:
+		order.PutUint32(b, uint32(v))
+	}
+	return b, nil
+}
+
+// unescapeMatch unescapes the string match value, which supports the C escapes such as "\x89", "\0" and "\n".
+func unescapeMatch(s string) ([]byte, error) {
+	var b []byte
+	for i := 0; i < len(s); i++ {
+		if s[i] != '\\' {
+			b = append(b, s[i])
+			continue
+		}
+		i++
+		if i >= len(s) {
+			return nil, fmt.Errorf("trailing backslash in match value %q", s)
+		}
+		switch c := s[i]; {
+		case c == 'x':
+			j := i + 1
+			for j < len(s) && j < i+3 && isHex(s[j]) {
+				j++
+			}
+			if j == i+1 {
+				return nil, fmt.Errorf("invalid hexadecimal escape in match value %q", s)
+			}
+			v, _ := strconv.ParseUint(s[i+1:j], 16, 8)
+			b = append(b, byte(v))
+			i = j - 1
+		case '0' <= c && c <= '7':
+			j := i
+			for j < len(s) && j < i+3 && '0' <= s[j] && s[j] <= '7' {
+				j++
+			}
+			v, err := strconv.ParseUint(s[i:j], 8, 8)
+			if err != nil {
+				return nil, fmt.Errorf("invalid octal escape in match value %q", s)
+			}
+			b = append(b, byte(v))
+			i = j - 1
+		default:
+			if e, ok := cEscapes[c]; ok {
+				b = append(b, e)
+			} else {
+				b = append(b, c)
+			}
+		}
+	}
+	return b, nil
+}
+
+var cEscapes = map[byte]byte{'a': '\a', 'b': '\b', 'f': '\f', 'n': '\n', 'r': '\r', 't': '\t', 'v': '\v'}
+
+func isHex(c byte) bool {
+	return '0' <= c && c <= '9' || 'a' <= c && c <= 'f' || 'A' <= c && c <= 'F'
+}
+
+// parseHexMask parses the "0x" hexadecimal mask of a string match of n bytes.
+func parseHexMask(s string, n int) ([]byte, error) {
+	hex := strings.TrimPrefix(strings.TrimPrefix(s, "0x"), "0X")
+	if len(hex) != 2*n {
+		return nil, fmt.Errorf("mask %q does not match the length of the value", s)
+	}
+	b := make([]byte, n)
+	for i := range b {
+		v, err := strconv.ParseUint(hex[2*i:2*i+2], 16, 8)
+		if err != nil {
+			return nil, fmt.Errorf("invalid mask %q", s)
+		}
+		b[i] = byte(v)
+	}
+	return b, nil
+}
+
+// sortedTypes returns the compiled types sorted by name.
+func (c *compiler) sortedTypes() []*compiledType {
+	types := make([]*compiledType, 0, len(c.types))
+	for _, t := range c.types {
+		types = append(types, t)
+	}
+	sort.Slice(types, func(i, j int) bool { return types[i].name < types[j].name })
+	return types
+}
+
+// globs returns all the globs, with the __NOGLOBS__ markers, sorted by weight, pattern and MIME type.
+func (c *compiler) globs() []glob {
+	var globs []glob
+	for _, t := range c.sortedTypes() {
+		if t.deleteGlobs {
+			globs = append(globs, glob{weight: DefaultWeight, mimeType: t.name, pattern: noGlobsPattern})
+		}
+		globs = append(globs, t.globs...)
+	}
+	sort.SliceStable(globs, func(i, j int) bool {
+		a, b := globs[i], globs[j]
+		if a.weight != b.weight {
+			return a.weight > b.weight
+		}
+		if a.pattern != b.pattern {
+			return a.pattern < b.pattern
+		}
+		return a.mimeType < b.mimeType
+	})
+	return globs
+}
+
+// magic returns all the magic sections, with the __NOMAGIC__ markers, sorted by priority and MIME type.
+func (c *compiler) magic() []*magicSection {
+	var sections []*magicSection
+	for _, t := range c.sortedTypes() {
+		if t.deleteMagic {
+			sections = append(sections, &magicSection{
+				priority: DefaultWeight,
+				mimeType: t.name,
+				rules:    []*magicRule{{rangeLen: 1, wordSize: 1, value: []byte(noMagicValue)}},
+			})
+		}
+		sections = append(sections, t.magic...)
+	}
+	sort.SliceStable(sections, func(i, j int) bool {
+		if sections[i].priority != sections[j].priority {
+			return sections[i].priority > sections[j].priority
+		}
+		return sections[i].mimeType < sections[j].mimeType
+	})
+	return sections
+}
+
+// pairs returns the sorted pairs of the MIME types and the values fn returns for them.
+func (c *compiler) pairs(fn func(t *compiledType) [][2]string) [][2]string {
+	var pairs [][2]string
+	for _, t := range c.sortedTypes() {
+		pairs = append(pairs, fn(t)...)
+	}
+	sort.SliceStable(pairs, func(i, j int) bool {
+		if pairs[i][0] != pairs[j][0] {
+			return pairs[i][0] < pairs[j][0]
+		}
+		return pairs[i][1] < pairs[j][1]
+	})
+	return pairs
+}
+
+func (c *compiler) aliases() [][2]string {
+	return c.pairs(func(t *compiledType) [][2]string {
+		var pairs [][2]string
+		for _, a := range t.aliases {
+			pairs = append(pairs, [2]string{a, t.name})
+		}
+		return pairs
+	})
+}
+
+func (c *compiler) subclasses() [][2]string {
+	var pairs [][2]string
+	for _, t := range c.sortedTypes() {
+		for _, p := range t.parents {
+			pairs = append(pairs, [2]string{t.name, p})
+		}
+	}
+	return pairs
+}
+
+func (c *compiler) icons(generic bool) [][2]string {
+	return c.pairs(func(t *compiledType) [][2]string {
+		icon := t.icon
+		if generic {
+			icon = t.genericIcon
+		}
+		if icon == "" {
+			return nil
+		}
+		return [][2]string{{t.name, icon}}
+	})
+}
+
+// files returns the contents of the generated files.
+func (c *compiler) files() map[string][]byte {
+	globs := c.globs()
+	var globs1, globs2 bytes.Buffer
+	globs1.WriteString(generatedHeader)
+	globs2.WriteString(generatedHeader)
+	for _, g := range globs {
+		fmt.Fprintf(&globs1, "%s:%s\n", g.mimeType, g.pattern)
+		fmt.Fprintf(&globs2, "%d:%s:%s", g.weight, g.mimeType, g.pattern)
+		if g.caseSensitive {
+			globs2.WriteString(":cs")
+		}
+		globs2.WriteByte('\n')
+	}
+
+	magic := c.magic()
+	var magicBuf bytes.Buffer
+	magicBuf.WriteString(magicHeader)
+	for _, s := range magic {
+		fmt.Fprintf(&magicBuf, "[%d:%s]\n", s.priority, s.mimeType)
+		writeRules(&magicBuf, s.rules, 0)
+	}
+
+	writePairs := func(pairs [][2]string) []byte {
+		var b bytes.Buffer
+		for _, p := range pairs {
+			fmt.Fprintf(&b, "%s %s\n", p[0], p[1])
+		}
+		return b.Bytes()
+	}
+	var types bytes.Buffer
+	for _, t := range c.sortedTypes() {
+		fmt.Fprintln(&types, t.name)
+	}
+
+	return map[string][]byte{
+		GlobsFile:        globs1.Bytes(),
+		Globs2File:       globs2.Bytes(),
+		MagicFile:        magicBuf.Bytes(),
+		AliasesFile:      writePairs(c.aliases()),
+		SubclassesFile:   writePairs(c.subclasses()),
+		TypesFile:        types.Bytes(),
+		IconsFile:        writePairs(c.icons(false)),
+		GenericIconsFile: writePairs(c.icons(true)),
+		CacheFile:        c.cache(globs, magic),
+	}
+}
+
+// writeRules writes the magic rules at the indent level.
+func writeRules(b *bytes.Buffer, rules []*magicRule, indent int) {
+	for _, r := range rules {
+		if indent > 0 {
+			b.WriteString(strconv.Itoa(indent))
+		}
+		fmt.Fprintf(b, ">%d=", r.offset)
+		binary.Write(b, binary.BigEndian, uint16(len(r.value)))
+		b.Write(r.value)
+		if r.mask != nil {
+			b.WriteByte('&')
+			b.Write(r.mask)
+		}
+		if r.wordSize != 1 {
+			fmt.Fprintf(b, "~%d", r.wordSize)
+		}
+		if r.rangeLen != 1 {
+			fmt.Fprintf(b, "+%d", r.rangeLen)
+		}
+		b.WriteByte('\n')
+		writeRules(b, r.children, indent+1)
+	}
+}
@@ -0,0 +1,222 @@
+// Copyright 2026 The go-xdgbasedir Authors. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package sharedmime_test
+
+import (
+	"bytes"
+	"os"
+	"reflect"
+	"strings"
+	"testing"
+
+	"github.com/zchee/go-xdgbasedir/sharedmime"
+	"github.com/zchee/go-xdgbasedir/xdgtest"
+)
+
+const basePackage = `<?xml version="1.0" encoding="UTF-8"?>
+<mime-info xmlns="http://www.freedesktop.org/standards/shared-mime-info">
+  <mime-type type="text/markdown">
+    <comment>Markdown document</comment>
+    <sub-class-of type="text/plain"/>
+    <alias type="text/x-markdown"/>
+    <generic-icon name="text-x-generic"/>
+    <glob pattern="*.md"/>
+    <glob pattern="*.markdown" weight="60"/>
+  </mime-type>
+  <mime-type type="image/png">
+    <icon name="image-png-custom"/>
+    <magic priority="50">
+      <match type="string" value="\x89PNG" offset="0"/>
+    </magic>
+    <glob pattern="*.png"/>
+  </mime-type>
+  <mime-type type="text/x-c++src">
+    <sub-class-of type="text/x-csrc"/>
+    <glob pattern="*.C" case-sensitive="true"/>
+    <glob pattern="*.cpp"/>
+  </mime-type>
+  <mime-type type="text/x-csrc">
+    <sub-class-of type="text/plain"/>
+    <glob pattern="*.c"/>
+  </mime-type>
+  <mime-type type="application/x-makefile">
+    <glob pattern="Makefile" case-sensitive="true"/>
+    <glob pattern="makefile"/>
+    <glob pattern="Makefile.*"/>
+  </mime-type>
+  <mime-type type="application/x-custom">
+    <root-XML namespaceURI="http://example.org/ns" localName="custom"/>
+    <magic priority="80">
+      <match type="string" value="CUST" offset="0:4">
+        <match type="host16" value="0x1234" offset="8"/>
+        <match type="big32" value="0xcafebabe" mask="0xffff0000" offset="8"/>
+      </match>
+    </magic>
+    <magic priority="60">
+      <match type="string" value="\0\001x" mask="0xff00ff" offset="0"/>
+    </magic>
+    <glob pattern="*.cust"/>
+  </mime-type>
+</mime-info>
+`
+
+const overridePackage = `<?xml version="1.0" encoding="UTF-8"?>
+<mime-info xmlns="http://www.freedesktop.org/standards/shared-mime-info">
+  <mime-type type="image/png">
+    <glob-deleteall/>
+    <magic-deleteall/>
+    <glob pattern="*.portable"/>
+  </mime-type>
+</mime-info>
+`
+
+func TestCompile(t *testing.T) {
+	t.Parallel()
+
+	tr := xdgtest.New(t, xdgtest.WithFiles(map[string]string{
+		"home/.local/share/mime/packages/base.xml":     basePackage,
+		"home/.local/share/mime/packages/Override.xml": overridePackage,
+		"home/.local/share/mime/packages/README":       "ignored",
+	}))
+	r := tr.Resolver()
+	dir := tr.Path("home/.local/share/mime")
+
+	files, err := sharedmime.Compile(r.FS(), dir)
+	if err != nil {
+		t.Fatal(err)
+	}
+	again, err := sharedmime.Compile(r.FS(), dir)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if !reflect.DeepEqual(files, again) {
+		t.Error("Compile() is not deterministic")
+	}
+
+	want := map[string]string{
+		sharedmime.Globs2File: `# This file was automatically generated by the
+# update-mime-database command. DO NOT EDIT!
+60:text/markdown:*.markdown
+50:text/x-c++src:*.C:cs
+50:text/x-csrc:*.c
+50:text/x-c++src:*.cpp
+50:application/x-custom:*.cust
+50:text/markdown:*.md
+50:image/png:*.portable
+50:application/x-makefile:Makefile:cs
+50:application/x-makefile:Makefile.*
+50:image/png:__NOGLOBS__
+50:application/x-makefile:makefile
+`,
+		sharedmime.AliasesFile:      "text/x-markdown text/markdown\n",
+		sharedmime.SubclassesFile:   "text/markdown text/plain\ntext/x-c++src text/x-csrc\ntext/x-csrc text/plain\n",
+		sharedmime.IconsFile:        "image/png image-png-custom\n",
+		sharedmime.GenericIconsFile: "text/markdown text-x-generic\n",
+		sharedmime.TypesFile:        "application/x-custom\napplication/x-makefile\nimage/png\ntext/markdown\ntext/x-c++src\ntext/x-csrc\n",
+	}
+	for name, content := range want {
+		if got := string(files[name]); got != content {
+			t.Errorf("%s =\n%s\nwant\n%s", name, got, content)
+		}
+	}
+	if !bytes.HasPrefix(files[sharedmime.MagicFile], []byte("MIME-Magic\x00\n[80:application/x-custom]\n>0=\x00\x04CUST+5\n1>8=\x00\x02\x12\x34~2\n1>8=\x00\x04\xca\xfe\xba\xbe&\xff\xff\x00\x00\n[60:application/x-custom]\n>0=\x00\x03\x00\x01x&\xff\x00\xff\n[50:image/png]\n>0=\x00\x0b__NOMAGIC__\n")) {
+		t.Errorf("magic = %q", files[sharedmime.MagicFile])
+	}
+
+	if err := sharedmime.Update(r, ""); err != nil {
+		t.Fatal(err)
+	}
+	for name, content := range files {
+		tr.AssertFileContent("home/.local/share/mime/"+name, string(content))
+	}
+	if _, err := sharedmime.LoadCache(r.FS(), dir); err != nil {
+		t.Fatalf("LoadCache() of the updated cache: %v", err)
+	}
+
+	word := "\x12\x34"
+	if littleEndian() {
+		word = "\x34\x12"
+	}
+	check := func(t *testing.T, db *sharedmime.Database) {
+		t.Helper()
+		names := map[string]string{
+			"notes.md":       "text/markdown",
+			"NOTES.MD":       "text/markdown",
+			"a.markdown":     "text/markdown",
+			"main.C":         "text/x-c++src",
+			"main.c":         "text/x-csrc",
+			"main.CPP":       "text/x-c++src",
+			"Makefile":       "application/x-makefile",
+			"MAKEFILE":       "application/x-makefile",
+			"Makefile.am":    "application/x-makefile",
+			"image.png":      "",
+			"image.PORTABLE": "image/png",
+		}
+		for name, want := range names {
+			if got := db.TypeByName(name); got != want {
+				t.Errorf("TypeByName(%q) = %q, want %q", name, got, want)
+			}
+		}
+		contents := map[string]string{
+			"..CUST.." + word:          "application/x-custom",
+			"CUST....\xca\xfe\x00\x00": "application/x-custom",
+			"CUST....\x00\x00":         sharedmime.OctetStream,
+			"\x00\xff" + "x":           "application/x-custom",
+			"\x89PNG\r\n\x1a\n":        sharedmime.OctetStream,
+		}
+		for data, want := range contents {
+			if got := db.TypeByContent([]byte(data)); got != want {
+				t.Errorf("TypeByContent(%q) = %q, want %q", data, got, want)
+			}
+		}
+		if !db.IsA("text/x-c++src", "text/plain") || !db.IsA("text/x-markdown", "text/plain") {
+			t.Error("IsA() does not follow the compiled subclasses and aliases")
+		}
+		if got := db.Icon("image/png"); got != "image-png-custom" {
+			t.Errorf("Icon(image/png) = %q", got)
+		}
+	}
+
+	t.Run("cache", func(t *testing.T) {
+		db, err := sharedmime.Load(r)
+		if err != nil {
+			t.Fatal(err)
+		}
+		check(t, db)
+	})
+	t.Run("text", func(t *testing.T) {
+		if err := os.Remove(tr.Path("home/.local/share/mime/mime.cache")); err != nil {
+			t.Fatal(err)
+		}
+		db, err := sharedmime.Load(r)
+		if err != nil {
+			t.Fatal(err)
+		}
+		check(t, db)
+	})
+}
+
+func TestCompileError(t *testing.T) {
+	t.Parallel()
+
+	tests := map[string]string{
+		"malformed":    "<mime-info><mime-type",
+		"invalid type": `<mime-info><mime-type type="text"/></mime-info>`,
+		"match type":   `<mime-info><mime-type type="a/b"><magic><match type="regex" value="x" offset="0"/></magic></mime-type></mime-info>`,
+		"offset":       `<mime-info><mime-type type="a/b"><magic><match type="string" value="x" offset="4:2"/></magic></mime-type></mime-info>`,
+		"mask":         `<mime-info><mime-type type="a/b"><magic><match type="string" value="xy" mask="0xff" offset="0"/></magic></mime-type></mime-info>`,
+		"value":        `<mime-info><mime-type type="a/b"><magic><match type="byte" value="256" offset="0"/></magic></mime-type></mime-info>`,
+		"weight":       `<mime-info><mime-type type="a/b"><glob pattern="*.b" weight="heavy"/></mime-type></mime-info>`,
+		"glob colon":   `<mime-info><mime-type type="a/b"><glob pattern="*.a:b"/></mime-type></mime-info>`,
+		"glob newline": `<mime-info><mime-type type="a/b"><glob pattern="*.a&#10;b"/></mime-type></mime-info>`,
+		"long value":   `<mime-info><mime-type type="a/b"><magic><match type="string" value="` + strings.Repeat("x", 1<<16) + `" offset="0"/></magic></mime-type></mime-info>`,
+	}
+	for name, pkg := range tests {
+		tr := xdgtest.New(t, xdgtest.WithFiles(map[string]string{"mime/packages/p.xml": pkg}))
+		if _, err := sharedmime.Compile(tr.Resolver().FS(), tr.Path("mime")); err == nil {
+			t.Errorf("%s: Compile() succeeded", name)
+		}
+	}
+}
@@ -0,0 +1,353 @@
+// Copyright 2026 The go-xdgbasedir Authors. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package xdgbasedir
+
+import (
+	"fmt"
+	"io/fs"
+	"path/filepath"
+
+	"github.com/zchee/go-xdgbasedir/internal/userdirsfile"
+)
+
+// StandardLocation is a standard location of Qt's QStandardPaths, resolved on top of the base directories.
+//
+// ref: https://doc.qt.io/qt-6/qstandardpaths.html
+type StandardLocation int
+
+// List of the standard locations, in the order of QStandardPaths::StandardLocation.
+const (
+	DesktopLocation StandardLocation = iota
+	DocumentsLocation
+	FontsLocation
+	ApplicationsLocation
+	MusicLocation
+	MoviesLocation
+	PicturesLocation
+	TempLocation
+	HomeLocation
+	AppLocalDataLocation
+	CacheLocation
+	GenericDataLocation
+	RuntimeLocation
+	ConfigLocation
+	DownloadLocation
+	GenericCacheLocation
+	GenericConfigLocation
+	AppDataLocation
+	AppConfigLocation
+	PublicShareLocation
+	TemplatesLocation
+	StateLocation
+	GenericStateLocation
+)
+
+var locationNames = [...]string{
+	DesktopLocation:       "Desktop",
+	DocumentsLocation:     "Documents",
+	FontsLocation:         "Fonts",
+	ApplicationsLocation:  "Applications",
+	MusicLocation:         "Music",
+	MoviesLocation:        "Movies",
+	PicturesLocation:      "Pictures",
+	TempLocation:          "Temp",
+	HomeLocation:          "Home",
+	AppLocalDataLocation:  "AppLocalData",
+	CacheLocation:         "Cache",
+	GenericDataLocation:   "GenericData",
+	RuntimeLocation:       "Runtime",
+	ConfigLocation:        "Config",
+	DownloadLocation:      "Download",
+	GenericCacheLocation:  "GenericCache",
+	GenericConfigLocation: "GenericConfig",
+	AppDataLocation:       "AppData",
+	AppConfigLocation:     "AppConfig",
+	PublicShareLocation:   "PublicShare",
+	TemplatesLocation:     "Templates",
+	StateLocation:         "State",
+	GenericStateLocation:  "GenericState",
+}
+
+// String implements fmt.Stringer.
+func (loc StandardLocation) String() string {
+	if loc >= 0 && int(loc) < len(locationNames) {
+		return locationNames[loc]
+	}
+	return fmt.Sprintf("StandardLocation(%d)", int(loc))
+}
+
+// WithAppName sets the application name appended to the AppDataLocation, AppLocalDataLocation, AppConfigLocation,
+// CacheLocation and StateLocation standard locations. name may be a slash separated path such as "example.org/app".
+func WithAppName(name string) Option {
+	return func(r *Resolver) {
+		r.app = name
+	}
+}
+
+// Writable returns the directory where files of loc should be written to, or an empty string if there is none.
+func Writable(loc StandardLocation) string {
+	return std.Writable(loc)
+}
+
+// Standard returns the directories of loc in precedence order. The first one is the Writable directory.
+func Standard(loc StandardLocation) []string {
+	return std.Standard(loc)
+}
+
+// Locate searches the name file or directory in the Standard directories of loc, and returns the first existing path.
+func Locate(loc StandardLocation, name string) (string, error) {
+	return std.Locate(loc, name)
+}
+
+// Writable returns the directory where files of loc should be written to resolved by r, or an empty string if there is none.
+func (r *Resolver) Writable(loc StandardLocation) string {
+	if dirs := r.Standard(loc); len(dirs) > 0 {
+		return dirs[0]
+	}
+	return ""
+}
+
+// Standard returns the directories of loc resolved by r in precedence order, for r's platform Profile.
+// The first one is the Writable directory.
+func (r *Resolver) Standard(loc StandardLocation) []string {
+	var dirs []string
+	switch r.profile() {
+	case ProfileDarwin:
+		dirs = r.darwinLocation(loc)
+	case ProfileWindows:
+		dirs = r.windowsLocation(loc)
+	default:
+		dirs = r.unixLocation(loc)
+	}
+
+	var out []string
+	seen := make(map[string]bool)
+	for _, dir := range dirs {
+		if dir != "" && !seen[dir] {
+			seen[dir] = true
+			out = append(out, dir)
+		}
+	}
+	return out
+}
+
+// Locate searches the name file or directory in the Standard directories of loc resolved by r, and returns the
+// first existing path.
+func (r *Resolver) Locate(loc StandardLocation, name string) (string, error) {
+	for _, dir := range r.Standard(loc) {
+		path := filepath.Join(dir, name)
+		if r.Exists(path) {
+			return path, nil
+		}
+	}
+	return "", fmt.Errorf("xdgbasedir: %s: %s: %w", loc, name, fs.ErrNotExist)
+}
+
+// unixLocation returns the directories of loc for ProfileUnix, same as QStandardPaths on linux.
+func (r *Resolver) unixLocation(loc StandardLocation) []string {
+	home := r.Home()
+	switch loc {
+	case DesktopLocation:
+		return []string{r.userDir("DESKTOP", "Desktop")}
+	case DocumentsLocation:
+		return []string{r.userDir("DOCUMENTS", "Documents")}
+	case MusicLocation:
+		return []string{r.userDir("MUSIC", "Music")}
+	case MoviesLocation:
+		return []string{r.userDir("VIDEOS", "Videos")}
+	case PicturesLocation:
+		return []string{r.userDir("PICTURES", "Pictures")}
+	case DownloadLocation:
+		return []string{r.userDir("DOWNLOAD", "Downloads")}
+	case PublicShareLocation:
+		return []string{r.userDir("PUBLICSHARE", "Public")}
+	case TemplatesLocation:
+		return []string{r.userDir("TEMPLATES", "Templates")}
+	case FontsLocation:
+		return append([]string{filepath.Join(r.DataHome(), "fonts"), filepath.Join(home, ".fonts")}, subdirs(splitList(r.DataDirs()), "fonts")...)
+	case ApplicationsLocation:
+		return r.dataLocation("applications")
+	case TempLocation:
+		return []string{r.getenvDefault("TMPDIR", "/tmp")}
+	case HomeLocation:
+		return []string{home}
+	case AppDataLocation, AppLocalDataLocation:
+		return r.dataLocation(r.app)
+	case GenericDataLocation:
+		return r.dataLocation("")
+	case CacheLocation:
+		return []string{r.appDir(r.CacheHome())}
+	case GenericCacheLocation:
+		return []string{r.CacheHome()}
+	case RuntimeLocation:
+		return []string{r.RuntimeDir()}
+	case ConfigLocation, GenericConfigLocation:
+		return r.configLocation("")
+	case AppConfigLocation:
+		return r.configLocation(r.app)
+	case StateLocation:
+		return []string{r.appDir(r.StateHome())}
+	case GenericStateLocation:
+		return []string{r.StateHome()}
+	}
+	return nil
+}
+
+// darwinLocation returns the directories of loc for ProfileDarwin, same as QStandardPaths on macOS.
+func (r *Resolver) darwinLocation(loc StandardLocation) []string {
+	home := r.Home()
+	switch loc {
+	case DesktopLocation:
+		return []string{filepath.Join(home, "Desktop")}
+	case DocumentsLocation:
+		return []string{filepath.Join(home, "Documents")}
+	case MusicLocation:
+		return []string{filepath.Join(home, "Music")}
+	case MoviesLocation:
+		return []string{filepath.Join(home, "Movies")}
+	case PicturesLocation:
+		return []string{filepath.Join(home, "Pictures")}
+	case DownloadLocation:
+		return []string{filepath.Join(home, "Downloads")}
+	case PublicShareLocation:
+		return []string{filepath.Join(home, "Public")}
+	case TemplatesLocation:
+		return []string{filepath.Join(home, "Templates")}
+	case FontsLocation:
+		return []string{filepath.Join(home, "Library", "Fonts"), filepath.Join("/Library", "Fonts"), filepath.Join("/System", "Library", "Fonts")}
+	case ApplicationsLocation:
+		return []string{filepath.Join(home, "Applications"), "/Applications"}
+	case TempLocation:
+		return []string{r.getenvDefault("TMPDIR", "/tmp")}
+	case HomeLocation:
+		return []string{home}
+	case AppDataLocation, AppLocalDataLocation:
+		return []string{r.appDir(r.DataHome()), r.appDir(filepath.Join("/Library", "Application Support"))}
+	case GenericDataLocation:
+		return []string{r.DataHome(), filepath.Join("/Library", "Application Support")}
+	case CacheLocation:
+		return []string{r.appDir(r.CacheHome()), r.appDir(filepath.Join("/Library", "Caches"))}
+	case GenericCacheLocation:
+		return []string{r.CacheHome(), filepath.Join("/Library", "Caches")}
+	case RuntimeLocation:
+		return []string{r.RuntimeDir()}
+	case ConfigLocation, GenericConfigLocation:
+		return []string{r.ConfigHome(), filepath.Join("/Library", "Preferences")}
+	case AppConfigLocation:
+		return []string{r.appDir(r.ConfigHome()), r.appDir(filepath.Join("/Library", "Preferences"))}
+	case StateLocation:
+		return []string{r.appDir(r.StateHome())}
+	case GenericStateLocation:
+		return []string{r.StateHome()}
+	}
+	return nil
+}
+
+// windowsLocation returns the directories of loc for ProfileWindows, same as QStandardPaths on windows.
+func (r *Resolver) windowsLocation(loc StandardLocation) []string {
+	home := r.Home()
+	localAppData := r.getenvDefault("LOCALAPPDATA", filepath.Join(home, "AppData", "Local"))
+	programData := r.getenvDefault("PROGRAMDATA", `C:\ProgramData`)
+	switch loc {
+	case DesktopLocation:
+		return []string{filepath.Join(home, "Desktop")}
+	case DocumentsLocation:
+		return []string{filepath.Join(home, "Documents")}
+	case MusicLocation:
+		return []string{filepath.Join(home, "Music")}
+	case MoviesLocation:
+		return []string{filepath.Join(home, "Videos")}
+	case PicturesLocation:
+		return []string{filepath.Join(home, "Pictures")}
+	case DownloadLocation:
+		return []string{filepath.Join(home, "Downloads")}
+	case PublicShareLocation:
+		return []string{r.getenvDefault("PUBLIC", filepath.Join(filepath.Dir(home), "Public"))}
+	case TemplatesLocation:
+		return []string{filepath.Join(r.DataHome(), "Microsoft", "Windows", "Templates")}
+	case FontsLocation:
+		return []string{filepath.Join(localAppData, "Microsoft", "Windows", "Fonts"), filepath.Join(r.getenvDefault("WINDIR", `C:\Windows`), "Fonts")}
+	case ApplicationsLocation:
+		return []string{filepath.Join(r.DataHome(), "Microsoft", "Windows", "Start Menu", "Programs"), filepath.Join(programData, "Microsoft", "Windows", "Start Menu", "Programs")}
+	case TempLocation:
+		return []string{r.getenvDefault("TEMP", r.getenvDefault("TMP", filepath.Join(localAppData, "Temp")))}
+	case HomeLocation:
+		return []string{home}
+	case AppDataLocation:
+		return []string{r.appDir(r.DataHome()), r.appDir(programData)}
+	case AppLocalDataLocation:
+		return []string{r.appDir(localAppData), r.appDir(programData)}
+	case GenericDataLocation:
+		return []string{r.DataHome(), programData}
+	case CacheLocation:
+		return []string{r.appDir(r.CacheHome())}
+	case GenericCacheLocation:
+		return []string{r.CacheHome()}
+	case RuntimeLocation:
+		return []string{r.RuntimeDir()}
+	case ConfigLocation, GenericConfigLocation:
+		return []string{r.ConfigHome(), programData}
+	case AppConfigLocation:
+		return []string{r.appDir(r.ConfigHome()), r.appDir(programData)}
+	case StateLocation:
+		return []string{r.appDir(r.StateHome())}
+	case GenericStateLocation:
+		return []string{r.StateHome()}
+	}
+	return nil
+}
+
+// dataLocation returns the rel directory of r's DataHome and each DataDirs.
+func (r *Resolver) dataLocation(rel string) []string {
+	return subdirs(append([]string{r.DataHome()}, splitList(r.DataDirs())...), rel)
+}
+
+// configLocation returns the rel directory of r's ConfigHome and each ConfigDirs.
+func (r *Resolver) configLocation(rel string) []string {
+	return subdirs(append([]string{r.ConfigHome()}, splitList(r.ConfigDirs())...), rel)
+}
+
+// appDir returns the application directory of r under dir.
+func (r *Resolver) appDir(dir string) string {
+	if r.app == "" {
+		return dir
+	}
+	return filepath.Join(dir, filepath.FromSlash(r.app))
+}
+
+// userDir returns the xdg-user-dirs directory name, or def under the home directory if it is not set.
+func (r *Resolver) userDir(name, def string) string {
+	home := r.Home()
+	if v := r.getenv("XDG_" + name + "_DIR"); v != "" {
+		if dir, ok := userdirsfile.Expand(v, home); ok {
+			return dir
+		}
+	}
+	if data, err := r.FS().ReadFile(filepath.Join(r.ConfigHome(), "user-dirs.dirs")); err == nil {
+		if dir, ok := userdirsfile.Lookup(userdirsfile.Parse(data, home), name); ok {
+			return dir
+		}
+	}
+	return filepath.Join(home, def)
+}
+
+// getenvDefault returns the value of the environment variable named by the key from r's environment, or def if it is empty.
+func (r *Resolver) getenvDefault(key, def string) string {
+	if v := r.getenv(key); v != "" {
+		return v
+	}
+	return def
+}
+
+// subdirs returns the rel directory of each dirs.
+func subdirs(dirs []string, rel string) []string {
+	out := make([]string, 0, len(dirs))
+	for _, dir := range dirs {
+		if dir != "" {
+			out = append(out, filepath.Join(dir, filepath.FromSlash(rel)))
+		}
+	}
+	return out
+}
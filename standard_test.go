@@ -0,0 +1,115 @@
+// Copyright 2026 The go-xdgbasedir Authors. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package xdgbasedir
+
+import (
+	"errors"
+	"io/fs"
+	"path/filepath"
+	"reflect"
+	"testing"
+)
+
+func TestStandardLocation(t *testing.T) {
+	home := filepath.Join("/home", "gopher")
+	tests := []struct {
+		name    string
+		profile Profile
+		env     []string
+		files   map[string]string
+		want    map[StandardLocation][]string
+	}{
+		{
+			name:    "unix",
+			profile: ProfileUnix,
+			env:     []string{"XDG_MUSIC_DIR=$HOME/Audio", "TMPDIR=/var/tmp"},
+			files: map[string]string{
+				filepath.Join(home, ".config", "user-dirs.dirs"): "XDG_DESKTOP_DIR=\"$HOME/Schreibtisch\"\nXDG_MUSIC_DIR=\"$HOME/Musik\"\n",
+			},
+			want: map[StandardLocation][]string{
+				DesktopLocation:       {filepath.Join(home, "Schreibtisch")},
+				MusicLocation:         {filepath.Join(home, "Audio")},
+				MoviesLocation:        {filepath.Join(home, "Videos")},
+				TempLocation:          {filepath.Join("/var", "tmp")},
+				HomeLocation:          {home},
+				AppDataLocation:       {filepath.Join(home, ".local", "share", "org", "app"), filepath.Join("/usr", "local", "share", "org", "app"), filepath.Join("/usr", "share", "org", "app")},
+				GenericConfigLocation: {filepath.Join(home, ".config"), filepath.Join("/etc", "xdg")},
+				AppConfigLocation:     {filepath.Join(home, ".config", "org", "app"), filepath.Join("/etc", "xdg", "org", "app")},
+				CacheLocation:         {filepath.Join(home, ".cache", "org", "app")},
+				StateLocation:         {filepath.Join(home, ".local", "state", "org", "app")},
+				RuntimeLocation:       {filepath.Join("/run", "user", "1000")},
+				FontsLocation:         {filepath.Join(home, ".local", "share", "fonts"), filepath.Join(home, ".fonts"), filepath.Join("/usr", "local", "share", "fonts"), filepath.Join("/usr", "share", "fonts")},
+			},
+		},
+		{
+			name:    "darwin",
+			profile: ProfileDarwin,
+			want: map[StandardLocation][]string{
+				DesktopLocation:      {filepath.Join(home, "Desktop")},
+				MoviesLocation:       {filepath.Join(home, "Movies")},
+				TempLocation:         {"/tmp"},
+				AppDataLocation:      {filepath.Join(home, "Library", "Application Support", "org", "app"), filepath.Join("/Library", "Application Support", "org", "app")},
+				GenericCacheLocation: {filepath.Join(home, "Library", "Caches"), filepath.Join("/Library", "Caches")},
+				ApplicationsLocation: {filepath.Join(home, "Applications"), "/Applications"},
+			},
+		},
+		{
+			name:    "windows",
+			profile: ProfileWindows,
+			env:     []string{"PROGRAMDATA=" + filepath.Join("/ProgramData"), "TEMP=" + filepath.Join("/Temp")},
+			want: map[StandardLocation][]string{
+				MoviesLocation:       {filepath.Join(home, "Videos")},
+				TempLocation:         {filepath.Join("/Temp")},
+				AppDataLocation:      {filepath.Join(home, "AppData", "Roaming", "org", "app"), filepath.Join("/ProgramData", "org", "app")},
+				AppLocalDataLocation: {filepath.Join(home, "AppData", "Local", "org", "app"), filepath.Join("/ProgramData", "org", "app")},
+				GenericDataLocation:  {filepath.Join(home, "AppData", "Roaming"), filepath.Join("/ProgramData")},
+			},
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			r := New(
+				WithHermetic(Hermetic{Home: home, UID: 1000, Profile: tt.profile, Env: tt.env}),
+				WithEnvGuard(func(key string) { t.Errorf("read process env %s", key) }),
+				WithFileSystem(NewMemFS(tt.files)),
+				WithAppName("org/app"),
+			)
+			for loc, want := range tt.want {
+				if got := r.Standard(loc); !reflect.DeepEqual(got, want) {
+					t.Errorf("Standard(%s) = %v, want %v", loc, got, want)
+				}
+				if got := r.Writable(loc); got != want[0] {
+					t.Errorf("Writable(%s) = %v, want %v", loc, got, want[0])
+				}
+			}
+		})
+	}
+}
+
+func TestLocate(t *testing.T) {
+	fsys := NewMemFS(map[string]string{
+		filepath.Join("/usr", "share", "applications", "app.desktop"):                        "system",
+		filepath.Join("/usr", "local", "share", "applications", "app.desktop"):               "local",
+		filepath.Join("/home", "gopher", ".local", "share", "applications", "other.desktop"): "user",
+	})
+	r := memResolver(fsys)
+
+	got, err := r.Locate(ApplicationsLocation, "app.desktop")
+	if want := filepath.Join("/usr", "local", "share", "applications", "app.desktop"); err != nil || got != want {
+		t.Errorf("Locate() = %v, %v, want %v", got, err, want)
+	}
+	if _, err := r.Locate(ApplicationsLocation, "missing.desktop"); !errors.Is(err, fs.ErrNotExist) {
+		t.Errorf("Locate(missing) error = %v, want ErrNotExist", err)
+	}
+}
+
+func TestStandardLocationString(t *testing.T) {
+	if got := GenericConfigLocation.String(); got != "GenericConfig" {
+		t.Errorf("String() = %q", got)
+	}
+	if got := StandardLocation(-1).String(); got != "StandardLocation(-1)" {
+		t.Errorf("String() = %q", got)
+	}
+}
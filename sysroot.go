@@ -0,0 +1,196 @@
+// Copyright 2026 The go-xdgbasedir Authors. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package xdgbasedir
+
+import (
+	"bufio"
+	"bytes"
+	"errors"
+	"io/fs"
+	"path/filepath"
+	"strconv"
+	"strings"
+)
+
+// sysroot holds the alternate filesystem root configuration of a Resolver.
+type sysroot struct {
+	root    string
+	user    string
+	hostEnv bool
+}
+
+// WithSysroot makes the Resolver resolve the XDG base directories of the target root filesystem mounted at root,
+// such as an image being built or a chroot.
+//
+// The system directories such as /etc/xdg and /usr/share, and the values of the XDG environment variables,
+// are prefixed with root. The user home directory is looked up from the target's /etc/passwd, and the
+// Unix layout of the specification is used regardless of the host platform.
+//
+// The host process environment is not used unless WithHostEnv or WithEnv is also given.
+func WithSysroot(root string) Option {
+	return func(r *Resolver) {
+		r.sysroot.root = root
+	}
+}
+
+// WithSysrootUser selects the user of the target root filesystem by user name or numeric uid.
+// By default, the root user is used.
+func WithSysrootUser(user string) Option {
+	return func(r *Resolver) {
+		r.sysroot.user = user
+	}
+}
+
+// WithHostEnv allows a Resolver created WithSysroot to use the host process environment.
+func WithHostEnv() Option {
+	return func(r *Resolver) {
+		r.sysroot.hostEnv = true
+	}
+}
+
+// activate looks up the user home directory from the target root filesystem.
+func (s *sysroot) activate(r *Resolver) {
+	if s.root == "" {
+		return
+	}
+	if abs, err := filepath.Abs(s.root); err == nil {
+		s.root = abs
+	}
+	if r.env == nil && !s.hostEnv {
+		r.env = make(map[string]string)
+	}
+
+	user := s.user
+	if user == "" {
+		user = "root"
+	}
+	r.layout = &layout{profile: ProfileUnix}
+	if home, uid, err := lookupPasswd(r.FS(), filepath.Join(s.root, "etc", "passwd"), user); err == nil {
+		r.layout.home, r.layout.uid = home, uid
+		return
+	}
+
+	// fallback to the useradd(8) convention if the user is not found in the target's /etc/passwd.
+	// The uid of a user name is unknown then, so XDG_RUNTIME_DIR is left unset rather than pointing at root's.
+	r.layout.uid = -1
+	if uid, err := strconv.Atoi(user); err == nil {
+		r.layout.uid = uid
+	}
+	switch user {
+	case "root", "0":
+		r.layout.home, r.layout.uid = "/root", 0
+	default:
+		r.layout.home = "/home/" + user
+	}
+}
+
+// errUserNotFound is returned by lookupPasswd if the user is not found.
+var errUserNotFound = errors.New("user not found")
+
+// lookupPasswd looks up the home directory and uid of user, a user name or numeric uid, from the passwd(5) file.
+func lookupPasswd(fsys FileSystem, passwd, user string) (home string, uid int, err error) {
+	data, err := fsys.ReadFile(passwd)
+	if err != nil {
+		return "", 0, err
+	}
+
+	sc := bufio.NewScanner(bytes.NewReader(data))
+	for sc.Scan() {
+		line := strings.TrimSpace(sc.Text())
+		if line == "" || line[0] == '#' {
+			continue
+		}
+		// name:password:UID:GID:GECOS:directory:shell
+		fields := strings.Split(line, ":")
+		if len(fields) < 7 {
+			continue
+		}
+		if fields[0] != user && fields[2] != user {
+			continue
+		}
+		uid, err := strconv.Atoi(fields[2])
+		if err != nil {
+			continue
+		}
+		return fields[5], uid, nil
+	}
+	if err := sc.Err(); err != nil {
+		return "", 0, err
+	}
+	return "", 0, errUserNotFound
+}
+
+// Sysroot returns the alternate filesystem root of r, if any.
+func (r *Resolver) Sysroot() (root string, ok bool) {
+	return r.sysroot.root, r.sysroot.root != ""
+}
+
+// prefix prefixes each element of the dirs list with the sysroot.
+func (s *sysroot) prefix(dirs string) string {
+	list := splitList(dirs)
+	for i, dir := range list {
+		list[i] = filepath.Join(s.root, dir)
+	}
+	return joinList(list)
+}
+
+// maxSymlinks is the maximum number of symbolic links followed by inRoot, same as Linux MAXSYMLINKS.
+const maxSymlinks = 40
+
+// inRoot resolves path, a host path under root, following symbolic links in fsys as if root were the filesystem root,
+// so that lookups never escape root. Nonexistent trailing elements are kept as is.
+func inRoot(fsys FileSystem, root, path string) (string, error) {
+	rel, err := filepath.Rel(root, path)
+	if err != nil {
+		return "", err
+	}
+
+	sep := string(filepath.Separator)
+	elems := strings.Split(rel, sep)
+	cur := root
+	links := 0
+	for len(elems) > 0 {
+		elem := elems[0]
+		elems = elems[1:]
+
+		switch elem {
+		case "", ".":
+			continue
+		case "..":
+			if cur != root {
+				cur = filepath.Dir(cur)
+			}
+			continue
+		}
+
+		next := filepath.Join(cur, elem)
+		fi, err := fsys.Lstat(next)
+		if err != nil {
+			if errors.Is(err, fs.ErrNotExist) {
+				return filepath.Join(append([]string{next}, elems...)...), nil
+			}
+			return "", err
+		}
+		if fi.Mode()&fs.ModeSymlink == 0 {
+			cur = next
+			continue
+		}
+
+		links++
+		if links > maxSymlinks {
+			return "", &fs.PathError{Op: "lstat", Path: path, Err: errors.New("too many levels of symbolic links")}
+		}
+		target, err := fsys.Readlink(next)
+		if err != nil {
+			return "", err
+		}
+		if filepath.IsAbs(target) {
+			cur = root
+		}
+		elems = append(strings.Split(filepath.Clean(target), sep), elems...)
+	}
+
+	return cur, nil
+}
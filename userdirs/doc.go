@@ -0,0 +1,17 @@
+// Copyright 2026 The go-xdgbasedir Authors. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+// Package userdirs implements the xdg-user-dirs well known user directories, such as Desktop and Downloads.
+//
+//	https://www.freedesktop.org/wiki/Software/xdg-user-dirs/
+//
+// The directories are read from $XDG_CONFIG_HOME/user-dirs.dirs, a shell fragment of the form
+//
+//	XDG_DESKTOP_DIR="$HOME/Desktop"
+//	XDG_DOWNLOAD_DIR="/data/downloads"
+//
+// and the XDG_*_DIR environment variables override it. Same as xdg-user-dir, a missing directory falls back to
+// the home directory, except Desktop which falls back to $HOME/Desktop, and a directory set to the home directory
+// itself is disabled.
+package userdirs // import "github.com/zchee/go-xdgbasedir/userdirs"
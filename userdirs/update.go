@@ -0,0 +1,287 @@
+// Copyright 2026 The go-xdgbasedir Authors. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package userdirs
+
+import (
+	"bufio"
+	"bytes"
+	"errors"
+	"fmt"
+	"io/fs"
+	"path/filepath"
+	"strings"
+
+	"github.com/zchee/go-xdgbasedir"
+	"github.com/zchee/go-xdgbasedir/internal/userdirsfile"
+	"github.com/zchee/go-xdgbasedir/locale"
+)
+
+// List of the files of xdg-user-dirs-update.
+const (
+	// DefaultsFile is the name of the system defaults file under the XDG_CONFIG_DIRS directories.
+	DefaultsFile = "user-dirs.defaults"
+	// LocaleFile is the name of the file under the XDG_CONFIG_HOME directory which records the locale
+	// the user directories were created for.
+	LocaleFile = "user-dirs.locale"
+)
+
+// userDirPerm is the permission of the created user directories.
+const userDirPerm = 0755
+
+// filePerm is the permission of the written user-dirs.dirs and user-dirs.locale.
+const filePerm = 0644
+
+// Defaults is the set of the default user directories used when no user-dirs.defaults is found, relative to the
+// home directory.
+var Defaults = map[Name]string{
+	Desktop:     "Desktop",
+	Download:    "Downloads",
+	Templates:   "Templates",
+	PublicShare: "Public",
+	Documents:   "Documents",
+	Music:       "Music",
+	Pictures:    "Pictures",
+	Videos:      "Videos",
+}
+
+// Catalog is a message catalog which translates the default directory names, keyed by locale such as "de" or
+// "pt_BR" and then by the untranslated name such as "Desktop".
+type Catalog map[string]map[string]string
+
+// translate translates each element of the relative path rel for the first matching locale of prefs.
+func (c Catalog) translate(prefs []string, rel string) string {
+	elems := strings.Split(rel, "/")
+	for i, elem := range elems {
+		for _, pref := range prefs {
+			if msg, ok := c[pref][elem]; ok && msg != "" {
+				elems[i] = msg
+				break
+			}
+		}
+	}
+	return strings.Join(elems, "/")
+}
+
+// Option configures Update.
+type Option func(*options)
+
+type options struct {
+	catalog Catalog
+}
+
+// WithCatalog translates the default directory names for the locale of the Resolver with c.
+func WithCatalog(c Catalog) Option {
+	return func(o *options) {
+		o.catalog = c
+	}
+}
+
+// Update updates the user directories resolved by r the way xdg-user-dirs-update does, and returns them.
+// If r is nil, xdgbasedir.Default() is used.
+//
+// The default directories are read from the first user-dirs.defaults in ConfigDirs, or Defaults if there is none,
+// and their names are translated for the current locale. The directories already in user-dirs.dirs are kept, and
+// the ones which no longer exist are reset to the home directory, which disables them. The default directories
+// missing from user-dirs.dirs are added and created. user-dirs.dirs and user-dirs.locale are written atomically
+// if anything changed.
+func Update(r *xdgbasedir.Resolver, opts ...Option) (*Dirs, error) {
+	if r == nil {
+		r = xdgbasedir.Default()
+	}
+	var o options
+	for _, opt := range opts {
+		opt(&o)
+	}
+	fsys := r.FS()
+	home := r.Home()
+	if home == "" {
+		return nil, errors.New("userdirs: home directory not found")
+	}
+
+	defaults, err := loadDefaults(r)
+	if err != nil {
+		return nil, err
+	}
+	dirsPath := filepath.Join(r.ConfigHome(), DirsFile)
+	data, err := fsys.ReadFile(dirsPath)
+	changed := errors.Is(err, fs.ErrNotExist)
+	if err != nil && !changed {
+		return nil, err
+	}
+	current := userdirsfile.Parse(data, home)
+	prefs := locale.Preferences(r)
+
+	var entries []entry
+	seen := make(map[Name]bool)
+	for _, def := range defaults {
+		seen[def.name] = true
+		dir, ok := userdirsfile.Lookup(current, string(def.name))
+		e := entry{name: def.name, dir: dir}
+		if ok && !samePath(e.dir, home) {
+			// a directory the user removed is disabled rather than recreated, same as xdg-user-dirs-update
+			if _, err := fsys.Stat(e.dir); err != nil {
+				e.dir = home
+				changed = true
+			}
+		}
+		if !ok {
+			e = entry{name: def.name, dir: filepath.Join(home, filepath.FromSlash(o.catalog.translate(prefs, def.dir)))}
+			changed = true
+		}
+		entries = append(entries, e)
+	}
+	for _, e := range current {
+		if name := Name(e.Name); !seen[name] {
+			seen[name] = true
+			dir, _ := userdirsfile.Lookup(current, e.Name)
+			entries = append(entries, entry{name: name, dir: dir})
+		}
+	}
+
+	// only the default directories are created, same as xdg-user-dirs-update
+	for _, e := range entries[:len(defaults)] {
+		if !samePath(e.dir, home) {
+			if err := fsys.MkdirAll(e.dir, userDirPerm); err != nil {
+				return nil, err
+			}
+		}
+	}
+
+	if changed {
+		if err := writeDirs(fsys, dirsPath, home, entries); err != nil {
+			return nil, err
+		}
+		messages := locale.Messages(r)
+		messages.Encoding = ""
+		lang := messages.String()
+		if messages.IsC() {
+			lang = "C"
+		}
+		if err := xdgbasedir.WriteFileAtomic(fsys, filepath.Join(r.ConfigHome(), LocaleFile), []byte(lang+"\n"), filePerm); err != nil {
+			return nil, err
+		}
+	}
+
+	return Load(r)
+}
+
+// Set sets the user directory name resolved by r to the absolute path dir in user-dirs.dirs, same as
+// "xdg-user-dirs-update --set NAME PATH". It does not create dir. If r is nil, xdgbasedir.Default() is used.
+func Set(r *xdgbasedir.Resolver, name Name, dir string) error {
+	if r == nil {
+		r = xdgbasedir.Default()
+	}
+	if !filepath.IsAbs(dir) {
+		return fmt.Errorf("userdirs: %s: path must be absolute", dir)
+	}
+	fsys := r.FS()
+	home := r.Home()
+
+	dirsPath := filepath.Join(r.ConfigHome(), DirsFile)
+	data, err := fsys.ReadFile(dirsPath)
+	if err != nil && !errors.Is(err, fs.ErrNotExist) {
+		return err
+	}
+	entries := parse(data, home)
+	found := false
+	for i := range entries {
+		if entries[i].name == name {
+			entries[i].dir = dir
+			found = true
+		}
+	}
+	if !found {
+		entries = append(entries, entry{name: name, dir: dir})
+	}
+
+	return writeDirs(fsys, dirsPath, home, entries)
+}
+
+// loadDefaults reads the first user-dirs.defaults in r's ConfigDirs.
+func loadDefaults(r *xdgbasedir.Resolver) ([]entry, error) {
+	for _, dir := range filepath.SplitList(r.ConfigDirs()) {
+		data, err := r.FS().ReadFile(filepath.Join(dir, DefaultsFile))
+		if errors.Is(err, fs.ErrNotExist) {
+			continue
+		}
+		if err != nil {
+			return nil, err
+		}
+		return parseDefaults(data), nil
+	}
+
+	defaults := make([]entry, 0, len(Names))
+	for _, name := range Names {
+		defaults = append(defaults, entry{name: name, dir: Defaults[name]})
+	}
+	return defaults, nil
+}
+
+// parseDefaults parses user-dirs.defaults, which consists of NAME=relative/path lines.
+func parseDefaults(data []byte) []entry {
+	var defaults []entry
+	sc := bufio.NewScanner(bytes.NewReader(data))
+	for sc.Scan() {
+		s := strings.TrimSpace(sc.Text())
+		if s == "" || s[0] == '#' {
+			continue
+		}
+		i := strings.IndexByte(s, '=')
+		if i <= 0 {
+			continue
+		}
+		defaults = append(defaults, entry{name: Name(strings.TrimSpace(s[:i])), dir: strings.TrimSpace(s[i+1:])})
+	}
+	return defaults
+}
+
+// header is the header comment of user-dirs.dirs written by xdg-user-dirs-update.
+const header = `# This file is written by xdg-user-dirs-update
+# If you want to change or add directories, just edit the line you're
+# interested in. All local changes will be retained on the next run.
+# Format is XDG_xxx_DIR="$HOME/yyy", where yyy is a shell-escaped
+# homedir-relative path, or XDG_xxx_DIR="/yyy", where /yyy is an
+# absolute path. No other format is supported.
+#
+`
+
+// writeDirs writes the entries to user-dirs.dirs atomically.
+func writeDirs(fsys xdgbasedir.FileSystem, path, home string, entries []entry) error {
+	var b strings.Builder
+	b.WriteString(header)
+	for _, e := range entries {
+		fmt.Fprintf(&b, "%s=\"%s\"\n", e.name.Env(), quote(e.dir, home))
+	}
+	if err := fsys.MkdirAll(filepath.Dir(path), 0700); err != nil {
+		return err
+	}
+	return xdgbasedir.WriteFileAtomic(fsys, path, []byte(b.String()), filePerm)
+}
+
+// quote returns the shell-escaped value of dir, relative to "$HOME" if dir is under home.
+func quote(dir, home string) string {
+	prefix := ""
+	if rel, err := filepath.Rel(home, dir); err == nil && rel != ".." && !strings.HasPrefix(rel, ".."+string(filepath.Separator)) {
+		prefix = "$HOME/"
+		dir = rel
+		if rel == "." {
+			dir = ""
+		}
+	}
+	var b strings.Builder
+	b.WriteString(prefix)
+	for _, c := range filepath.ToSlash(dir) {
+		if c == '"' || c == '\\' || c == '$' || c == '`' {
+			b.WriteByte('\\')
+		}
+		b.WriteRune(c)
+	}
+	return b.String()
+}
+
+// samePath reports whether a and b are the same path.
+func samePath(a, b string) bool {
+	return filepath.Clean(a) == filepath.Clean(b)
+}
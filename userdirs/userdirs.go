@@ -0,0 +1,116 @@
+// Copyright 2026 The go-xdgbasedir Authors. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package userdirs
+
+import (
+	"errors"
+	"io/fs"
+	"path/filepath"
+
+	"github.com/zchee/go-xdgbasedir"
+	"github.com/zchee/go-xdgbasedir/internal/userdirsfile"
+)
+
+// Name is the name of a user directory, as used in the XDG_<Name>_DIR variables.
+type Name string
+
+// List of the user directories known to xdg-user-dirs.
+const (
+	Desktop     Name = "DESKTOP"
+	Download    Name = "DOWNLOAD"
+	Templates   Name = "TEMPLATES"
+	PublicShare Name = "PUBLICSHARE"
+	Documents   Name = "DOCUMENTS"
+	Music       Name = "MUSIC"
+	Pictures    Name = "PICTURES"
+	Videos      Name = "VIDEOS"
+)
+
+// Names is the list of the user directories known to xdg-user-dirs, in the order of user-dirs.defaults.
+var Names = []Name{Desktop, Download, Templates, PublicShare, Documents, Music, Pictures, Videos}
+
+// Env returns the name of the environment variable of n, such as "XDG_DESKTOP_DIR".
+func (n Name) Env() string {
+	return "XDG_" + string(n) + "_DIR"
+}
+
+// DirsFile is the name of the user directories file under the XDG_CONFIG_HOME directory.
+const DirsFile = "user-dirs.dirs"
+
+// Dirs is the set of the user directories of a user.
+type Dirs struct {
+	home string
+	dirs map[Name]string
+}
+
+// Load loads the user directories resolved by r. If r is nil, xdgbasedir.Default() is used.
+// A missing user-dirs.dirs is not an error.
+func Load(r *xdgbasedir.Resolver) (*Dirs, error) {
+	if r == nil {
+		r = xdgbasedir.Default()
+	}
+	d := &Dirs{home: r.Home(), dirs: make(map[Name]string)}
+
+	data, err := r.FS().ReadFile(filepath.Join(r.ConfigHome(), DirsFile))
+	if err != nil && !errors.Is(err, fs.ErrNotExist) {
+		return nil, err
+	}
+	for _, e := range parse(data, d.home) {
+		d.dirs[e.name] = e.dir
+	}
+
+	for _, name := range Names {
+		if v := r.Getenv(name.Env()); v != "" {
+			if dir, ok := userdirsfile.Expand(v, d.home); ok {
+				d.dirs[name] = dir
+			}
+		}
+	}
+
+	return d, nil
+}
+
+// Dir returns the user directory name, or the home directory if it is missing or disabled.
+// Like xdg-user-dir, a missing Desktop directory falls back to "Desktop" under the home directory.
+func (d *Dirs) Dir(name Name) string {
+	if dir, ok := d.dirs[name]; ok {
+		return dir
+	}
+	if name == Desktop {
+		return filepath.Join(d.home, "Desktop")
+	}
+	return d.home
+}
+
+// Lookup returns the user directory name and whether it is set to a directory other than the home directory.
+func (d *Dirs) Lookup(name Name) (string, bool) {
+	dir := d.Dir(name)
+	return dir, !samePath(dir, d.home)
+}
+
+// Dir returns the user directory name resolved by xdgbasedir.Default(), with the fallbacks of (*Dirs).Dir if it is
+// missing, disabled or user-dirs.dirs is unreadable.
+func Dir(name Name) string {
+	d, err := Load(nil)
+	if err != nil {
+		d = &Dirs{home: xdgbasedir.Default().Home()}
+	}
+	return d.Dir(name)
+}
+
+// entry is an entry of user-dirs.dirs.
+type entry struct {
+	name Name
+	dir  string
+}
+
+// parse parses the user-dirs.dirs data the way xdg-user-dirs does, in order. Malformed lines are ignored.
+func parse(data []byte, home string) []entry {
+	var entries []entry
+	for _, e := range userdirsfile.Parse(data, home) {
+		entries = append(entries, entry{name: Name(e.Name), dir: e.Dir})
+	}
+	return entries
+}
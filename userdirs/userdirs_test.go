@@ -0,0 +1,226 @@
+// Copyright 2026 The go-xdgbasedir Authors. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package userdirs_test
+
+import (
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/zchee/go-xdgbasedir"
+	"github.com/zchee/go-xdgbasedir/userdirs"
+	"github.com/zchee/go-xdgbasedir/xdgtest"
+)
+
+const dirs = `# This file is written by xdg-user-dirs-update
+# If you want to change or add directories, just edit the line you're
+# interested in. All local changes will be retained on the next run.
+# Format is XDG_xxx_DIR="$HOME/yyy", where yyy is a shell-escaped
+# homedir-relative path, or XDG_xxx_DIR="/yyy", where /yyy is an
+# absolute path. No other format is supported.
+#
+XDG_DESKTOP_DIR="$HOME/Desktop"
+XDG_DOWNLOAD_DIR="/data/downloads"
+XDG_TEMPLATES_DIR="$HOME/"
+  XDG_DOCUMENTS_DIR = "$HOME/My \"Documents\""
+XDG_MUSIC_DIR="$HOME/Music"
+XDG_PICTURES_DIR=Pictures
+XDG_VIDEOS_DIR="relative/Videos"
+`
+
+func TestLoad(t *testing.T) {
+	t.Parallel()
+
+	tr := xdgtest.New(t, xdgtest.WithFiles(map[string]string{
+		"home/.config/user-dirs.dirs": dirs,
+	}))
+	r := tr.Resolver(xdgbasedir.WithEnv(append(tr.Env(), "XDG_MUSIC_DIR=$HOME/Audio")))
+
+	d, err := userdirs.Load(r)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	tests := []struct {
+		name    userdirs.Name
+		want    string
+		enabled bool
+	}{
+		{name: userdirs.Desktop, want: filepath.Join(tr.Home, "Desktop"), enabled: true},
+		{name: userdirs.Download, want: filepath.FromSlash("/data/downloads"), enabled: true},
+		{name: userdirs.Templates, want: tr.Home, enabled: false},
+		{name: userdirs.PublicShare, want: tr.Home, enabled: false},
+		{name: userdirs.Documents, want: filepath.Join(tr.Home, `My "Documents"`), enabled: true},
+		{name: userdirs.Music, want: filepath.Join(tr.Home, "Audio"), enabled: true},
+		{name: userdirs.Pictures, want: tr.Home, enabled: false},
+		{name: userdirs.Videos, want: tr.Home, enabled: false},
+	}
+	for _, tt := range tests {
+		if got := d.Dir(tt.name); got != tt.want {
+			t.Errorf("Dir(%s) = %q, want %q", tt.name, got, tt.want)
+		}
+		if _, enabled := d.Lookup(tt.name); enabled != tt.enabled {
+			t.Errorf("Lookup(%s) enabled = %v, want %v", tt.name, enabled, tt.enabled)
+		}
+	}
+}
+
+func TestLoadMissing(t *testing.T) {
+	t.Parallel()
+
+	tr := xdgtest.New(t)
+	d, err := userdirs.Load(tr.Resolver())
+	if err != nil {
+		t.Fatal(err)
+	}
+	for _, name := range userdirs.Names {
+		want := tr.Home
+		if name == userdirs.Desktop {
+			want = filepath.Join(tr.Home, "Desktop")
+		}
+		if got := d.Dir(name); got != want {
+			t.Errorf("Dir(%s) = %q, want %q", name, got, want)
+		}
+	}
+}
+
+func TestNameEnv(t *testing.T) {
+	t.Parallel()
+
+	if got, want := userdirs.PublicShare.Env(), "XDG_PUBLICSHARE_DIR"; got != want {
+		t.Fatalf("Env() = %q, want %q", got, want)
+	}
+}
+
+func TestUpdate(t *testing.T) {
+	t.Parallel()
+
+	tr := xdgtest.New(t, xdgtest.WithFiles(map[string]string{
+		"etc/xdg/user-dirs.defaults": "# comment\nDESKTOP=Desktop\nDOWNLOAD=Downloads\nTEMPLATES=Templates\nMUSIC=Music\nPICTURES=Media/Pictures\n",
+		"home/.config/user-dirs.dirs": `XDG_DOWNLOAD_DIR="$HOME/dl"
+XDG_TEMPLATES_DIR="$HOME/"
+XDG_MUSIC_DIR="$HOME/gone"
+XDG_PROJECTS_DIR="/srv/projects"
+`,
+		"home/dl/file": "",
+	}))
+	r := tr.Resolver(xdgbasedir.WithEnv(append(tr.Env(), "LANG=de_DE.UTF-8")))
+	catalog := userdirs.Catalog{
+		"de":    {"Desktop": "Schreibtisch", "Music": "Musik", "Pictures": "Bilder"},
+		"de_AT": {"Desktop": "Arbeitsfläche"},
+	}
+
+	d, err := userdirs.Update(r, userdirs.WithCatalog(catalog))
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	want := map[userdirs.Name]string{
+		userdirs.Desktop:   filepath.Join(tr.Home, "Schreibtisch"),
+		userdirs.Download:  filepath.Join(tr.Home, "dl"),
+		userdirs.Templates: tr.Home,
+		userdirs.Music:     tr.Home,
+		userdirs.Pictures:  filepath.Join(tr.Home, "Media", "Bilder"),
+		"PROJECTS":         filepath.FromSlash("/srv/projects"),
+	}
+	for name, dir := range want {
+		if got := d.Dir(name); got != dir {
+			t.Errorf("Dir(%s) = %q, want %q", name, got, dir)
+		}
+	}
+	for _, rel := range []string{"home/Schreibtisch", "home/Media/Bilder"} {
+		if fi, err := os.Stat(tr.Path(rel)); err != nil || !fi.IsDir() {
+			t.Errorf("%s is not created: %v", rel, err)
+		}
+	}
+	tr.AssertNotExist("home/gone")
+	tr.AssertNotExist("home/Musik")
+	tr.AssertFileContent("home/.config/user-dirs.dirs", `# This file is written by xdg-user-dirs-update
+# If you want to change or add directories, just edit the line you're
+# interested in. All local changes will be retained on the next run.
+# Format is XDG_xxx_DIR="$HOME/yyy", where yyy is a shell-escaped
+# homedir-relative path, or XDG_xxx_DIR="/yyy", where /yyy is an
+# absolute path. No other format is supported.
+#
+XDG_DESKTOP_DIR="$HOME/Schreibtisch"
+XDG_DOWNLOAD_DIR="$HOME/dl"
+XDG_TEMPLATES_DIR="$HOME/"
+XDG_MUSIC_DIR="$HOME/"
+XDG_PICTURES_DIR="$HOME/Media/Bilder"
+XDG_PROJECTS_DIR="/srv/projects"
+`)
+	tr.AssertFileContent("home/.config/user-dirs.locale", "de_DE\n")
+
+	// a second run keeps everything, even in another locale
+	os.Remove(tr.Path("home/.config/user-dirs.locale"))
+	if _, err := userdirs.Update(tr.Resolver(), userdirs.WithCatalog(catalog)); err != nil {
+		t.Fatal(err)
+	}
+	tr.AssertNotExist("home/.config/user-dirs.locale")
+}
+
+func TestUpdateDuplicates(t *testing.T) {
+	t.Parallel()
+
+	tr := xdgtest.New(t, xdgtest.WithFiles(map[string]string{
+		"etc/xdg/user-dirs.defaults": "DOWNLOAD=Downloads\n",
+		"home/.config/user-dirs.dirs": `XDG_DOWNLOAD_DIR="$HOME/old"
+XDG_DOWNLOAD_DIR="$HOME/dl"
+XDG_PROJECTS_DIR="/srv/old"
+XDG_PROJECTS_DIR="/srv/projects"
+`,
+		"home/dl/file": "",
+	}))
+	r := tr.Resolver()
+	before, err := userdirs.Load(r)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	d, err := userdirs.Update(r)
+	if err != nil {
+		t.Fatal(err)
+	}
+	// Update edits the same last entries which the readers resolve
+	for _, name := range []userdirs.Name{userdirs.Download, "PROJECTS"} {
+		if got, want := d.Dir(name), before.Dir(name); got != want {
+			t.Errorf("Dir(%s) = %q, want %q", name, got, want)
+		}
+	}
+	if got, want := d.Dir(userdirs.Download), filepath.Join(tr.Home, "dl"); got != want {
+		t.Errorf("Dir(DOWNLOAD) = %q, want %q", got, want)
+	}
+	tr.AssertNotExist("home/old")
+}
+
+func TestSet(t *testing.T) {
+	t.Parallel()
+
+	tr := xdgtest.New(t, xdgtest.WithFiles(map[string]string{
+		"home/.config/user-dirs.dirs": "XDG_DESKTOP_DIR=\"$HOME/Desktop\"\n",
+	}))
+	r := tr.Resolver()
+
+	if err := userdirs.Set(r, userdirs.Desktop, filepath.Join(tr.Home, `$weird "dir"`)); err != nil {
+		t.Fatal(err)
+	}
+	if err := userdirs.Set(r, userdirs.Music, filepath.FromSlash("/srv/music")); err != nil {
+		t.Fatal(err)
+	}
+	if err := userdirs.Set(r, userdirs.Videos, "relative"); err == nil {
+		t.Fatal("Set with a relative path succeeded")
+	}
+
+	d, err := userdirs.Load(r)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if got, want := d.Dir(userdirs.Desktop), filepath.Join(tr.Home, `$weird "dir"`); got != want {
+		t.Errorf("Dir(DESKTOP) = %q, want %q", got, want)
+	}
+	if got, want := d.Dir(userdirs.Music), filepath.FromSlash("/srv/music"); got != want {
+		t.Errorf("Dir(MUSIC) = %q, want %q", got, want)
+	}
+}
@@ -0,0 +1,74 @@
+// Copyright 2026 The go-xdgbasedir Authors. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package xdgbasedir
+
+import (
+	"context"
+	"time"
+)
+
+// Event is sent by the watchers when the effective file of a search changes.
+type Event struct {
+	// Path is the new effective path, or empty if no file is found any more.
+	Path string
+	// ModTime is the modification time of Path.
+	ModTime time.Time
+}
+
+// DefaultWatchInterval is the polling interval of the watchers when the given interval is not positive.
+const DefaultWatchInterval = time.Second
+
+// WatchConfig polls the rel file in r's ConfigHome and ConfigDirs every interval, and sends an Event whenever
+// the effective path or its modification time changes, including when a higher precedence file shadows it.
+// The first Event describes the initial state. The channel is closed when ctx is done.
+// A non-positive interval means DefaultWatchInterval.
+func (r *Resolver) WatchConfig(ctx context.Context, rel string, interval time.Duration) <-chan Event {
+	return r.watch(ctx, interval, func() (string, error) { return r.FindConfig(rel) })
+}
+
+// WatchData polls the rel file in r's DataHome and DataDirs every interval, like WatchConfig.
+func (r *Resolver) WatchData(ctx context.Context, rel string, interval time.Duration) <-chan Event {
+	return r.watch(ctx, interval, func() (string, error) { return r.FindData(rel) })
+}
+
+// watch polls find every interval in r's FileSystem.
+func (r *Resolver) watch(ctx context.Context, interval time.Duration, find func() (string, error)) <-chan Event {
+	if interval <= 0 {
+		interval = DefaultWatchInterval
+	}
+	ch := make(chan Event)
+	go func() {
+		defer close(ch)
+
+		ticker := time.NewTicker(interval)
+		defer ticker.Stop()
+
+		var last *Event
+		for {
+			var ev Event
+			if path, err := find(); err == nil {
+				ev.Path = path
+				if fi, err := r.stat(path); err == nil {
+					ev.ModTime = fi.ModTime()
+				}
+			}
+			if last == nil || ev.Path != last.Path || !ev.ModTime.Equal(last.ModTime) {
+				select {
+				case ch <- ev:
+				case <-ctx.Done():
+					return
+				}
+				last = &ev
+			}
+
+			select {
+			case <-ticker.C:
+			case <-ctx.Done():
+				return
+			}
+		}
+	}()
+	return ch
+}
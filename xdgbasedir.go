@@ -5,9 +5,6 @@
 package xdgbasedir
 
 import (
-	"os"
-	"path/filepath"
-	"runtime"
 	"sync"
 )
 
@@ -34,10 +31,7 @@ var initOnce sync.Once
 // $XDG_DATA_HOME defines the base directory relative to which user specific data files should be stored.
 // If $XDG_DATA_HOME is either not set or empty, a default equal to $HOME/.local/share should be used.
 func DataHome() string {
-	if env := os.Getenv("XDG_DATA_HOME"); env != "" {
-		return expandUser(env)
-	}
-	return dataHome()
+	return std.DataHome()
 }
 
 // ConfigHome return the XDG_CONFIG_HOME based directory path.
@@ -45,10 +39,15 @@ func DataHome() string {
 // $XDG_CONFIG_HOME defines the base directory relative to which user specific configuration files should be stored.
 // If $XDG_CONFIG_HOME is either not set or empty, a default equal to $HOME/.config should be used.
 func ConfigHome() string {
-	if env := os.Getenv("XDG_CONFIG_HOME"); env != "" {
-		return expandUser(env)
-	}
-	return configHome()
+	return std.ConfigHome()
+}
+
+// StateHome return the XDG_STATE_HOME based directory path.
+//
+// $XDG_STATE_HOME defines the base directory relative to which user specific state files should be stored.
+// If $XDG_STATE_HOME is either not set or empty, a default equal to $HOME/.local/state should be used.
+func StateHome() string {
+	return std.StateHome()
 }
 
 // DataDirs return the XDG_DATA_DIRS based directory path.
@@ -57,10 +56,7 @@ func ConfigHome() string {
 // to the $XDG_DATA_HOME base directory. The directories in $XDG_DATA_DIRS should be seperated with a colon ':'.
 // If $XDG_DATA_DIRS is either not set or empty, a value equal to /usr/local/share/:/usr/share/ should be used.
 func DataDirs() string {
-	if env := os.Getenv("XDG_DATA_DIRS"); env != "" {
-		return expandUser(env)
-	}
-	return dataDirs()
+	return std.DataDirs()
 }
 
 // ConfigDirs return the XDG_CONFIG_DIRS based directory path.
@@ -69,10 +65,7 @@ func DataDirs() string {
 // to the $XDG_CONFIG_HOME base directory. The directories in $XDG_CONFIG_DIRS should be seperated with a colon ':'.
 // If $XDG_CONFIG_DIRS is either not set or empty, a value equal to /etc/xdg should be used.
 func ConfigDirs() string {
-	if env := os.Getenv("XDG_CONFIG_DIRS"); env != "" {
-		return expandUser(env)
-	}
-	return configDirs()
+	return std.ConfigDirs()
 }
 
 // CacheHome return the XDG_CACHE_HOME based directory path.
@@ -80,10 +73,7 @@ func ConfigDirs() string {
 // $XDG_CACHE_HOME defines the base directory relative to which user specific non-essential data files should be stored.
 // If $XDG_CACHE_HOME is either not set or empty, a default equal to $HOME/.cache should be used.
 func CacheHome() string {
-	if env := os.Getenv("XDG_CACHE_HOME"); env != "" {
-		return expandUser(env)
-	}
-	return cacheHome()
+	return std.CacheHome()
 }
 
 // RuntimeDir return the XDG_RUNTIME_DIR based directory path.
@@ -97,38 +87,10 @@ func CacheHome() string {
 // xref:
 //	http://serverfault.com/questions/388840/good-default-for-xdg-runtime-dir/727994#727994
 func RuntimeDir() string {
-	if env := os.Getenv("XDG_RUNTIME_DIR"); env != "" {
-		return expandUser(env)
-	}
-	return runtimeDir()
+	return std.RuntimeDir()
 }
 
 // expandUser expands shell's user home directory tilde expansion from s.
 func expandUser(s string) string {
-	if len(s) < 2 || s[0] != '~' || !os.IsPathSeparator(s[1]) {
-		return s
-	}
-
-	env := "HOME"
-	if runtime.GOOS == "windows" {
-		env = "USERPROFILE"
-	} else if runtime.GOOS == "plan9" {
-		env = "home"
-	}
-	home := os.Getenv(env)
-	if home == "" {
-		return s
-	}
-
-	if runtime.GOOS == "windows" {
-		s = filepath.ToSlash(filepath.Join(home, s[2:]))
-	} else {
-		s = filepath.Join(home, s[2:])
-	}
-	return os.Expand(s, func(env string) string {
-		if env == "HOME" {
-			return home
-		}
-		return os.Getenv(env)
-	})
+	return std.expandUser(s)
 }
@@ -8,8 +8,6 @@ package xdgbasedir
 
 import (
 	"os"
-	"path/filepath"
-	"strconv"
 
 	"github.com/zchee/go-xdgbasedir/home"
 )
@@ -19,6 +17,7 @@ var (
 	defaultConfigHome string
 	defaultDataDirs   string
 	defaultConfigDirs string
+	defaultStateHome  string
 	defaultCacheHome  string
 	defaultRuntimeDir string
 )
@@ -27,20 +26,21 @@ func initDir() {
 	initOnce.Do(func() {
 		switch Mode {
 		case Unix:
-			defaultDataHome = filepath.Join(home.Dir(), ".local", "share")
-			defaultConfigHome = filepath.Join(home.Dir(), ".config")
-			defaultDataDirs = filepath.Join("/usr", "local", "share") + string(filepath.ListSeparator) + filepath.Join("/usr", "share")
-			defaultConfigDirs = filepath.Join("/etc", "xdg")
-			defaultCacheHome = filepath.Join(home.Dir(), ".cache")
-			defaultRuntimeDir = filepath.Join("/run", "user", strconv.Itoa(os.Getuid()))
+			defaultDataHome = unixDir(EnvDataHome, home.Dir(), os.Getuid())
+			defaultConfigHome = unixDir(EnvConfigHome, home.Dir(), os.Getuid())
+			defaultDataDirs = unixDir(EnvDataDirs, home.Dir(), os.Getuid())
+			defaultConfigDirs = unixDir(EnvConfigDirs, home.Dir(), os.Getuid())
+			defaultStateHome = unixDir(EnvStateHome, home.Dir(), os.Getuid())
+			defaultCacheHome = unixDir(EnvCacheHome, home.Dir(), os.Getuid())
+			defaultRuntimeDir = unixDir(EnvRuntimeDir, home.Dir(), os.Getuid())
 		case Native:
-			// ref: https://developer.apple.com/library/content/documentation/FileManagement/Conceptual/FileSystemProgrammingGuide/MacOSXDirectories/MacOSXDirectories.html
-			defaultDataHome = filepath.Join(home.Dir(), "Library", "Application Support")
-			defaultConfigHome = filepath.Join(home.Dir(), "Library", "Preferences")
-			defaultDataDirs = defaultDataHome
-			defaultConfigDirs = defaultConfigHome
-			defaultCacheHome = filepath.Join(home.Dir(), "Library", "Caches")
-			defaultRuntimeDir = defaultDataHome
+			defaultDataHome = darwinDir(EnvDataHome, home.Dir())
+			defaultConfigHome = darwinDir(EnvConfigHome, home.Dir())
+			defaultDataDirs = darwinDir(EnvDataDirs, home.Dir())
+			defaultConfigDirs = darwinDir(EnvConfigDirs, home.Dir())
+			defaultStateHome = darwinDir(EnvStateHome, home.Dir())
+			defaultCacheHome = darwinDir(EnvCacheHome, home.Dir())
+			defaultRuntimeDir = darwinDir(EnvRuntimeDir, home.Dir())
 		}
 	})
 }
@@ -55,6 +55,11 @@ func configHome() string {
 	return defaultConfigHome
 }
 
+func stateHome() string {
+	initDir()
+	return defaultStateHome
+}
+
 func dataDirs() string {
 	initDir()
 	return defaultDataDirs
@@ -74,3 +79,10 @@ func runtimeDir() string {
 	initDir()
 	return defaultRuntimeDir
 }
+
+func platformProfile() Profile {
+	if Mode == Native {
+		return ProfileDarwin
+	}
+	return ProfileUnix
+}
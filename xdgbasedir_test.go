@@ -97,6 +97,46 @@ func TestConfigHome(t *testing.T) {
 	}
 }
 
+func TestStateHome(t *testing.T) {
+	var testDefaultStateHome string
+	switch runtime.GOOS {
+	case "windows":
+		testDefaultStateHome = filepath.Join(home.Dir(), "AppData", "Local", "state")
+	default:
+		testDefaultStateHome = filepath.Join(home.Dir(), ".local", "state")
+	}
+
+	tests := []struct {
+		name string
+		env  string
+		want string
+	}{
+		{
+			name: "set env based specification",
+			env:  testDefaultStateHome,
+			want: testDefaultStateHome,
+		},
+		{
+			name: "set env based different from specification",
+			env:  filepath.Join("/tmp", "state"),
+			want: filepath.Join("/tmp", "state"),
+		},
+		{
+			name: "empty env",
+			env:  "",
+			want: testDefaultStateHome,
+		},
+	}
+	for _, tt := range tests {
+		os.Setenv("XDG_STATE_HOME", tt.env)
+		t.Run(tt.name, func(t *testing.T) {
+			if got := StateHome(); got != tt.want {
+				t.Errorf("StateHome() = %v, want %v", got, tt.want)
+			}
+		})
+	}
+}
+
 func TestDataDirs(t *testing.T) {
 	var testDefaultDataDirs string
 	switch runtime.GOOS {
@@ -281,6 +321,11 @@ func TestNativeMode(t *testing.T) {
 			fn:   ConfigHome(),
 			want: filepath.Join(home.Dir(), "Library", "Preferences"),
 		},
+		{
+			name: "StateHome",
+			fn:   StateHome(),
+			want: filepath.Join(home.Dir(), "Library", "Application Support"),
+		},
 		{
 			name: "DataDirs",
 			fn:   DataDirs(),
@@ -367,6 +412,12 @@ func BenchmarkConfigHome(b *testing.B) {
 	}
 }
 
+func BenchmarkStateHome(b *testing.B) {
+	for i := 0; i < b.N; i++ {
+		StateHome()
+	}
+}
+
 func BenchmarkDataDirs(b *testing.B) {
 	for i := 0; i < b.N; i++ {
 		DataDirs()
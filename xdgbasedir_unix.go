@@ -9,19 +9,18 @@ package xdgbasedir
 
 import (
 	"os"
-	"path/filepath"
-	"strconv"
 
 	"github.com/zchee/go-xdgbasedir/home"
 )
 
 var (
-	defaultDataHome   = filepath.Join(home.Dir(), ".local", "share")
-	defaultConfigHome = filepath.Join(home.Dir(), ".config")
-	defaultDataDirs   = filepath.Join("/usr", "local", "share") + string(filepath.ListSeparator) + filepath.Join("/usr", "share")
-	defaultConfigDirs = filepath.Join("/etc", "xdg")
-	defaultCacheHome  = filepath.Join(home.Dir(), ".cache")
-	defaultRuntimeDir = filepath.Join("/run", "user", strconv.Itoa(os.Getuid()))
+	defaultDataHome   = unixDir(EnvDataHome, home.Dir(), os.Getuid())
+	defaultConfigHome = unixDir(EnvConfigHome, home.Dir(), os.Getuid())
+	defaultStateHome  = unixDir(EnvStateHome, home.Dir(), os.Getuid())
+	defaultDataDirs   = unixDir(EnvDataDirs, home.Dir(), os.Getuid())
+	defaultConfigDirs = unixDir(EnvConfigDirs, home.Dir(), os.Getuid())
+	defaultCacheHome  = unixDir(EnvCacheHome, home.Dir(), os.Getuid())
+	defaultRuntimeDir = unixDir(EnvRuntimeDir, home.Dir(), os.Getuid())
 )
 
 func dataHome() string {
@@ -32,6 +31,10 @@ func configHome() string {
 	return defaultConfigHome
 }
 
+func stateHome() string {
+	return defaultStateHome
+}
+
 func dataDirs() string {
 	return defaultDataDirs
 }
@@ -47,3 +50,7 @@ func cacheHome() string {
 func runtimeDir() string {
 	return defaultRuntimeDir
 }
+
+func platformProfile() Profile {
+	return ProfileUnix
+}
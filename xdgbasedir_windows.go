@@ -20,6 +20,7 @@ var (
 	defaultConfigHome = appData
 	defaultDataDirs   = appData
 	defaultConfigDirs = appData
+	defaultStateHome  = filepath.Join(localAppData, "state")
 	defaultCacheHome  = filepath.Join(localAppData, "cache")
 	defaultRuntimeDir = home.Dir()
 )
@@ -32,6 +33,10 @@ func configHome() string {
 	return defaultConfigHome
 }
 
+func stateHome() string {
+	return defaultStateHome
+}
+
 func dataDirs() string {
 	return defaultDataDirs
 }
@@ -47,3 +52,7 @@ func cacheHome() string {
 func runtimeDir() string {
 	return defaultRuntimeDir
 }
+
+func platformProfile() Profile {
+	return ProfileWindows
+}
@@ -0,0 +1,15 @@
+// Copyright 2026 The go-xdgbasedir Authors. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+// Package xdgopen opens a file or URL in the user's preferred application, like the xdg-open command of
+// xdg-utils but without a desktop-specific backend.
+//
+// The MIME type of a local file is detected with the shared MIME-info database, and a URL is handled by the
+// x-scheme-handler/<scheme> pseudo MIME type. The default application and the other associations are resolved
+// through the mimeapps.list files, falling back to the parent types of the MIME type, and the Exec line of the
+// first desktop entry which can be launched is run.
+//
+// Every step is injectable with an Option, including the command runner, so that opening can be tested without a
+// desktop session.
+package xdgopen // import "github.com/zchee/go-xdgbasedir/xdgopen"
@@ -0,0 +1,301 @@
+// Copyright 2026 The go-xdgbasedir Authors. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package xdgopen
+
+import (
+	"errors"
+	"fmt"
+	"net/url"
+	"os/exec"
+	"path/filepath"
+	"strings"
+
+	"github.com/zchee/go-xdgbasedir"
+	"github.com/zchee/go-xdgbasedir/desktopentry"
+	"github.com/zchee/go-xdgbasedir/mimeapps"
+	"github.com/zchee/go-xdgbasedir/sharedmime"
+)
+
+// ErrNoHandler is matched by the errors of Open if no application can open the target.
+var ErrNoHandler = errors.New("xdgopen: no application found")
+
+// Error is returned by Open if no application can open the target.
+type Error struct {
+	Target   string
+	MIMEType string
+	// Types is the MIME type and its parent types whose applications were looked up.
+	Types []string
+	// Errs is the errors of the candidate applications which failed to load or launch.
+	Errs []error
+}
+
+// Error implements error.
+func (e *Error) Error() string {
+	if len(e.Errs) == 0 {
+		return fmt.Sprintf("xdgopen: no application is associated with %s of type %s (looked up %s)",
+			e.Target, e.MIMEType, strings.Join(e.Types, ", "))
+	}
+	msgs := make([]string, len(e.Errs))
+	for i, err := range e.Errs {
+		msgs[i] = err.Error()
+	}
+	return fmt.Sprintf("xdgopen: no application could open %s of type %s: %s", e.Target, e.MIMEType, strings.Join(msgs, "; "))
+}
+
+// Unwrap returns the errors of the candidate applications. As they may match fs.ErrNotExist, such as for a missing
+// Exec program, check for an *Error before checking whether the target is missing.
+func (e *Error) Unwrap() []error {
+	return e.Errs
+}
+
+// Is reports whether target is ErrNoHandler.
+func (e *Error) Is(target error) bool {
+	return target == ErrNoHandler
+}
+
+// Opener opens files and URLs.
+type Opener struct {
+	resolver *xdgbasedir.Resolver
+	db       *sharedmime.Database
+	detect   func(path string) (string, error)
+	parents  func(mimeType string) []string
+	lookup   func(mimeType string) []string
+	load     func(id string) (*desktopentry.Entry, error)
+	run      func(cmd *exec.Cmd) error
+	terminal []string
+}
+
+// Option configures an Opener.
+type Option func(*Opener)
+
+// WithResolver makes the Opener resolve the databases and run the applications in the environment of r.
+// By default, xdgbasedir.Default().
+func WithResolver(r *xdgbasedir.Resolver) Option {
+	return func(o *Opener) {
+		o.resolver = r
+	}
+}
+
+// WithDatabase sets the shared MIME-info database used to detect the MIME types of local files and their parent
+// types. By default, the database is loaded from the resolver on the first use.
+func WithDatabase(db *sharedmime.Database) Option {
+	return func(o *Opener) {
+		o.db = db
+	}
+}
+
+// WithDetector sets the function which detects the MIME type of a local file.
+func WithDetector(detect func(path string) (string, error)) Option {
+	return func(o *Opener) {
+		o.detect = detect
+	}
+}
+
+// WithParents sets the function which returns the parent types of a MIME type, whose applications are used if
+// there is none for the MIME type itself.
+func WithParents(parents func(mimeType string) []string) Option {
+	return func(o *Opener) {
+		o.parents = parents
+	}
+}
+
+// WithLookup sets the function which returns the desktop-file IDs of the candidate applications of a MIME type,
+// the default application first. By default, mimeapps.Applications.
+func WithLookup(lookup func(mimeType string) []string) Option {
+	return func(o *Opener) {
+		o.lookup = lookup
+	}
+}
+
+// WithLoader sets the function which loads the desktop entry of a desktop-file ID. By default,
+// desktopentry.Load.
+func WithLoader(load func(id string) (*desktopentry.Entry, error)) Option {
+	return func(o *Opener) {
+		o.load = load
+	}
+}
+
+// WithRunner sets the function which runs the commands of the application. By default, (*exec.Cmd).Start.
+func WithRunner(run func(cmd *exec.Cmd) error) Option {
+	return func(o *Opener) {
+		o.run = run
+	}
+}
+
+// WithTerminal sets the command prefix which runs the applications of Terminal=true, like
+// desktopentry.WithTerminal.
+func WithTerminal(argv ...string) Option {
+	return func(o *Opener) {
+		o.terminal = argv
+	}
+}
+
+// New returns a new Opener configured by opts.
+func New(opts ...Option) *Opener {
+	o := &Opener{
+		resolver: xdgbasedir.Default(),
+		run:      (*exec.Cmd).Start,
+	}
+	for _, opt := range opts {
+		opt(o)
+	}
+	if o.detect == nil {
+		o.detect = func(path string) (string, error) {
+			db, err := o.database()
+			if err != nil {
+				return "", err
+			}
+			return db.TypeByFile(path)
+		}
+	}
+	if o.parents == nil {
+		o.parents = func(mimeType string) []string {
+			db, err := o.database()
+			if err != nil {
+				return nil
+			}
+			return db.Parents(mimeType)
+		}
+	}
+	if o.lookup == nil {
+		o.lookup = func(mimeType string) []string {
+			return mimeapps.Applications(o.resolver, mimeType)
+		}
+	}
+	if o.load == nil {
+		o.load = func(id string) (*desktopentry.Entry, error) {
+			return desktopentry.Load(o.resolver, id)
+		}
+	}
+	return o
+}
+
+// database returns the shared MIME-info database, loading it on the first use.
+func (o *Opener) database() (*sharedmime.Database, error) {
+	if o.db == nil {
+		db, err := sharedmime.Load(o.resolver)
+		if err != nil {
+			return nil, err
+		}
+		o.db = db
+	}
+	return o.db, nil
+}
+
+// Open opens target, a local file path or a URL, in the preferred application with the default Opener.
+func Open(target string) error {
+	return New().Open(target)
+}
+
+// Type returns the MIME type of target, the detected type of a local file path or a file URL, or
+// x-scheme-handler/<scheme> of the other URLs, and the target to be passed to the application.
+func (o *Opener) Type(target string) (mimeType, arg string, err error) {
+	if scheme, ok := uriScheme(target); ok {
+		if !strings.EqualFold(scheme, "file") {
+			return mimeapps.SchemeHandler(scheme), target, nil
+		}
+		u, err := url.Parse(target)
+		if err != nil {
+			return "", "", fmt.Errorf("xdgopen: %w", err)
+		}
+		target = filepath.FromSlash(u.Path)
+	}
+	path, err := filepath.Abs(target)
+	if err != nil {
+		return "", "", fmt.Errorf("xdgopen: %w", err)
+	}
+	mimeType, err = o.detect(path)
+	if err != nil {
+		return "", "", fmt.Errorf("xdgopen: %w", err)
+	}
+	return mimeType, path, nil
+}
+
+// uriScheme returns the scheme of target if it is a URI. A drive letter such as "C:" is not a scheme.
+func uriScheme(target string) (string, bool) {
+	i := strings.IndexByte(target, ':')
+	if i < 2 {
+		return "", false
+	}
+	for j, c := range target[:i] {
+		switch {
+		case 'a' <= c && c <= 'z', 'A' <= c && c <= 'Z':
+		case j > 0 && ('0' <= c && c <= '9' || c == '+' || c == '-' || c == '.'):
+		default:
+			return "", false
+		}
+	}
+	return target[:i], true
+}
+
+// Types returns the MIME type and its ancestors in breadth-first order, whose applications are looked up in turn.
+// application/octet-stream, the implicit ancestor of all the binary types, is not included unless it is mimeType
+// itself, so that arbitrary data is not opened in a generic binary viewer.
+func (o *Opener) Types(mimeType string) []string {
+	types := []string{mimeType}
+	for i := 0; i < len(types); i++ {
+		for _, p := range o.parents(types[i]) {
+			if p != sharedmime.OctetStream && !contains(types, p) {
+				types = append(types, p)
+			}
+		}
+	}
+	return types
+}
+
+// Open opens target, a local file path or a URL, in the preferred application. The candidate applications of the
+// MIME type of target are tried in order, and then the ones of its parent types, until one is launched. It returns
+// an *Error matching ErrNoHandler if no application can open target.
+func (o *Opener) Open(target string) error {
+	mimeType, arg, err := o.Type(target)
+	if err != nil {
+		return err
+	}
+
+	launcher := o.launcher()
+	nerr := &Error{Target: target, MIMEType: mimeType}
+	tried := make(map[string]bool)
+	for _, t := range o.Types(mimeType) {
+		nerr.Types = append(nerr.Types, t)
+		for _, id := range o.lookup(t) {
+			if tried[id] {
+				continue
+			}
+			tried[id] = true
+			e, err := o.load(id)
+			if err != nil {
+				nerr.Errs = append(nerr.Errs, err)
+				continue
+			}
+			if err := launcher.Launch(e, arg); err != nil {
+				nerr.Errs = append(nerr.Errs, fmt.Errorf("%s: %w", id, err))
+				continue
+			}
+			return nil
+		}
+	}
+	return nerr
+}
+
+// launcher returns the desktopentry.Launcher of o.
+func (o *Opener) launcher() *desktopentry.Launcher {
+	opts := []desktopentry.LaunchOption{
+		desktopentry.WithResolver(o.resolver),
+		desktopentry.WithRunner(o.run),
+	}
+	if o.terminal != nil {
+		opts = append(opts, desktopentry.WithTerminal(o.terminal...))
+	}
+	return desktopentry.NewLauncher(opts...)
+}
+
+func contains(list []string, s string) bool {
+	for _, e := range list {
+		if e == s {
+			return true
+		}
+	}
+	return false
+}
@@ -0,0 +1,153 @@
+// Copyright 2026 The go-xdgbasedir Authors. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package xdgopen_test
+
+import (
+	"errors"
+	"os/exec"
+	"reflect"
+	"strings"
+	"testing"
+
+	"github.com/zchee/go-xdgbasedir"
+	"github.com/zchee/go-xdgbasedir/desktopentry"
+	"github.com/zchee/go-xdgbasedir/xdgopen"
+	"github.com/zchee/go-xdgbasedir/xdgtest"
+)
+
+func app(name, exec, mimeTypes string) string {
+	return "[Desktop Entry]\nType=Application\nName=" + name + "\nExec=" + exec + "\nMimeType=" + mimeTypes + "\n"
+}
+
+func TestOpen(t *testing.T) {
+	t.Parallel()
+
+	tr := xdgtest.New(t, xdgtest.WithFiles(map[string]string{
+		"usr/share/mime/globs2":     "50:text/markdown:*.md\n50:text/x-rst:*.rst\n50:image/png:*.png\n50:application/x-broken:*.broken\n",
+		"usr/share/mime/subclasses": "text/markdown text/plain\ntext/x-rst text/plain\n",
+
+		"usr/share/applications/editor.desktop":  app("Editor", "editor %F", "text/markdown;"),
+		"usr/share/applications/viewer.desktop":  app("Viewer", "viewer %f", "text/plain;"),
+		"usr/share/applications/browser.desktop": app("Browser", "browser %u", "x-scheme-handler/https;"),
+		"usr/share/applications/local.desktop":   app("Local", "local %f", "x-scheme-handler/ftp;application/x-broken;"),
+		"usr/share/applications/link.desktop":    "[Desktop Entry]\nType=Link\nName=Link\nURL=https://example.org\nMimeType=application/x-broken;\n",
+		"home/.config/mimeapps.list":             "[Default Applications]\ntext/markdown=missing.desktop;editor.desktop;\napplication/x-broken=link.desktop;\n",
+
+		"home/notes.md":  "# notes\n",
+		"home/doc.rst":   "doc\n",
+		"home/image.png": "\x89PNG",
+		"home/a.broken":  "",
+	}))
+	r := tr.Resolver()
+
+	var ran [][]string
+	o := xdgopen.New(
+		xdgopen.WithResolver(r),
+		xdgopen.WithRunner(func(cmd *exec.Cmd) error {
+			ran = append(ran, cmd.Args)
+			return nil
+		}),
+	)
+
+	tests := []struct {
+		target   string
+		mimeType string
+		want     []string
+	}{
+		{target: tr.Path("home/notes.md"), mimeType: "text/markdown", want: []string{"editor", tr.Path("home/notes.md")}},
+		{target: "file://" + tr.Path("home/notes.md"), mimeType: "text/markdown", want: []string{"editor", tr.Path("home/notes.md")}},
+		{target: tr.Path("home/doc.rst"), mimeType: "text/x-rst", want: []string{"viewer", tr.Path("home/doc.rst")}},
+		{target: "https://example.org/a?b", mimeType: "x-scheme-handler/https", want: []string{"browser", "https://example.org/a?b"}},
+	}
+	for _, tt := range tests {
+		ran = nil
+		if mimeType, _, err := o.Type(tt.target); err != nil || mimeType != tt.mimeType {
+			t.Errorf("Type(%s) = %q, %v, want %q", tt.target, mimeType, err, tt.mimeType)
+		}
+		if err := o.Open(tt.target); err != nil {
+			t.Errorf("Open(%s) error: %v", tt.target, err)
+			continue
+		}
+		if !reflect.DeepEqual(ran, [][]string{tt.want}) {
+			t.Errorf("Open(%s) ran %q, want %q", tt.target, ran, tt.want)
+		}
+	}
+
+	ran = nil
+	err := o.Open(tr.Path("home/image.png"))
+	var nerr *xdgopen.Error
+	if !errors.Is(err, xdgopen.ErrNoHandler) || !errors.As(err, &nerr) {
+		t.Fatalf("Open(image.png) error = %v, want ErrNoHandler", err)
+	}
+	if nerr.MIMEType != "image/png" || !reflect.DeepEqual(nerr.Types, []string{"image/png"}) || len(nerr.Errs) != 0 {
+		t.Errorf("Open(image.png) error = %+v", nerr)
+	}
+	if !strings.Contains(err.Error(), "no application is associated") {
+		t.Errorf("Error() = %q", err)
+	}
+
+	// the Link entry of the default application cannot be launched, and local %f cannot open a remote URL
+	if err := o.Open(tr.Path("home/a.broken")); err != nil {
+		t.Errorf("Open(a.broken) error: %v", err)
+	}
+	if err := o.Open("ftp://example.org/file"); !errors.Is(err, xdgopen.ErrNoHandler) || !strings.Contains(err.Error(), "local.desktop") {
+		t.Errorf("Open(ftp) error = %v, want ErrNoHandler with the launch error of local.desktop", err)
+	}
+	if want := [][]string{{"local", tr.Path("home/a.broken")}}; !reflect.DeepEqual(ran, want) {
+		t.Errorf("ran %q, want %q", ran, want)
+	}
+
+	if err := o.Open(tr.Path("home/missing.md")); err == nil || errors.Is(err, xdgopen.ErrNoHandler) {
+		t.Errorf("Open(missing) error = %v, want a detection error", err)
+	}
+}
+
+func TestOpenInjected(t *testing.T) {
+	t.Parallel()
+
+	var ran [][]string
+	o := xdgopen.New(
+		xdgopen.WithResolver(xdgbasedir.New(xdgbasedir.WithEnv(nil))),
+		xdgopen.WithDetector(func(path string) (string, error) { return "application/x-custom", nil }),
+		xdgopen.WithParents(func(mimeType string) []string {
+			if mimeType == "application/x-custom" {
+				return []string{"application/x-parent", "application/octet-stream"}
+			}
+			return nil
+		}),
+		xdgopen.WithLookup(func(mimeType string) []string {
+			switch mimeType {
+			case "application/x-custom":
+				return []string{"broken.desktop"}
+			case "application/x-parent":
+				return []string{"broken.desktop", "tool.desktop"}
+			case "application/octet-stream":
+				return []string{"hexdump.desktop"}
+			}
+			return nil
+		}),
+		xdgopen.WithLoader(func(id string) (*desktopentry.Entry, error) {
+			if id == "broken.desktop" {
+				return nil, errors.New("broken")
+			}
+			return &desktopentry.Entry{ID: id, Type: desktopentry.Application, Exec: "tool --open %f", Terminal: true}, nil
+		}),
+		xdgopen.WithTerminal("term", "--"),
+		xdgopen.WithRunner(func(cmd *exec.Cmd) error {
+			ran = append(ran, cmd.Args)
+			return nil
+		}),
+	)
+
+	if got, want := o.Types("application/x-custom"), []string{"application/x-custom", "application/x-parent"}; !reflect.DeepEqual(got, want) {
+		t.Errorf("Types() = %q, want %q", got, want)
+	}
+	if err := o.Open("/data/file.custom"); err != nil {
+		t.Fatal(err)
+	}
+	if want := [][]string{{"term", "--", "tool", "--open", "/data/file.custom"}}; !reflect.DeepEqual(ran, want) {
+		t.Errorf("ran %q, want %q", ran, want)
+	}
+}
@@ -0,0 +1,21 @@
+// Copyright 2026 The go-xdgbasedir Authors. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+// Package xdgtest implements sandboxed XDG base directory trees for tests.
+//
+// A Tree is created under t.TempDir with the following layout, and is removed when the test finishes:
+//
+//	home/                 $HOME
+//	home/.config/         $XDG_CONFIG_HOME
+//	home/.local/share/    $XDG_DATA_HOME
+//	home/.local/state/    $XDG_STATE_HOME
+//	home/.cache/          $XDG_CACHE_HOME
+//	run/user/             $XDG_RUNTIME_DIR
+//	etc/xdg/              $XDG_CONFIG_DIRS
+//	usr/local/share/      $XDG_DATA_DIRS
+//	usr/share/            $XDG_DATA_DIRS
+//
+// Tree.Resolver returns a hermetic Resolver of the tree which is safe for parallel tests, and
+// Tree.Setenv sets the environment variables for code which uses the package level functions.
+package xdgtest // import "github.com/zchee/go-xdgbasedir/xdgtest"
@@ -0,0 +1,306 @@
+// Copyright 2026 The go-xdgbasedir Authors. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package xdgtest
+
+import (
+	"bytes"
+	"io/fs"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+
+	"github.com/zchee/go-xdgbasedir"
+)
+
+// Tree is a sandboxed XDG base directory tree.
+type Tree struct {
+	// Root is the root directory of the tree.
+	Root string
+
+	Home       string
+	ConfigHome string
+	DataHome   string
+	StateHome  string
+	CacheHome  string
+	RuntimeDir string
+	ConfigDirs []string
+	DataDirs   []string
+
+	t testing.TB
+}
+
+// config is the configuration of New.
+type config struct {
+	configDirs []string
+	dataDirs   []string
+	files      map[string]string
+	fsys       []fs.FS
+}
+
+// Option configures a Tree.
+type Option func(*config)
+
+// WithConfigDirs sets the $XDG_CONFIG_DIRS entries of the tree, in slash-separated paths relative to the tree root.
+// By default, "etc/xdg".
+func WithConfigDirs(dirs ...string) Option {
+	return func(c *config) {
+		c.configDirs = dirs
+	}
+}
+
+// WithDataDirs sets the $XDG_DATA_DIRS entries of the tree, in slash-separated paths relative to the tree root.
+// By default, "usr/local/share" and "usr/share".
+func WithDataDirs(dirs ...string) Option {
+	return func(c *config) {
+		c.dataDirs = dirs
+	}
+}
+
+// WithFiles seeds the tree with files, keyed by slash-separated paths relative to the tree root
+// such as "home/.config/app/app.conf" or "etc/xdg/app/app.conf".
+func WithFiles(files map[string]string) Option {
+	return func(c *config) {
+		if c.files == nil {
+			c.files = make(map[string]string, len(files))
+		}
+		for name, data := range files {
+			c.files[name] = data
+		}
+	}
+}
+
+// WithFS seeds the tree with all files of fsys, whose paths are relative to the tree root.
+func WithFS(fsys fs.FS) Option {
+	return func(c *config) {
+		c.fsys = append(c.fsys, fsys)
+	}
+}
+
+// New creates a new Tree under t.TempDir.
+func New(t testing.TB, opts ...Option) *Tree {
+	t.Helper()
+
+	c := &config{
+		configDirs: []string{"etc/xdg"},
+		dataDirs:   []string{"usr/local/share", "usr/share"},
+	}
+	for _, opt := range opts {
+		opt(c)
+	}
+
+	root := t.TempDir()
+	join := func(rel string) string {
+		return filepath.Join(root, filepath.FromSlash(rel))
+	}
+	tr := &Tree{
+		Root:       root,
+		Home:       join("home"),
+		ConfigHome: join("home/.config"),
+		DataHome:   join("home/.local/share"),
+		StateHome:  join("home/.local/state"),
+		CacheHome:  join("home/.cache"),
+		RuntimeDir: join("run/user"),
+		t:          t,
+	}
+	for _, dir := range c.configDirs {
+		tr.ConfigDirs = append(tr.ConfigDirs, join(dir))
+	}
+	for _, dir := range c.dataDirs {
+		tr.DataDirs = append(tr.DataDirs, join(dir))
+	}
+
+	dirs := []string{tr.Home, tr.ConfigHome, tr.DataHome, tr.StateHome, tr.CacheHome, tr.RuntimeDir}
+	for _, dir := range append(append(dirs, tr.ConfigDirs...), tr.DataDirs...) {
+		if err := os.MkdirAll(dir, 0700); err != nil {
+			t.Fatal(err)
+		}
+	}
+	// the specification requires the runtime directory to be 0700 regardless of umask
+	if err := os.Chmod(tr.RuntimeDir, 0700); err != nil {
+		t.Fatal(err)
+	}
+
+	for _, fsys := range c.fsys {
+		tr.copyFS(fsys)
+	}
+	for name, data := range c.files {
+		tr.WriteFile(name, data)
+	}
+
+	return tr
+}
+
+// Env returns the environment variables of the tree in the form "key=value".
+func (tr *Tree) Env() []string {
+	return []string{
+		"HOME=" + tr.Home,
+		xdgbasedir.EnvConfigHome + "=" + tr.ConfigHome,
+		xdgbasedir.EnvDataHome + "=" + tr.DataHome,
+		xdgbasedir.EnvStateHome + "=" + tr.StateHome,
+		xdgbasedir.EnvCacheHome + "=" + tr.CacheHome,
+		xdgbasedir.EnvRuntimeDir + "=" + tr.RuntimeDir,
+		xdgbasedir.EnvConfigDirs + "=" + strings.Join(tr.ConfigDirs, string(filepath.ListSeparator)),
+		xdgbasedir.EnvDataDirs + "=" + strings.Join(tr.DataDirs, string(filepath.ListSeparator)),
+	}
+}
+
+// Resolver returns a hermetic Resolver of the tree. The test fails if the Resolver falls back to the process environment.
+//
+// The Resolver does not touch the process environment, so it can be used in parallel tests.
+func (tr *Tree) Resolver(opts ...xdgbasedir.Option) *xdgbasedir.Resolver {
+	t := tr.t
+	return xdgbasedir.New(append([]xdgbasedir.Option{
+		xdgbasedir.WithHermetic(xdgbasedir.Hermetic{
+			Home: tr.Home,
+			UID:  os.Getuid(),
+			Env:  tr.Env(),
+		}),
+		xdgbasedir.WithEnvGuard(func(key string) {
+			t.Helper()
+			t.Errorf("xdgtest: resolver read the process environment %s", key)
+		}),
+	}, opts...)...)
+}
+
+// Setenv sets the environment variables of the tree with t.Setenv, which restores them when the test finishes.
+//
+// Like t.Setenv, it cannot be used in parallel tests.
+func (tr *Tree) Setenv() {
+	tr.t.Helper()
+	for _, kv := range tr.Env() {
+		i := strings.IndexByte(kv, '=')
+		setenv(tr.t, kv[:i], kv[i+1:])
+	}
+}
+
+// SetDefault makes the package level functions of xdgbasedir resolve through the Resolver of the tree,
+// and restores the previous Resolver when the test finishes.
+//
+// Like Setenv, it cannot be used in parallel tests.
+func (tr *Tree) SetDefault() {
+	prev := xdgbasedir.Default()
+	xdgbasedir.SetDefault(tr.Resolver())
+	tr.t.Cleanup(func() { xdgbasedir.SetDefault(prev) })
+}
+
+// setenv calls t.Setenv if t supports it.
+func setenv(t testing.TB, key, value string) {
+	t.Helper()
+	if st, ok := t.(interface{ Setenv(key, value string) }); ok {
+		st.Setenv(key, value)
+		return
+	}
+	prev, ok := os.LookupEnv(key)
+	if err := os.Setenv(key, value); err != nil {
+		t.Fatal(err)
+	}
+	t.Cleanup(func() {
+		if ok {
+			os.Setenv(key, prev)
+		} else {
+			os.Unsetenv(key)
+		}
+	})
+}
+
+// Path returns the path of the slash-separated rel path relative to the tree root.
+func (tr *Tree) Path(rel string) string {
+	return filepath.Join(tr.Root, filepath.FromSlash(rel))
+}
+
+// WriteFile writes data to the slash-separated name path relative to the tree root, creating parent directories.
+func (tr *Tree) WriteFile(name, data string) {
+	tr.t.Helper()
+	path := tr.Path(name)
+	if err := os.MkdirAll(filepath.Dir(path), 0755); err != nil {
+		tr.t.Fatal(err)
+	}
+	if err := os.WriteFile(path, []byte(data), 0644); err != nil {
+		tr.t.Fatal(err)
+	}
+}
+
+// copyFS copies all files of fsys into the tree.
+func (tr *Tree) copyFS(fsys fs.FS) {
+	tr.t.Helper()
+	err := fs.WalkDir(fsys, ".", func(name string, d fs.DirEntry, err error) error {
+		if err != nil {
+			return err
+		}
+		if d.IsDir() {
+			return os.MkdirAll(tr.Path(name), 0755)
+		}
+		data, err := fs.ReadFile(fsys, name)
+		if err != nil {
+			return err
+		}
+		return os.WriteFile(tr.Path(name), data, 0644)
+	})
+	if err != nil {
+		tr.t.Fatal(err)
+	}
+}
+
+// AssertConfigFile asserts that the rel file was written under ConfigHome, and returns its contents.
+func (tr *Tree) AssertConfigFile(rel string) []byte {
+	tr.t.Helper()
+	return tr.assertFile("ConfigHome", tr.ConfigHome, rel)
+}
+
+// AssertDataFile asserts that the rel file was written under DataHome, and returns its contents.
+func (tr *Tree) AssertDataFile(rel string) []byte {
+	tr.t.Helper()
+	return tr.assertFile("DataHome", tr.DataHome, rel)
+}
+
+// AssertStateFile asserts that the rel file was written under StateHome, and returns its contents.
+func (tr *Tree) AssertStateFile(rel string) []byte {
+	tr.t.Helper()
+	return tr.assertFile("StateHome", tr.StateHome, rel)
+}
+
+// AssertCacheFile asserts that the rel file was written under CacheHome, and returns its contents.
+func (tr *Tree) AssertCacheFile(rel string) []byte {
+	tr.t.Helper()
+	return tr.assertFile("CacheHome", tr.CacheHome, rel)
+}
+
+// AssertRuntimeFile asserts that the rel file was written under RuntimeDir, and returns its contents.
+func (tr *Tree) AssertRuntimeFile(rel string) []byte {
+	tr.t.Helper()
+	return tr.assertFile("RuntimeDir", tr.RuntimeDir, rel)
+}
+
+// AssertFileContent asserts that the slash-separated name file relative to the tree root has the want contents.
+func (tr *Tree) AssertFileContent(name, want string) {
+	tr.t.Helper()
+	data, err := os.ReadFile(tr.Path(name))
+	if err != nil {
+		tr.t.Errorf("xdgtest: %v", err)
+		return
+	}
+	if !bytes.Equal(data, []byte(want)) {
+		tr.t.Errorf("xdgtest: %s = %q, want %q", name, data, want)
+	}
+}
+
+// AssertNotExist asserts that the slash-separated name path relative to the tree root does not exist.
+func (tr *Tree) AssertNotExist(name string) {
+	tr.t.Helper()
+	if _, err := os.Lstat(tr.Path(name)); err == nil {
+		tr.t.Errorf("xdgtest: %s exists", name)
+	}
+}
+
+func (tr *Tree) assertFile(base, dir, rel string) []byte {
+	tr.t.Helper()
+	data, err := os.ReadFile(filepath.Join(dir, filepath.FromSlash(rel)))
+	if err != nil {
+		tr.t.Errorf("xdgtest: %s was not written under %s: %v", rel, base, err)
+		return nil
+	}
+	return data
+}
@@ -0,0 +1,89 @@
+// Copyright 2026 The go-xdgbasedir Authors. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package xdgtest_test
+
+import (
+	"os"
+	"path/filepath"
+	"testing"
+	"testing/fstest"
+
+	"github.com/zchee/go-xdgbasedir"
+	"github.com/zchee/go-xdgbasedir/xdgtest"
+)
+
+func TestResolver(t *testing.T) {
+	t.Parallel()
+
+	tr := xdgtest.New(t,
+		xdgtest.WithConfigDirs("etc/xdg", "opt/etc/xdg"),
+		xdgtest.WithFiles(map[string]string{
+			"opt/etc/xdg/app/app.conf": "system",
+		}),
+		xdgtest.WithFS(fstest.MapFS{
+			"usr/share/app/data.txt": {Data: []byte("data")},
+		}),
+	)
+	r := tr.Resolver()
+
+	if got := r.ConfigHome(); got != tr.ConfigHome {
+		t.Errorf("ConfigHome() = %v, want %v", got, tr.ConfigHome)
+	}
+	if got, err := r.FindConfig(filepath.Join("app", "app.conf")); err != nil || got != tr.Path("opt/etc/xdg/app/app.conf") {
+		t.Errorf("FindConfig() = %v, %v", got, err)
+	}
+	if got, err := r.FindData(filepath.Join("app", "data.txt")); err != nil || got != tr.Path("usr/share/app/data.txt") {
+		t.Errorf("FindData() = %v, %v", got, err)
+	}
+
+	if err := os.MkdirAll(filepath.Join(r.ConfigHome(), "app"), 0700); err != nil {
+		t.Fatal(err)
+	}
+	if err := os.WriteFile(filepath.Join(r.ConfigHome(), "app", "app.conf"), []byte("user"), 0600); err != nil {
+		t.Fatal(err)
+	}
+	if got := string(tr.AssertConfigFile("app/app.conf")); got != "user" {
+		t.Errorf("AssertConfigFile() = %q, want %q", got, "user")
+	}
+	tr.AssertFileContent("home/.config/app/app.conf", "user")
+	tr.AssertNotExist("home/.cache/app")
+
+	fi, err := os.Stat(tr.RuntimeDir)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if perm := fi.Mode().Perm(); perm != 0700 {
+		t.Errorf("RuntimeDir mode = %#o, want 0700", perm)
+	}
+}
+
+func TestSetenv(t *testing.T) {
+	var dataHome string
+	t.Run("set", func(t *testing.T) {
+		tr := xdgtest.New(t)
+		tr.Setenv()
+		dataHome = tr.DataHome
+		if got := xdgbasedir.DataHome(); got != tr.DataHome {
+			t.Errorf("DataHome() = %v, want %v", got, tr.DataHome)
+		}
+	})
+	if got := os.Getenv(xdgbasedir.EnvDataHome); got == dataHome {
+		t.Errorf("%s was not restored", xdgbasedir.EnvDataHome)
+	}
+}
+
+func TestSetDefault(t *testing.T) {
+	prev := xdgbasedir.Default()
+	t.Run("set", func(t *testing.T) {
+		tr := xdgtest.New(t)
+		tr.SetDefault()
+		if got := xdgbasedir.StateHome(); got != tr.StateHome {
+			t.Errorf("StateHome() = %v, want %v", got, tr.StateHome)
+		}
+	})
+	if xdgbasedir.Default() != prev {
+		t.Error("default Resolver was not restored")
+	}
+}